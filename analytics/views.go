@@ -0,0 +1,104 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/harshvardha/blogs/internal/database"
+)
+
+// number of pending views which triggers a write before the next flush
+const maxPendingViews = 1000
+
+// a view of a blog by a reader within a dedup window
+type view struct {
+	blogID    uuid.UUID
+	viewerKey string
+	window    int64
+}
+
+// ViewRecorder collects the blog views in memory and writes them to the database in batches so that reading a blog stays fast
+// a reader viewing the same blog again within the dedup window is counted once
+type ViewRecorder struct {
+	db          *database.Queries
+	dedupWindow time.Duration
+	mu          sync.Mutex
+	pending     map[view]struct{}
+	full        chan struct{}
+}
+
+// NewViewRecorder creates the view recorder, views are only written while Run is running
+// the dedup window splits time into whole seconds so it must be at least a second long
+func NewViewRecorder(db *database.Queries, dedupWindow time.Duration) (*ViewRecorder, error) {
+	if dedupWindow < time.Second {
+		return nil, errors.New("view dedup window must be at least a second")
+	}
+	return &ViewRecorder{
+		db:          db,
+		dedupWindow: dedupWindow,
+		pending:     make(map[view]struct{}),
+		full:        make(chan struct{}, 1),
+	}, nil
+}
+
+// Record counts a view of the blog by the reader, the reader is the user id or an anonymous session key
+func (v *ViewRecorder) Record(blogID uuid.UUID, viewerKey string) {
+	v.mu.Lock()
+	v.pending[view{
+		blogID:    blogID,
+		viewerKey: viewerKey,
+		window:    time.Now().Unix() / int64(v.dedupWindow.Seconds()),
+	}] = struct{}{}
+	pendingCount := len(v.pending)
+	v.mu.Unlock()
+
+	if pendingCount >= maxPendingViews {
+		select {
+		case v.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Run writes the pending views every interval, or sooner when too many are pending, until the context is cancelled
+func (v *ViewRecorder) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			v.flush(context.Background())
+			return
+		case <-ticker.C:
+		case <-v.full:
+		}
+		v.flush(ctx)
+	}
+}
+
+// function to write the pending views in a single insert, views already counted in their window are skipped by the database
+func (v *ViewRecorder) flush(ctx context.Context) {
+	v.mu.Lock()
+	pending := v.pending
+	v.pending = make(map[view]struct{})
+	v.mu.Unlock()
+	if len(pending) == 0 {
+		return
+	}
+
+	params := database.RecordBlogViewsParams{}
+	for pendingView := range pending {
+		params.BlogIds = append(params.BlogIds, pendingView.blogID)
+		params.ViewerKeys = append(params.ViewerKeys, pendingView.viewerKey)
+		params.ViewWindows = append(params.ViewWindows, pendingView.window)
+	}
+	err := v.db.RecordBlogViews(ctx, params)
+	if err != nil {
+		log.Println("Error recording blog views: ", err)
+	}
+}
@@ -0,0 +1,174 @@
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/harshvardha/blogs/internal/database"
+	"github.com/harshvardha/blogs/utility"
+)
+
+// number of days covered by the analytics when not asked for
+const (
+	defaultAnalyticsDays = 30
+	maxAnalyticsDays     = 365
+)
+
+// function to count a view of a blog, authors reading their own blogs are not counted
+func (apiCfg *ApiConfig) recordView(r *http.Request, user database.User, blogID uuid.UUID, authorID uuid.UUID) {
+	if apiCfg.Views == nil || user.ID == authorID {
+		return
+	}
+	apiCfg.Views.Record(blogID, viewerKey(r, user))
+}
+
+// function to identify the reader of a blog, anonymous readers are identified by their address and browser
+func viewerKey(r *http.Request, user database.User) string {
+	if user.ID != uuid.Nil {
+		return "user:" + user.ID.String()
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	session := sha256.Sum256([]byte(host + "|" + r.UserAgent()))
+	return "session:" + hex.EncodeToString(session[:16])
+}
+
+// function to read the number of days the analytics should cover from the query params
+func parseAnalyticsDays(r *http.Request) int32 {
+	days, err := strconv.Atoi(r.URL.Query().Get("days"))
+	if err != nil || days <= 0 {
+		return defaultAnalyticsDays
+	}
+	if days > maxAnalyticsDays {
+		return maxAnalyticsDays
+	}
+	return int32(days)
+}
+
+// function to check if the analytics should be exported as csv, the refreshed access token is sent in a header since csv has no place for it
+func wantsCSV(w http.ResponseWriter, r *http.Request, newAccessToken string) bool {
+	if r.URL.Query().Get("format") != "csv" {
+		return false
+	}
+	if len(newAccessToken) > 0 {
+		w.Header().Set("X-Access-Token", newAccessToken)
+	}
+	return true
+}
+
+// handler function to get the views, unique readers, likes and comments of every blog of the user over the last days
+func (apiCfg *ApiConfig) HandleGetBlogAnalytics(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	days := parseAnalyticsDays(r)
+	blogStats, err := apiCfg.DB.GetAuthorBlogStats(r.Context(), database.GetAuthorBlogStatsParams{
+		AuthorID: user.ID,
+		Days:     days,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// exporting as csv
+	if wantsCSV(w, r, newAccessToken) {
+		records := [][]string{{"blog_id", "title", "published_at", "views", "unique_readers", "likes", "comments"}}
+		for _, stats := range blogStats {
+			records = append(records, []string{
+				stats.ID.String(),
+				stats.Title,
+				stats.CreatedAt.Format("2006-01-02"),
+				strconv.FormatInt(stats.Views, 10),
+				strconv.FormatInt(stats.UniqueReaders, 10),
+				strconv.FormatInt(stats.Likes, 10),
+				strconv.FormatInt(stats.Comments, 10),
+			})
+		}
+		utility.RespondWithCSV(w, "blog-analytics.csv", records)
+		return
+	}
+
+	// creating response
+	analytics := []BlogAnalyticsResponse{}
+	for _, stats := range blogStats {
+		analytics = append(analytics, BlogAnalyticsResponse{
+			BlogID:        stats.ID,
+			Title:         stats.Title,
+			PublishedAt:   stats.CreatedAt,
+			Views:         stats.Views,
+			UniqueReaders: stats.UniqueReaders,
+			Likes:         stats.Likes,
+			Comments:      stats.Comments,
+			AccessToken:   newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, analytics)
+}
+
+// handler function to get the views, unique readers, likes, comments and new followers of the user per day
+// passing a blogID narrows the views, readers, likes and comments to that blog
+func (apiCfg *ApiConfig) HandleGetDailyAnalytics(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	// checking if the blog belongs to the user
+	blogID := uuid.NullUUID{}
+	if blogIDString := r.URL.Query().Get("blogID"); len(blogIDString) > 0 {
+		id, err := uuid.Parse(blogIDString)
+		if err != nil {
+			utility.RespondWithError(w, http.StatusBadRequest, "Invalid blog id")
+			return
+		}
+		authorID, err := apiCfg.DB.GetBlogAuthorId(r.Context(), id)
+		if err != nil || authorID != user.ID {
+			utility.RespondWithError(w, http.StatusNotFound, "Blog not found")
+			return
+		}
+		blogID = uuid.NullUUID{UUID: id, Valid: true}
+	}
+
+	// fetching the stats of every day
+	dailyStats, err := apiCfg.DB.GetAuthorDailyStats(r.Context(), database.GetAuthorDailyStatsParams{
+		AuthorID: user.ID,
+		BlogID:   blogID,
+		Days:     parseAnalyticsDays(r),
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// exporting as csv
+	if wantsCSV(w, r, newAccessToken) {
+		records := [][]string{{"day", "views", "unique_readers", "likes", "comments", "new_followers"}}
+		for _, stats := range dailyStats {
+			records = append(records, []string{
+				stats.Day.Format("2006-01-02"),
+				strconv.FormatInt(stats.Views, 10),
+				strconv.FormatInt(stats.UniqueReaders, 10),
+				strconv.FormatInt(stats.Likes, 10),
+				strconv.FormatInt(stats.Comments, 10),
+				strconv.FormatInt(stats.NewFollowers, 10),
+			})
+		}
+		utility.RespondWithCSV(w, "daily-analytics.csv", records)
+		return
+	}
+
+	// creating response
+	analytics := []DailyAnalyticsResponse{}
+	for _, stats := range dailyStats {
+		analytics = append(analytics, DailyAnalyticsResponse{
+			Day:           stats.Day.Format("2006-01-02"),
+			Views:         stats.Views,
+			UniqueReaders: stats.UniqueReaders,
+			Likes:         stats.Likes,
+			Comments:      stats.Comments,
+			NewFollowers:  stats.NewFollowers,
+			AccessToken:   newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, analytics)
+}
@@ -38,6 +38,13 @@ func (apiCfg *ApiConfig) HandleUserRegistration(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	// checking if the username can be taken
+	err = apiCfg.checkUsernameAvailable(r.Context(), params.Username, uuid.Nil)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+
 	// creating new user if not exist already
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(params.Password), bcrypt.DefaultCost)
 	if err != nil {
@@ -50,6 +57,10 @@ func (apiCfg *ApiConfig) HandleUserRegistration(w http.ResponseWriter, r *http.R
 		HashedPassword: string(hashedPassword),
 	})
 	if err != nil {
+		if isUniqueViolation(err) {
+			utility.RespondWithError(w, http.StatusConflict, "Username is already taken")
+			return
+		}
 		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
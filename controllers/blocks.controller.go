@@ -0,0 +1,194 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/harshvardha/blogs/internal/database"
+	"github.com/harshvardha/blogs/utility"
+)
+
+// function to check if either of the users blocked the other, anonymous users are never blocked
+func (apiCfg *ApiConfig) isBlockedEitherWay(ctx context.Context, userID uuid.UUID, otherUserID uuid.UUID) (bool, error) {
+	if userID == uuid.Nil || otherUserID == uuid.Nil {
+		return false, nil
+	}
+
+	return apiCfg.DB.IsBlockedEitherWay(ctx, database.IsBlockedEitherWayParams{
+		UserID:      userID,
+		OtherUserID: otherUserID,
+	})
+}
+
+// handler function to block a user
+// blocked users cannot follow, comment on the blogs of, like the content of or mention the user and both stop seeing each other
+func (apiCfg *ApiConfig) HandleBlockUser(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	// fetching the user id from url params
+	blockedUserID, ok := apiCfg.parseExistingUserID(w, r)
+	if !ok {
+		return
+	}
+	if blockedUserID == user.ID {
+		utility.RespondWithError(w, http.StatusBadRequest, "You cannot block yourself")
+		return
+	}
+
+	// blocking the user and removing the follows and follow requests between the two users
+	err := apiCfg.DB.BlockUser(r.Context(), database.BlockUserParams{
+		BlockerID: user.ID,
+		BlockedID: blockedUserID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	err = apiCfg.DB.DeleteFollowsBetween(r.Context(), database.DeleteFollowsBetweenParams{
+		UserID:      user.ID,
+		OtherUserID: blockedUserID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	err = apiCfg.DB.DeleteFollowRequestsBetween(r.Context(), database.DeleteFollowRequestsBetweenParams{
+		UserID:      user.ID,
+		OtherUserID: blockedUserID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, EmptyResponse{
+		AccessToken: newAccessToken,
+	})
+}
+
+// handler function to unblock a user, the follows removed while blocking are not restored
+func (apiCfg *ApiConfig) HandleUnblockUser(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	// fetching the user id from url params
+	blockedUserID, err := uuid.Parse(r.PathValue("userID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid user id")
+		return
+	}
+
+	err = apiCfg.DB.UnblockUser(r.Context(), database.UnblockUserParams{
+		BlockerID: user.ID,
+		BlockedID: blockedUserID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, EmptyResponse{
+		AccessToken: newAccessToken,
+	})
+}
+
+// handler function to get the users blocked by the user
+func (apiCfg *ApiConfig) HandleGetBlockedUsers(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	limit, offset := utility.ParsePagination(r)
+	blockedUsers, err := apiCfg.DB.GetBlockedUsers(r.Context(), database.GetBlockedUsersParams{
+		BlockerID: user.ID,
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	response := []RestrictedUserResponse{}
+	for _, blockedUser := range blockedUsers {
+		response = append(response, RestrictedUserResponse{
+			ID:          blockedUser.ID,
+			Username:    blockedUser.Username,
+			DisplayName: blockedUser.DisplayName,
+			AvatarURL:   blockedUser.AvatarUrl,
+			Since:       blockedUser.CreatedAt,
+			AccessToken: newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, response)
+}
+
+// handler function to mute a user, the content of muted users is hidden from the feed and they do not notify the user
+func (apiCfg *ApiConfig) HandleMuteUser(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	// fetching the user id from url params
+	mutedUserID, ok := apiCfg.parseExistingUserID(w, r)
+	if !ok {
+		return
+	}
+	if mutedUserID == user.ID {
+		utility.RespondWithError(w, http.StatusBadRequest, "You cannot mute yourself")
+		return
+	}
+
+	err := apiCfg.DB.MuteUser(r.Context(), database.MuteUserParams{
+		MuterID: user.ID,
+		MutedID: mutedUserID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, EmptyResponse{
+		AccessToken: newAccessToken,
+	})
+}
+
+// handler function to unmute a user
+func (apiCfg *ApiConfig) HandleUnmuteUser(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	// fetching the user id from url params
+	mutedUserID, err := uuid.Parse(r.PathValue("userID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid user id")
+		return
+	}
+
+	err = apiCfg.DB.UnmuteUser(r.Context(), database.UnmuteUserParams{
+		MuterID: user.ID,
+		MutedID: mutedUserID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, EmptyResponse{
+		AccessToken: newAccessToken,
+	})
+}
+
+// handler function to get the users muted by the user
+func (apiCfg *ApiConfig) HandleGetMutedUsers(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	limit, offset := utility.ParsePagination(r)
+	mutedUsers, err := apiCfg.DB.GetMutedUsers(r.Context(), database.GetMutedUsersParams{
+		MuterID: user.ID,
+		Limit:   limit,
+		Offset:  offset,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	response := []RestrictedUserResponse{}
+	for _, mutedUser := range mutedUsers {
+		response = append(response, RestrictedUserResponse{
+			ID:          mutedUser.ID,
+			Username:    mutedUser.Username,
+			DisplayName: mutedUser.DisplayName,
+			AvatarURL:   mutedUser.AvatarUrl,
+			Since:       mutedUser.CreatedAt,
+			AccessToken: newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, response)
+}
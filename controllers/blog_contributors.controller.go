@@ -0,0 +1,337 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/harshvardha/blogs/internal/database"
+	"github.com/harshvardha/blogs/notifications"
+	"github.com/harshvardha/blogs/utility"
+)
+
+// roles of the users working on a blog
+// co-authors are listed as authors and can edit everything, editors can edit the blog but not its visibility
+// reviewers can read the blog whatever its visibility, only the owner can delete the blog and manage its contributors
+const (
+	BlogRoleOwner    = "owner"
+	BlogRoleCoAuthor = "co-author"
+	BlogRoleEditor   = "editor"
+	BlogRoleReviewer = "reviewer"
+)
+
+// states of a blog contribution
+const (
+	BlogContributorInvited  = "invited"
+	BlogContributorAccepted = "accepted"
+)
+
+// function to check if the role can be given to a contributor
+func isValidBlogContributorRole(role string) bool {
+	return role == BlogRoleCoAuthor || role == BlogRoleEditor || role == BlogRoleReviewer
+}
+
+// function to get the role of the user on a blog, users who are not contributors have no role
+func (apiCfg *ApiConfig) blogRole(ctx context.Context, blogID uuid.UUID, userID uuid.UUID) (string, error) {
+	role, err := apiCfg.DB.GetBlogRole(ctx, database.GetBlogRoleParams{
+		BlogID: blogID,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", &requestError{http.StatusNotFound, "Blog not found"}
+		}
+		return "", err
+	}
+	return role, nil
+}
+
+// function to check if the role allows editing the blog
+func canEditBlog(role string) bool {
+	return role == BlogRoleOwner || role == BlogRoleCoAuthor || role == BlogRoleEditor
+}
+
+// function to check if the role allows changing who can see the blog
+func canChangeBlogVisibility(role string) bool {
+	return role == BlogRoleOwner || role == BlogRoleCoAuthor
+}
+
+// function to check if the role allows deleting the blog
+func canDeleteBlog(role string) bool {
+	return role == BlogRoleOwner
+}
+
+// function to get the owner and the co-authors of a blog
+func (apiCfg *ApiConfig) blogAuthors(ctx context.Context, blogID uuid.UUID) ([]BlogAuthor, error) {
+	blogAuthors, err := apiCfg.DB.GetBlogAuthors(ctx, blogID)
+	if err != nil {
+		return nil, err
+	}
+
+	authors := []BlogAuthor{}
+	for _, author := range blogAuthors {
+		authors = append(authors, BlogAuthor{
+			UserID:      author.ID,
+			Username:    author.Username,
+			DisplayName: author.DisplayName,
+			AvatarURL:   author.AvatarUrl,
+			Role:        author.Role,
+		})
+	}
+	return authors, nil
+}
+
+// function to parse the blog id from url params and check that the user owns the blog, it responds on failure
+func (apiCfg *ApiConfig) parseOwnedBlogID(w http.ResponseWriter, r *http.Request, user database.User) (uuid.UUID, bool) {
+	blogID, err := uuid.Parse(r.PathValue("blogID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid blog id")
+		return uuid.Nil, false
+	}
+	role, err := apiCfg.blogRole(r.Context(), blogID, user.ID)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return uuid.Nil, false
+	}
+	if role != BlogRoleOwner {
+		utility.RespondWithError(w, http.StatusUnauthorized, "Only the owner can manage the contributors of the blog")
+		return uuid.Nil, false
+	}
+	return blogID, true
+}
+
+// handler function to invite a user to contribute to a blog, inviting a contributor again changes their role
+func (apiCfg *ApiConfig) HandleInviteBlogContributor(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	blogID, ok := apiCfg.parseOwnedBlogID(w, r, user)
+	if !ok {
+		return
+	}
+
+	// decoding the request body
+	decoder := json.NewDecoder(r.Body)
+	params := BlogContributorRequest{}
+	err := decoder.Decode(&params)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid contributor details")
+		return
+	}
+	if !isValidBlogContributorRole(params.Role) {
+		utility.RespondWithError(w, http.StatusBadRequest, "Role must be co-author, editor or reviewer")
+		return
+	}
+	if params.UserID == user.ID {
+		utility.RespondWithError(w, http.StatusBadRequest, "You already own this blog")
+		return
+	}
+
+	// checking if the invited user exist and can be invited
+	invitedUser, err := apiCfg.DB.GetUserById(r.Context(), params.UserID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusNotFound, "User does not exist")
+		return
+	}
+	blocked, err := apiCfg.isBlockedEitherWay(r.Context(), user.ID, invitedUser.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if blocked {
+		utility.RespondWithError(w, http.StatusForbidden, "You cannot invite this user")
+		return
+	}
+
+	// inviting the user
+	contributor, err := apiCfg.DB.InviteBlogContributor(r.Context(), database.InviteBlogContributorParams{
+		BlogID:    blogID,
+		UserID:    invitedUser.ID,
+		Role:      params.Role,
+		InvitedBy: uuid.NullUUID{UUID: user.ID, Valid: true},
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if contributor.Status == BlogContributorInvited {
+		apiCfg.notify(r.Context(), notifications.Notification{
+			UserID:     invitedUser.ID,
+			ActorID:    user.ID,
+			Type:       notifications.TypeBlogInvite,
+			EntityType: notifications.EntityBlog,
+			EntityID:   blogID,
+		})
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, BlogContributorResponse{
+		UserID:      invitedUser.ID,
+		Username:    invitedUser.Username,
+		DisplayName: invitedUser.DisplayName,
+		AvatarURL:   invitedUser.AvatarUrl,
+		Role:        contributor.Role,
+		Status:      contributor.Status,
+		Since:       contributor.CreatedAt,
+		AccessToken: newAccessToken,
+	})
+}
+
+// handler function to remove a contributor from a blog or take back their invite
+func (apiCfg *ApiConfig) HandleRemoveBlogContributor(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	blogID, ok := apiCfg.parseOwnedBlogID(w, r, user)
+	if !ok {
+		return
+	}
+	contributorID, err := uuid.Parse(r.PathValue("userID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid user id")
+		return
+	}
+
+	removed, err := apiCfg.DB.RemoveBlogContributor(r.Context(), database.RemoveBlogContributorParams{
+		BlogID: blogID,
+		UserID: contributorID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if removed == 0 {
+		utility.RespondWithError(w, http.StatusNotFound, "User is not a contributor of the blog")
+		return
+	}
+	apiCfg.retractNotification(r.Context(), notifications.Notification{
+		UserID:   contributorID,
+		ActorID:  user.ID,
+		Type:     notifications.TypeBlogInvite,
+		EntityID: blogID,
+	})
+
+	utility.RespondWithJson(w, http.StatusOK, EmptyResponse{
+		AccessToken: newAccessToken,
+	})
+}
+
+// handler function to get the contributors and pending invites of a blog, any contributor can see them
+func (apiCfg *ApiConfig) HandleGetBlogContributors(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	blogID, err := uuid.Parse(r.PathValue("blogID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid blog id")
+		return
+	}
+	role, err := apiCfg.blogRole(r.Context(), blogID, user.ID)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+	if len(role) == 0 {
+		utility.RespondWithError(w, http.StatusNotFound, "Blog not found")
+		return
+	}
+
+	// fetching the contributors
+	blogContributors, err := apiCfg.DB.GetBlogContributors(r.Context(), blogID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	contributors := []BlogContributorResponse{}
+	for _, contributor := range blogContributors {
+		contributors = append(contributors, BlogContributorResponse{
+			UserID:      contributor.ID,
+			Username:    contributor.Username,
+			DisplayName: contributor.DisplayName,
+			AvatarURL:   contributor.AvatarUrl,
+			Role:        contributor.Role,
+			Status:      contributor.Status,
+			Since:       contributor.CreatedAt,
+			AccessToken: newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, contributors)
+}
+
+// handler function to get the blog invites the user has not accepted yet
+func (apiCfg *ApiConfig) HandleGetBlogInvites(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	pendingInvites, err := apiCfg.DB.GetBlogInvites(r.Context(), user.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	invites := []BlogInviteResponse{}
+	for _, invite := range pendingInvites {
+		invites = append(invites, BlogInviteResponse{
+			BlogID:      invite.ID,
+			BlogTitle:   invite.Title,
+			AuthorID:    invite.AuthorID,
+			AuthorName:  invite.AuthorName,
+			Role:        invite.Role,
+			InvitedAt:   invite.CreatedAt,
+			AccessToken: newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, invites)
+}
+
+// handler function to accept an invite to contribute to a blog
+func (apiCfg *ApiConfig) HandleAcceptBlogInvite(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	blogID, err := uuid.Parse(r.PathValue("blogID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid blog id")
+		return
+	}
+
+	contributor, err := apiCfg.DB.AcceptBlogInvite(r.Context(), database.AcceptBlogInviteParams{
+		BlogID: blogID,
+		UserID: user.ID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utility.RespondWithError(w, http.StatusNotFound, "Invite not found")
+			return
+		}
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, BlogContributorResponse{
+		UserID:      user.ID,
+		Username:    user.Username,
+		DisplayName: user.DisplayName,
+		AvatarURL:   user.AvatarUrl,
+		Role:        contributor.Role,
+		Status:      contributor.Status,
+		Since:       contributor.CreatedAt,
+		AccessToken: newAccessToken,
+	})
+}
+
+// handler function to stop contributing to a blog or decline an invite to it
+func (apiCfg *ApiConfig) HandleLeaveBlog(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	blogID, err := uuid.Parse(r.PathValue("blogID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid blog id")
+		return
+	}
+
+	left, err := apiCfg.DB.RemoveBlogContributor(r.Context(), database.RemoveBlogContributorParams{
+		BlogID: blogID,
+		UserID: user.ID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if left == 0 {
+		utility.RespondWithError(w, http.StatusNotFound, "You are not a contributor of the blog")
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, EmptyResponse{
+		AccessToken: newAccessToken,
+	})
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
@@ -8,10 +9,30 @@ import (
 	"net/http"
 
 	"github.com/google/uuid"
+	"github.com/harshvardha/blogs/filters"
 	"github.com/harshvardha/blogs/internal/database"
+	"github.com/harshvardha/blogs/notifications"
 	"github.com/harshvardha/blogs/utility"
 )
 
+// visibility of a blog
+// unlisted blogs can only be reached by their link, followers only blogs are visible to the followers of the author and private blogs only to the author
+const (
+	BlogVisibilityPublic    = "public"
+	BlogVisibilityUnlisted  = "unlisted"
+	BlogVisibilityFollowers = "followers"
+	BlogVisibilityPrivate   = "private"
+)
+
+// function to check if the visibility is one of the known visibilities
+func isValidBlogVisibility(visibility string) bool {
+	switch visibility {
+	case BlogVisibilityPublic, BlogVisibilityUnlisted, BlogVisibilityFollowers, BlogVisibilityPrivate:
+		return true
+	}
+	return false
+}
+
 // handler function to create a new blog
 func (apiCfg *ApiConfig) HandleCreateBlog(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
 	// decoding the request body
@@ -22,37 +43,129 @@ func (apiCfg *ApiConfig) HandleCreateBlog(w http.ResponseWriter, r *http.Request
 		utility.RespondWithError(w, http.StatusBadRequest, "Invalid Blog details")
 		return
 	}
+	if len(params.Visibility) == 0 {
+		params.Visibility = BlogVisibilityPublic
+	}
+	if !isValidBlogVisibility(params.Visibility) {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid visibility")
+		return
+	}
 
-	// creating a new blog
-	categoryId, err := apiCfg.DB.GetCategoryIdByName(r.Context(), params.Category)
+	// checking if the user can write in the publication, blogs of a publication use its categories
+	publicationID, err := apiCfg.resolvePublication(r.Context(), params.Publication)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+	if publicationID.Valid {
+		role, err := apiCfg.publicationRole(r.Context(), publicationID.UUID, user.ID)
+		if err != nil {
+			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if len(role) == 0 {
+			utility.RespondWithError(w, http.StatusForbidden, "Only the members of the publication can write in it")
+			return
+		}
+	}
+
+	// checking if the category exist or not, blogs in categories which require review start unpublished
+	categoryId, err := apiCfg.DB.GetCategoryIdByName(r.Context(), database.GetCategoryIdByNameParams{
+		CategoryName:  params.Category,
+		PublicationID: publicationID,
+	})
 	if err != nil {
 		utility.RespondWithError(w, http.StatusBadRequest, "Invalid category")
 		return
 	}
+	requiresReview, err := apiCfg.categoryRequiresReview(r.Context(), categoryId)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// running the blog through the content filters
+	verdict, err := apiCfg.checkContent(r.Context(), filters.Content{
+		Kind:     filters.KindBlog,
+		AuthorID: user.ID,
+		Title:    params.Title,
+		Body:     params.Content,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if verdict.Action == filters.ActionReject {
+		utility.RespondWithError(w, http.StatusUnprocessableEntity, "Blog rejected: "+verdict.Reason)
+		return
+	}
+
+	// creating a new blog
 	newBlog, err := apiCfg.DB.CreateBlog(r.Context(), database.CreateBlogParams{
-		Title:        params.Title,
-		ThumbnailUrl: params.ThumbnailURL,
-		Content:      params.Content,
-		Category:     categoryId,
-		AuthorID:     user.ID,
+		Title:         params.Title,
+		ThumbnailUrl:  params.ThumbnailURL,
+		Content:       params.Content,
+		Category:      categoryId,
+		AuthorID:      user.ID,
+		Visibility:    params.Visibility,
+		IsPublished:   !requiresReview,
+		PublicationID: publicationID,
 	})
 	if err != nil {
 		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	apiCfg.recordContent(filters.Content{
+		Kind:     filters.KindBlog,
+		EntityID: newBlog.ID,
+		AuthorID: user.ID,
+		Title:    newBlog.Title,
+		Body:     newBlog.Content,
+	}, true)
+	if verdict.Action != filters.ActionAllow {
+		err = apiCfg.DB.SetBlogModerationStatus(r.Context(), database.SetBlogModerationStatusParams{
+			ModerationStatus: blogModerationStatusForVerdict(verdict, BlogModerationVisible),
+			ID:               newBlog.ID,
+		})
+		if err != nil {
+			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		err = apiCfg.recordContentFlag(r.Context(), filters.KindBlog, newBlog.ID, user.ID, verdict)
+		if err != nil {
+			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	// saving the mentions in the blog, shadow hidden and unpublished blogs do not notify anyone
+	mentions := []ResponseMention{}
+	if verdict.Action != filters.ActionShadowHide && newBlog.IsPublished {
+		mentions, err = apiCfg.syncMentions(r.Context(), MentionSourceBlog, newBlog.ID, user.ID, newBlog.Content)
+		if err != nil {
+			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
 
 	// responding with the new created blog
 	utility.RespondWithJson(w, http.StatusCreated, ResponseBlog{
-		ID:           newBlog.ID,
-		Title:        newBlog.Title,
-		AuthorName:   user.Username,
-		ThumbnailURL: newBlog.ThumbnailUrl,
-		Content:      newBlog.Content,
-		Category:     params.Category,
-		Likes:        0,
-		CreatedAt:    newBlog.CreatedAt,
-		UpdatedAt:    newBlog.UpdatedAt,
-		AccessToken:  newAccessToken,
+		ID:              newBlog.ID,
+		Title:           newBlog.Title,
+		AuthorName:      user.Username,
+		ThumbnailURL:    newBlog.ThumbnailUrl,
+		Content:         newBlog.Content,
+		Category:        params.Category,
+		Visibility:      newBlog.Visibility,
+		Likes:           0,
+		RenderedContent: renderMentions(newBlog.Content, mentions),
+		Mentions:        mentions,
+		Draft:           !newBlog.IsPublished,
+		ReviewStatus:    newBlog.ReviewStatus,
+		PublicationID:   publicationIDOrNil(newBlog.PublicationID),
+		CreatedAt:       newBlog.CreatedAt,
+		UpdatedAt:       newBlog.UpdatedAt,
+		AccessToken:     newAccessToken,
 	})
 }
 
@@ -77,8 +190,13 @@ func (apiCfg *ApiConfig) HandleEditBlog(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
-	// checking if the user id authorized to edit the blog
-	if blogExist.AuthorID != user.ID {
+	// checking if the user id authorized to edit the blog, the owner, co-authors and editors can
+	role, err := apiCfg.blogRole(r.Context(), blogID, user.ID)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+	if !canEditBlog(role) {
 		utility.RespondWithError(w, http.StatusUnauthorized, "You are not authorized to edit this blog")
 		return
 	}
@@ -99,6 +217,7 @@ func (apiCfg *ApiConfig) HandleEditBlog(w http.ResponseWriter, r *http.Request,
 		ThumbnailUrl: blogExist.ThumbnailUrl,
 		Content:      blogExist.Content,
 		Category:     blogExist.Category,
+		Visibility:   blogExist.Visibility,
 		ID:           blogID,
 	}
 	fmt.Println("before updated blog: ", updateBlog)
@@ -112,20 +231,126 @@ func (apiCfg *ApiConfig) HandleEditBlog(w http.ResponseWriter, r *http.Request,
 		updateBlog.Content = params.Content
 	}
 	if len(params.Category) > 0 {
-		categoryID, err := apiCfg.DB.GetCategoryIdByName(r.Context(), params.Category)
+		categoryID, err := apiCfg.DB.GetCategoryIdByName(r.Context(), database.GetCategoryIdByNameParams{
+			CategoryName:  params.Category,
+			PublicationID: blogExist.PublicationID,
+		})
 		if err != nil {
 			utility.RespondWithError(w, http.StatusBadRequest, "Invalid category")
 			return
 		}
 		updateBlog.Category = categoryID
 	}
+	if len(params.Visibility) > 0 {
+		if !isValidBlogVisibility(params.Visibility) {
+			utility.RespondWithError(w, http.StatusBadRequest, "Invalid visibility")
+			return
+		}
+		if params.Visibility != blogExist.Visibility && !canChangeBlogVisibility(role) {
+			utility.RespondWithError(w, http.StatusUnauthorized, "Only the authors can change the visibility of this blog")
+			return
+		}
+		updateBlog.Visibility = params.Visibility
+	}
 	fmt.Println("after update blog: ", updateBlog)
+
+	// running the updated blog through the content filters
+	verdict, err := apiCfg.checkContent(r.Context(), filters.Content{
+		Kind:     filters.KindBlog,
+		EntityID: blogID,
+		AuthorID: user.ID,
+		Title:    updateBlog.Title,
+		Body:     updateBlog.Content,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if verdict.Action == filters.ActionReject {
+		utility.RespondWithError(w, http.StatusUnprocessableEntity, "Blog rejected: "+verdict.Reason)
+		return
+	}
+
 	// updating the blog
 	updatedBlog, err := apiCfg.DB.EditBlog(r.Context(), updateBlog)
 	if err != nil {
 		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	apiCfg.recordContent(filters.Content{
+		Kind:     filters.KindBlog,
+		EntityID: blogID,
+		AuthorID: user.ID,
+		Title:    updatedBlog.Title,
+		Body:     updatedBlog.Content,
+	}, false)
+
+	// moving a published blog into a category which requires review unpublishes it until the review is approved
+	if updateBlog.Category != blogExist.Category {
+		requiresReview, err := apiCfg.categoryRequiresReview(r.Context(), updateBlog.Category)
+		if err != nil {
+			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if needsReviewAfterCategoryChange(blogExist.IsPublished, blogExist.ReviewStatus, requiresReview) {
+			updatedBlog, err = apiCfg.holdBlogForReview(r.Context(), blogID, user.ID)
+			if err != nil {
+				utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+		}
+	}
+
+	// an approval covers the reviewed text, changing it sends the unpublished blog back for review
+	if updateBlog.Title != blogExist.Title || updateBlog.Content != blogExist.Content {
+		reset, err := apiCfg.DB.ResetBlogReview(r.Context(), blogID)
+		if err != nil {
+			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if reset > 0 {
+			err = apiCfg.DB.ClearReviewDecisions(r.Context(), blogID)
+			if err != nil {
+				utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			err = apiCfg.notifyReviewers(r.Context(), blogID, user.ID)
+			if err != nil {
+				utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			updatedBlog.ReviewStatus = ReviewStatusPending
+		}
+	}
+	moderationStatus := blogExist.ModerationStatus
+	if verdict.Action != filters.ActionAllow {
+		moderationStatus = blogModerationStatusForVerdict(verdict, blogExist.ModerationStatus)
+		err = apiCfg.DB.SetBlogModerationStatus(r.Context(), database.SetBlogModerationStatusParams{
+			ModerationStatus: moderationStatus,
+			ID:               blogID,
+		})
+		if err != nil {
+			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		err = apiCfg.recordContentFlag(r.Context(), filters.KindBlog, blogID, user.ID, verdict)
+		if err != nil {
+			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	// updating the mentions in the blog, only newly mentioned users are notified
+	var mentions []ResponseMention
+	if moderationStatus == BlogModerationShadowHidden || moderationStatus == BlogModerationHidden || !updatedBlog.IsPublished {
+		mentions, err = apiCfg.getMentions(r.Context(), MentionSourceBlog, blogID)
+	} else {
+		mentions, err = apiCfg.syncMentions(r.Context(), MentionSourceBlog, blogID, user.ID, updatedBlog.Content)
+	}
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
 	updatedCategoryName, err := apiCfg.DB.GetCategoryNameById(r.Context(), updatedBlog.Category)
 	if err != nil {
 		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
@@ -136,17 +361,35 @@ func (apiCfg *ApiConfig) HandleEditBlog(w http.ResponseWriter, r *http.Request,
 		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	authorName, err := apiCfg.DB.GetAuthorNameByBlogId(r.Context(), blogID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	authors, err := apiCfg.blogAuthors(r.Context(), blogID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
 	utility.RespondWithJson(w, http.StatusOK, ResponseBlog{
-		ID:           updatedBlog.ID,
-		Title:        updatedBlog.Title,
-		AuthorName:   user.Username,
-		ThumbnailURL: updatedBlog.ThumbnailUrl,
-		Content:      updatedBlog.Content,
-		Category:     updatedCategoryName,
-		Likes:        noOfLikes,
-		CreatedAt:    updatedBlog.CreatedAt,
-		UpdatedAt:    updatedBlog.UpdatedAt,
-		AccessToken:  newAccessToken,
+		ID:              updatedBlog.ID,
+		Title:           updatedBlog.Title,
+		AuthorID:        updatedBlog.AuthorID,
+		AuthorName:      authorName,
+		ThumbnailURL:    updatedBlog.ThumbnailUrl,
+		Content:         updatedBlog.Content,
+		Category:        updatedCategoryName,
+		Visibility:      updatedBlog.Visibility,
+		Likes:           noOfLikes,
+		RenderedContent: renderMentions(updatedBlog.Content, mentions),
+		Mentions:        mentions,
+		Authors:         authors,
+		Draft:           !updatedBlog.IsPublished,
+		ReviewStatus:    updatedBlog.ReviewStatus,
+		PublicationID:   publicationIDOrNil(updatedBlog.PublicationID),
+		CreatedAt:       updatedBlog.CreatedAt,
+		UpdatedAt:       updatedBlog.UpdatedAt,
+		AccessToken:     newAccessToken,
 	})
 }
 
@@ -163,19 +406,32 @@ func (apiCfg *ApiConfig) HandleDeleteBlog(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	// checking if the blog exist or not
-	blogExist, err := apiCfg.DB.GetBlogById(r.Context(), blogID)
+	// checking if the blog exist and the user is authorized to delete it, only the owner can
+	role, err := apiCfg.blogRole(r.Context(), blogID, user.ID)
 	if err != nil {
-		utility.RespondWithError(w, http.StatusNotFound, err.Error())
+		respondWithRequestError(w, err)
 		return
 	}
-
-	// checking if the user is authorized to delete this blog
-	if blogExist.AuthorID != user.ID {
+	if !canDeleteBlog(role) {
 		utility.RespondWithError(w, http.StatusUnauthorized, "You are not authorized to delete this blog")
 		return
 	}
 
+	// deleting the mentions in the blog and its comments
+	err = apiCfg.DB.DeleteCommentMentionsByBlogId(r.Context(), blogID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	err = apiCfg.DB.DeleteMentionsBySource(r.Context(), database.DeleteMentionsBySourceParams{
+		SourceType: MentionSourceBlog,
+		SourceID:   blogID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	// deleting the blog
 	deletedBlog, err := apiCfg.DB.DeleteBlog(r.Context(), blogID)
 	if err != nil {
@@ -200,6 +456,7 @@ func (apiCfg *ApiConfig) HandleDeleteBlog(w http.ResponseWriter, r *http.Request
 		ThumbnailURL: deletedBlog.ThumbnailUrl,
 		Content:      deletedBlog.Content,
 		Category:     categoryName,
+		Visibility:   deletedBlog.Visibility,
 		Likes:        noOfLikes,
 		CreatedAt:    deletedBlog.CreatedAt,
 		UpdatedAt:    deletedBlog.UpdatedAt,
@@ -227,6 +484,21 @@ func (apiCfg *ApiConfig) HandleGetBlogById(w http.ResponseWriter, r *http.Reques
 		utility.RespondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+
+	// checking if the user can read the blog, contributors can read it whatever its visibility
+	role, err := apiCfg.blogRole(r.Context(), blogID, user.ID)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+	if len(role) == 0 {
+		err = apiCfg.checkBlogAccess(r.Context(), user, blog)
+		if err != nil {
+			respondWithRequestError(w, err)
+			return
+		}
+	}
+	apiCfg.recordView(r, user, blog.ID, blog.AuthorID)
 	authorName, err := apiCfg.DB.GetAuthorNameByBlogId(r.Context(), blogID)
 	if err != nil {
 		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
@@ -242,18 +514,56 @@ func (apiCfg *ApiConfig) HandleGetBlogById(w http.ResponseWriter, r *http.Reques
 		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	mentions, err := apiCfg.getMentions(r.Context(), MentionSourceBlog, blogID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// checking if the user liked the blog, anonymous readers have not liked anything
+	likedByMe := false
+	if user.ID != uuid.Nil {
+		_, err = apiCfg.DB.IsBlogLiked(r.Context(), database.IsBlogLikedParams{
+			UserID: user.ID,
+			BlogID: blogID,
+		})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		likedByMe = err == nil
+	}
+	series, err := apiCfg.blogSeriesInfo(r.Context(), user, blogID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	authors, err := apiCfg.blogAuthors(r.Context(), blogID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
 	utility.RespondWithJson(w, http.StatusOK, ResponseBlog{
-		ID:           blog.ID,
-		Title:        blog.Title,
-		AuthorID:     blog.AuthorID,
-		AuthorName:   authorName,
-		ThumbnailURL: blog.ThumbnailUrl,
-		Content:      blog.Content,
-		Category:     categoryName,
-		Likes:        noOfLikes,
-		CreatedAt:    blog.CreatedAt,
-		UpdatedAt:    blog.UpdatedAt,
-		AccessToken:  newAccessToken,
+		ID:              blog.ID,
+		Title:           blog.Title,
+		AuthorID:        blog.AuthorID,
+		AuthorName:      authorName,
+		ThumbnailURL:    blog.ThumbnailUrl,
+		Content:         blog.Content,
+		Category:        categoryName,
+		Visibility:      blog.Visibility,
+		Likes:           noOfLikes,
+		LikedByMe:       likedByMe,
+		RenderedContent: renderMentions(blog.Content, mentions),
+		Mentions:        mentions,
+		Authors:         authors,
+		Series:          series,
+		Draft:           !blog.IsPublished,
+		ReviewStatus:    blog.ReviewStatus,
+		PublicationID:   publicationIDOrNil(blog.PublicationID),
+		CreatedAt:       blog.CreatedAt,
+		UpdatedAt:       blog.UpdatedAt,
+		AccessToken:     newAccessToken,
 	})
 }
 
@@ -294,7 +604,10 @@ func (apiCfg *ApiConfig) HandleGetAllBlogs(w http.ResponseWriter, r *http.Reques
 			ThumbnailURL: blog.ThumbnailUrl,
 			Content:      blog.Content,
 			Category:     categoryName,
+			Visibility:   blog.Visibility,
 			Likes:        noOfLikes,
+			Draft:        !blog.IsPublished,
+			ReviewStatus: blog.ReviewStatus,
 			CreatedAt:    blog.CreatedAt,
 			UpdatedAt:    blog.UpdatedAt,
 			AccessToken:  newAccessToken,
@@ -317,6 +630,26 @@ func (apiCfg *ApiConfig) HandleLikeOrUnlikeBlog(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	// checking if the blog exist or not
+	blog, err := apiCfg.DB.GetBlogById(r.Context(), blogID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusNotFound, "Blog not found")
+		return
+	}
+	authorID := blog.AuthorID
+	blocked, err := apiCfg.isBlockedEitherWay(r.Context(), user.ID, authorID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	likeNotification := notifications.Notification{
+		UserID:     authorID,
+		ActorID:    user.ID,
+		Type:       notifications.TypeBlogLike,
+		EntityType: notifications.EntityBlog,
+		EntityID:   blogID,
+	}
+
 	// checking if the user has already liked the blog then unlike it
 	blogLiked, err := apiCfg.DB.IsBlogLiked(r.Context(), database.IsBlogLikedParams{
 		UserID: user.ID,
@@ -324,11 +657,25 @@ func (apiCfg *ApiConfig) HandleLikeOrUnlikeBlog(w http.ResponseWriter, r *http.R
 	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			// liking the blog
+			// liking the blog, users blocked by or blocking the author cannot like it
+			if blocked {
+				utility.RespondWithError(w, http.StatusForbidden, "You cannot like this blog")
+				return
+			}
+
+			// only the users who can read the blog can like it
+			err = apiCfg.checkBlogAccess(r.Context(), user, blog)
+			if err != nil {
+				respondWithRequestError(w, err)
+				return
+			}
 			err = apiCfg.DB.LikeBlog(r.Context(), database.LikeBlogParams{
 				UserID: user.ID,
 				BlogID: blogID,
 			})
+			if err == nil {
+				apiCfg.notify(r.Context(), likeNotification)
+			}
 		} else {
 			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
 			return
@@ -339,12 +686,16 @@ func (apiCfg *ApiConfig) HandleLikeOrUnlikeBlog(w http.ResponseWriter, r *http.R
 			UserID: user.ID,
 			BlogID: blogID,
 		})
+		if err == nil {
+			apiCfg.retractNotification(r.Context(), likeNotification)
+		}
 	}
 
 	if err != nil {
 		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	apiCfg.publishBlogLikes(r.Context(), blogID)
 
 	utility.RespondWithJson(w, http.StatusOK, EmptyResponse{
 		AccessToken: newAccessToken,
@@ -352,7 +703,8 @@ func (apiCfg *ApiConfig) HandleLikeOrUnlikeBlog(w http.ResponseWriter, r *http.R
 }
 
 // handler function to search for blogs
-func (apiCfg *ApiConfig) HandleSearchBlog(w http.ResponseWriter, r *http.Request) {
+// blogs of users blocked by or blocking the viewer are left out
+func (apiCfg *ApiConfig) HandleSearchBlog(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
 	// fetching the query param
 	searchQuery := r.URL.Query().Get("blogName")
 	if len(searchQuery) == 0 {
@@ -361,7 +713,10 @@ func (apiCfg *ApiConfig) HandleSearchBlog(w http.ResponseWriter, r *http.Request
 	}
 
 	// searching for the blogs with the search query
-	blogs, err := apiCfg.DB.GetBlogsByTitle(r.Context(), searchQuery)
+	blogs, err := apiCfg.DB.GetBlogsByTitle(r.Context(), database.GetBlogsByTitleParams{
+		Title:    searchQuery,
+		ViewerID: user.ID,
+	})
 	if err != nil {
 		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -387,7 +742,8 @@ func (apiCfg *ApiConfig) HandleSearchBlog(w http.ResponseWriter, r *http.Request
 }
 
 // handler function to get blogs by category
-func (apiCfg *ApiConfig) HandleGetBlogsByCategory(w http.ResponseWriter, r *http.Request) {
+// blogs of users blocked by or blocking the viewer are left out
+func (apiCfg *ApiConfig) HandleGetBlogsByCategory(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
 	// fetching the category query param
 	category := r.URL.Query().Get("category")
 	if len(category) == 0 {
@@ -395,8 +751,16 @@ func (apiCfg *ApiConfig) HandleGetBlogsByCategory(w http.ResponseWriter, r *http
 		return
 	}
 
-	// checking if the category exist or not
-	categoryID, err := apiCfg.DB.GetCategoryIdByName(r.Context(), category)
+	// checking if the category exist or not, categories of a publication are looked up by its slug
+	publicationID, err := apiCfg.resolvePublication(r.Context(), r.URL.Query().Get("publication"))
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+	categoryID, err := apiCfg.DB.GetCategoryIdByName(r.Context(), database.GetCategoryIdByNameParams{
+		CategoryName:  category,
+		PublicationID: publicationID,
+	})
 	if err != nil {
 		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -407,7 +771,10 @@ func (apiCfg *ApiConfig) HandleGetBlogsByCategory(w http.ResponseWriter, r *http
 	}
 
 	// fetching all the blogs for the requested category
-	blogs, err := apiCfg.DB.GetBlogsByCategory(r.Context(), categoryID)
+	blogs, err := apiCfg.DB.GetBlogsByCategory(r.Context(), database.GetBlogsByCategoryParams{
+		Category: categoryID,
+		ViewerID: user.ID,
+	})
 	if err != nil {
 		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -437,3 +804,76 @@ func (apiCfg *ApiConfig) HandleGetBlogsByCategory(w http.ResponseWriter, r *http
 	}
 	utility.RespondWithJson(w, http.StatusOK, searchResult)
 }
+
+// function to check if the viewer can read a blog and its comments
+// hidden blogs, private blogs, unpublished blogs and blogs of users blocked by or blocking the viewer are not found
+// followers only blogs and blogs of private accounts are forbidden to non followers
+func (apiCfg *ApiConfig) checkBlogAccess(ctx context.Context, viewer database.User, blog database.GetBlogByIdRow) error {
+	authorID, visibility := blog.AuthorID, blog.Visibility
+	if !canViewBlog(viewer, authorID, blog.ModerationStatus) {
+		return &requestError{http.StatusNotFound, "Blog not found"}
+	}
+
+	// blogs which are not published yet are only visible to the people working on them
+	if !blog.IsPublished && viewer.ID != authorID && !isModerator(viewer) {
+		role, err := apiCfg.blogRole(ctx, blog.ID, viewer.ID)
+		if err != nil {
+			return err
+		}
+		if len(role) == 0 {
+			return &requestError{http.StatusNotFound, "Blog not found"}
+		}
+	}
+
+	blocked, err := apiCfg.isBlockedEitherWay(ctx, viewer.ID, authorID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return &requestError{http.StatusNotFound, "Blog not found"}
+	}
+
+	allowed, err := apiCfg.canViewBlogVisibility(ctx, viewer, authorID, visibility)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		if visibility == BlogVisibilityPrivate {
+			return &requestError{http.StatusNotFound, "Blog not found"}
+		}
+		return &requestError{http.StatusForbidden, "This blog is only visible to the followers of its author"}
+	}
+
+	allowed, err = apiCfg.canViewAuthorContent(ctx, viewer, authorID)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return &requestError{http.StatusForbidden, "This account is private, only approved followers can see its blogs"}
+	}
+	return nil
+}
+
+// function to check if the viewer can see a blog with the given visibility, the author and the moderators can see every blog
+func (apiCfg *ApiConfig) canViewBlogVisibility(ctx context.Context, viewer database.User, authorID uuid.UUID, visibility string) (bool, error) {
+	if viewer.ID == authorID || isModerator(viewer) {
+		return true, nil
+	}
+
+	switch visibility {
+	case BlogVisibilityPrivate:
+		return false, nil
+	case BlogVisibilityFollowers:
+		_, err := apiCfg.DB.GetPair(ctx, database.GetPairParams{
+			FollowerID:  viewer.ID,
+			FollowingID: authorID,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return false, nil
+			}
+			return false, err
+		}
+	}
+	return true, nil
+}
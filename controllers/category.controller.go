@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"strings"
@@ -10,7 +11,22 @@ import (
 	"github.com/harshvardha/blogs/utility"
 )
 
-// handler function to add a new category
+// function to check that the user can manage the categories of the publication, categories outside publications are not checked
+func (apiCfg *ApiConfig) checkPublicationCategoryAccess(ctx context.Context, user database.User, publicationID uuid.NullUUID) error {
+	if !publicationID.Valid {
+		return nil
+	}
+	role, err := apiCfg.publicationRole(ctx, publicationID.UUID, user.ID)
+	if err != nil {
+		return err
+	}
+	if !canManagePublicationCategories(role) {
+		return &requestError{http.StatusUnauthorized, "Only the owner and editors can manage the categories of the publication"}
+	}
+	return nil
+}
+
+// handler function to add a new category, categories of a publication only need to be unique in it
 func (apiCfg *ApiConfig) HandleAddCategory(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
 	// decoding the request body
 	decoder := json.NewDecoder(r.Body)
@@ -20,12 +36,28 @@ func (apiCfg *ApiConfig) HandleAddCategory(w http.ResponseWriter, r *http.Reques
 		utility.RespondWithError(w, http.StatusBadRequest, "Invalid category params")
 		return
 	}
+	publicationID, err := apiCfg.resolvePublication(r.Context(), params.Publication)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+	err = apiCfg.checkPublicationCategoryAccess(r.Context(), user, publicationID)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
 
 	// checking if the category with the same name exist
-	categoryExist, err := apiCfg.DB.GetCategoryIdByName(r.Context(), params.Name)
+	categoryExist, err := apiCfg.DB.GetCategoryIdByName(r.Context(), database.GetCategoryIdByNameParams{
+		CategoryName:  strings.ToUpper(params.Name),
+		PublicationID: publicationID,
+	})
 	if err != nil {
 		// adding new category
-		newCategory, err := apiCfg.DB.CreateCategory(r.Context(), strings.ToUpper(params.Name))
+		newCategory, err := apiCfg.DB.CreateCategory(r.Context(), database.CreateCategoryParams{
+			CategoryName:  strings.ToUpper(params.Name),
+			PublicationID: publicationID,
+		})
 		if err != nil {
 			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
 			return
@@ -33,10 +65,12 @@ func (apiCfg *ApiConfig) HandleAddCategory(w http.ResponseWriter, r *http.Reques
 
 		// creating response
 		utility.RespondWithJson(w, http.StatusCreated, CategoryResponse{
-			ID:          newCategory.ID,
-			Name:        newCategory.CategoryName,
-			AccessToken: newAccessToken,
+			ID:            newCategory.ID,
+			Name:          newCategory.CategoryName,
+			PublicationID: publicationIDOrNil(newCategory.PublicationID),
+			AccessToken:   newAccessToken,
 		})
+		return
 	}
 	if categoryExist != uuid.Nil {
 		utility.RespondWithError(w, http.StatusBadRequest, "Category already exist")
@@ -58,12 +92,17 @@ func (apiCfg *ApiConfig) HandleEditCategory(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	// checking if the category exist or not
-	_, err = apiCfg.DB.GetCategoryNameById(r.Context(), categoryID)
+	// checking if the category exist or not and if the user can manage it
+	category, err := apiCfg.DB.GetCategoryById(r.Context(), categoryID)
 	if err != nil {
 		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	err = apiCfg.checkPublicationCategoryAccess(r.Context(), user, category.PublicationID)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
 
 	// decoding the request body
 	decoder := json.NewDecoder(r.Body)
@@ -80,15 +119,21 @@ func (apiCfg *ApiConfig) HandleEditCategory(w http.ResponseWriter, r *http.Reque
 		ID:           categoryID,
 	})
 	if err != nil {
+		if isUniqueViolation(err) {
+			utility.RespondWithError(w, http.StatusBadRequest, "Category already exist")
+			return
+		}
 		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	// creating response
 	utility.RespondWithJson(w, http.StatusOK, CategoryResponse{
-		ID:          updatedCategory.ID,
-		Name:        updatedCategory.CategoryName,
-		AccessToken: newAccessToken,
+		ID:             updatedCategory.ID,
+		Name:           updatedCategory.CategoryName,
+		RequiresReview: updatedCategory.RequiresReview,
+		PublicationID:  publicationIDOrNil(updatedCategory.PublicationID),
+		AccessToken:    newAccessToken,
 	})
 }
 
@@ -106,6 +151,18 @@ func (apiCfg *ApiConfig) HandleRemoveCategory(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// checking if the category exist or not and if the user can manage it
+	category, err := apiCfg.DB.GetCategoryById(r.Context(), categoryID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	err = apiCfg.checkPublicationCategoryAccess(r.Context(), user, category.PublicationID)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+
 	// deleting the category
 	deletedCategory, err := apiCfg.DB.DeleteCategory(r.Context(), categoryID)
 	if err != nil {
@@ -115,8 +172,9 @@ func (apiCfg *ApiConfig) HandleRemoveCategory(w http.ResponseWriter, r *http.Req
 
 	// creating response
 	utility.RespondWithJson(w, http.StatusOK, CategoryResponse{
-		ID:          deletedCategory.ID,
-		Name:        deletedCategory.CategoryName,
-		AccessToken: newAccessToken,
+		ID:            deletedCategory.ID,
+		Name:          deletedCategory.CategoryName,
+		PublicationID: publicationIDOrNil(deletedCategory.PublicationID),
+		AccessToken:   newAccessToken,
 	})
 }
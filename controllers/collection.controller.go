@@ -1,14 +1,31 @@
 package controllers
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/harshvardha/blogs/internal/database"
 	"github.com/harshvardha/blogs/utility"
 )
 
+// gap left between the positions of neighbouring blogs in a collection, moving a blog takes the middle of the gap at its new place
+// so that the other blogs keep their positions until a gap runs out
+const collectionPositionGap = 1024
+
+// function to check the description and the cover image of a collection
+func validateCollectionDetails(description string, coverURL string) error {
+	err := utility.ValidateCollectionDescription(description)
+	if err != nil {
+		return err
+	}
+	return utility.ValidateURL("cover url", coverURL)
+}
+
 // handler function to create a new collection
 func (apiCfg *ApiConfig) HandleCreateCollection(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
 	// decoding the request body
@@ -19,11 +36,44 @@ func (apiCfg *ApiConfig) HandleCreateCollection(w http.ResponseWriter, r *http.R
 		utility.RespondWithError(w, http.StatusBadRequest, "Invalid collection name")
 		return
 	}
+	if len(params.Visibility) == 0 {
+		params.Visibility = CollectionVisibilityPrivate
+	}
+	if !isValidCollectionVisibility(params.Visibility) {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid visibility")
+		return
+	}
+	description, coverURL := "", ""
+	if params.Description != nil {
+		description = strings.TrimSpace(*params.Description)
+	}
+	if params.CoverURL != nil {
+		coverURL = strings.TrimSpace(*params.CoverURL)
+	}
+	err = validateCollectionDetails(description, coverURL)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// unlisted collections are shared through a link carrying their share token
+	shareToken := sql.NullString{}
+	if params.Visibility == CollectionVisibilityUnlisted {
+		shareToken, err = generateShareToken()
+		if err != nil {
+			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
 
 	// creating a new collection
 	newCollection, err := apiCfg.DB.CreateCollection(r.Context(), database.CreateCollectionParams{
-		Name:   params.Name,
-		UserID: user.ID,
+		Name:        params.Name,
+		UserID:      user.ID,
+		Visibility:  params.Visibility,
+		ShareToken:  shareToken,
+		Description: description,
+		CoverUrl:    coverURL,
 	})
 	if err != nil {
 		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
@@ -31,14 +81,7 @@ func (apiCfg *ApiConfig) HandleCreateCollection(w http.ResponseWriter, r *http.R
 	}
 
 	// creating response
-	utility.RespondWithJson(w, http.StatusCreated, CollectionResponse{
-		ID:          newCollection.ID,
-		Name:        newCollection.Name,
-		UserID:      newCollection.UserID,
-		CreatedAt:   newCollection.CreatedAt,
-		UpdatedAt:   newCollection.UpdatedAt,
-		AccessToken: newAccessToken,
-	})
+	utility.RespondWithJson(w, http.StatusCreated, ownCollectionResponse(newCollection, newAccessToken))
 }
 
 // handler function to edit a collection
@@ -64,35 +107,77 @@ func (apiCfg *ApiConfig) HandleEditCollection(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	// checking if the user is authorized to edit collection name or not
-	userID, err := apiCfg.DB.GetOwnerId(r.Context(), collectionID)
+	// checking if the user is authorized to edit the collection or not, editors can rename it and only the owner can change its visibility
+	role, err := apiCfg.collectionRole(r.Context(), collectionID, user.ID)
 	if err != nil {
-		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		respondWithRequestError(w, err)
 		return
 	}
-	if userID != user.ID {
+	if !canEditCollection(role) || (len(params.Visibility) > 0 && role != CollectionRoleOwner) {
 		utility.RespondWithError(w, http.StatusUnauthorized, "You are not authorized to edit the collection")
 		return
 	}
 
-	// updating the collection name
-	updatedCollection, err := apiCfg.DB.EditCollection(r.Context(), database.EditCollectionParams{
-		Name: params.Name,
-		ID:   collectionID,
-	})
+	// updating the name, description and cover of the collection
+	updatedCollection, err := apiCfg.DB.GetCollectionById(r.Context(), collectionID)
 	if err != nil {
 		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	if len(params.Name) > 0 || params.Description != nil || params.CoverURL != nil {
+		details := database.EditCollectionParams{
+			Name:        updatedCollection.Name,
+			Description: updatedCollection.Description,
+			CoverUrl:    updatedCollection.CoverUrl,
+			ID:          collectionID,
+		}
+		if len(params.Name) > 0 {
+			details.Name = params.Name
+		}
+		if params.Description != nil {
+			details.Description = strings.TrimSpace(*params.Description)
+		}
+		if params.CoverURL != nil {
+			details.CoverUrl = strings.TrimSpace(*params.CoverURL)
+		}
+		err = validateCollectionDetails(details.Description, details.CoverUrl)
+		if err != nil {
+			utility.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		updatedCollection, err = apiCfg.DB.EditCollection(r.Context(), details)
+		if err != nil {
+			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	// updating the visibility, the share token of an unlisted collection is kept so that links shared earlier keep working
+	if len(params.Visibility) > 0 {
+		if !isValidCollectionVisibility(params.Visibility) {
+			utility.RespondWithError(w, http.StatusBadRequest, "Invalid visibility")
+			return
+		}
+		shareToken := updatedCollection.ShareToken
+		if params.Visibility == CollectionVisibilityUnlisted && !shareToken.Valid {
+			shareToken, err = generateShareToken()
+			if err != nil {
+				utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+		}
+		updatedCollection, err = apiCfg.DB.SetCollectionVisibility(r.Context(), database.SetCollectionVisibilityParams{
+			Visibility: params.Visibility,
+			ShareToken: shareToken,
+			ID:         collectionID,
+		})
+		if err != nil {
+			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
 
-	utility.RespondWithJson(w, http.StatusOK, CollectionResponse{
-		ID:          updatedCollection.ID,
-		Name:        updatedCollection.Name,
-		UserID:      updatedCollection.UserID,
-		CreatedAt:   updatedCollection.CreatedAt,
-		UpdatedAt:   updatedCollection.UpdatedAt,
-		AccessToken: newAccessToken,
-	})
+	utility.RespondWithJson(w, http.StatusOK, collectionResponseForRole(updatedCollection, role, newAccessToken))
 }
 
 // handler function to delete a collection
@@ -131,14 +216,7 @@ func (apiCfg *ApiConfig) HandleDeleteCollection(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	utility.RespondWithJson(w, http.StatusOK, CollectionResponse{
-		ID:          deletedCollection.ID,
-		Name:        deletedCollection.Name,
-		UserID:      deletedCollection.UserID,
-		CreatedAt:   deletedCollection.CreatedAt,
-		UpdatedAt:   deletedCollection.UpdatedAt,
-		AccessToken: newAccessToken,
-	})
+	utility.RespondWithJson(w, http.StatusOK, ownCollectionResponse(deletedCollection, newAccessToken))
 }
 
 // handler function to GetAllCollectionsByUserID
@@ -148,8 +226,13 @@ func (apiCfg *ApiConfig) HandleGetAllCollectionsByUserID(w http.ResponseWriter,
 		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	sharedCollections, err := apiCfg.DB.GetSharedCollectionsByUserId(r.Context(), user.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
 
-	if len(allCollections) == 0 {
+	if len(allCollections) == 0 && len(sharedCollections) == 0 {
 		utility.RespondWithJson(w, http.StatusNotFound, "No Collections Found")
 		return
 	}
@@ -157,14 +240,15 @@ func (apiCfg *ApiConfig) HandleGetAllCollectionsByUserID(w http.ResponseWriter,
 	// creating response
 	var collections []CollectionResponse
 	for _, collection := range allCollections {
-		collections = append(collections, CollectionResponse{
-			ID:          collection.ID,
-			Name:        collection.Name,
-			UserID:      collection.UserID,
-			CreatedAt:   collection.CreatedAt,
-			UpdatedAt:   collection.UpdatedAt,
-			AccessToken: newAccessToken,
-		})
+		collections = append(collections, ownCollectionResponse(collection, newAccessToken))
+	}
+	for _, collection := range sharedCollections {
+		role, err := apiCfg.collectionRole(r.Context(), collection.ID, user.ID)
+		if err != nil {
+			respondWithRequestError(w, err)
+			return
+		}
+		collections = append(collections, collectionResponseForRole(collection, role, newAccessToken))
 	}
 
 	utility.RespondWithJson(w, http.StatusOK, collections)
@@ -184,8 +268,23 @@ func (apiCfg *ApiConfig) HandleGetAllBlogsByCollectionID(w http.ResponseWriter,
 		return
 	}
 
-	// fetching all the blogs for the given collection id
-	allBlogs, err := apiCfg.DB.GetAllBlogsByCollectionId(r.Context(), collectionID)
+	// checking if the user can see the collection, unlisted collections need their share token
+	collection, err := apiCfg.DB.GetCollectionById(r.Context(), collectionID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusNotFound, "Collection not found")
+		return
+	}
+	err = apiCfg.checkCollectionAccess(r.Context(), user, collection, r.URL.Query().Get("token"))
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+
+	// fetching all the blogs for the given collection id which the user can see
+	allBlogs, err := apiCfg.DB.GetAllBlogsByCollectionId(r.Context(), database.GetAllBlogsByCollectionIdParams{
+		ID:       collectionID,
+		ViewerID: user.ID,
+	})
 	if err != nil {
 		utility.RespondWithError(w, http.StatusNotFound, err.Error())
 		return
@@ -203,6 +302,9 @@ func (apiCfg *ApiConfig) HandleGetAllBlogsByCollectionID(w http.ResponseWriter,
 			BlogContent:      blog.Content,
 			BlogCategoryID:   blog.Category,
 			BlogCategoryName: blog.CategoryName,
+			AddedBy:          addedBy(blog.AddedBy),
+			Note:             blog.Note,
+			Position:         blog.Position,
 			BlogCreatedAt:    blog.CreatedAt,
 			BlogUpdatedAt:    blog.UpdatedAt,
 			AccessToken:      newAccessToken,
@@ -223,9 +325,9 @@ func (apiCfg *ApiConfig) HandleAddBlogToCollection(w http.ResponseWriter, r *htt
 	}
 
 	// checking if the collection exist or not
-	userID, err := apiCfg.DB.GetOwnerId(r.Context(), params.CollectionID)
+	role, err := apiCfg.collectionRole(r.Context(), params.CollectionID, user.ID)
 	if err != nil {
-		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		respondWithRequestError(w, err)
 		return
 	}
 
@@ -239,17 +341,30 @@ func (apiCfg *ApiConfig) HandleAddBlogToCollection(w http.ResponseWriter, r *htt
 		utility.RespondWithError(w, http.StatusBadGateway, "Blog does not exist")
 		return
 	}
+	err = apiCfg.checkBlogAccess(r.Context(), user, blogExist)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
 
-	// checking if the user is authorized to modify collection
-	if userID != user.ID {
+	// checking if the user is authorized to modify collection, the owner and the editors can
+	if !canEditCollection(role) {
 		utility.RespondWithError(w, http.StatusUnauthorized, "You are not authorized to modify collection")
 		return
 	}
+	err = utility.ValidateCollectionNote(strings.TrimSpace(params.Note))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	// adding blog to collection
 	modifiedCollection, err := apiCfg.DB.AddBlogToCollection(r.Context(), database.AddBlogToCollectionParams{
 		CollectionID: params.CollectionID,
 		BlogID:       params.BlogID,
+		AddedBy:      uuid.NullUUID{UUID: user.ID, Valid: true},
+		Note:         strings.TrimSpace(params.Note),
+		PositionGap:  collectionPositionGap,
 	})
 	if err != nil {
 		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
@@ -274,6 +389,7 @@ func (apiCfg *ApiConfig) HandleAddBlogToCollection(w http.ResponseWriter, r *htt
 		CollectionName: collectionName,
 		BlogID:         modifiedCollection.BlogID,
 		BlogName:       blogName,
+		Note:           modifiedCollection.Note,
 		AccessToken:    newAccessToken,
 	})
 }
@@ -289,13 +405,13 @@ func (apiCfg *ApiConfig) HandleRemoveBlogFromCollection(w http.ResponseWriter, r
 		return
 	}
 
-	// checking if the user is authorized to modify the collection
-	userID, err := apiCfg.DB.GetOwnerId(r.Context(), params.CollectionID)
+	// checking if the user is authorized to modify the collection, the owner and the editors can
+	role, err := apiCfg.collectionRole(r.Context(), params.CollectionID, user.ID)
 	if err != nil {
-		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		respondWithRequestError(w, err)
 		return
 	}
-	if userID == uuid.Nil || userID != user.ID {
+	if !canEditCollection(role) {
 		utility.RespondWithError(w, http.StatusUnauthorized, "You are not authorized to modify collection")
 		return
 	}
@@ -331,3 +447,163 @@ func (apiCfg *ApiConfig) HandleRemoveBlogFromCollection(w http.ResponseWriter, r
 		AccessToken:    newAccessToken,
 	})
 }
+
+// handler function to move a blog to a new index in a collection
+func (apiCfg *ApiConfig) HandleReorderCollection(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	// fetching the collection id from url params
+	collectionID, err := uuid.Parse(r.PathValue("collectionID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid collection id")
+		return
+	}
+
+	// decoding the request body
+	decoder := json.NewDecoder(r.Body)
+	params := CollectionReorderRequest{}
+	err = decoder.Decode(&params)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid reorder details")
+		return
+	}
+
+	// checking if the user is authorized to modify the collection, the owner and the editors can
+	role, err := apiCfg.collectionRole(r.Context(), collectionID, user.ID)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+	if !canEditCollection(role) {
+		utility.RespondWithError(w, http.StatusUnauthorized, "You are not authorized to modify collection")
+		return
+	}
+
+	// moving the blog
+	err = apiCfg.moveCollectionItem(r.Context(), collectionID, params.BlogID, params.Index)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+
+	// responding with the new order of the blogs
+	positions, err := apiCfg.DB.GetCollectionItemPositions(r.Context(), collectionID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	items := []CollectionItemPositionResponse{}
+	for _, item := range positions {
+		items = append(items, CollectionItemPositionResponse{
+			BlogID:      item.BlogID,
+			Position:    item.Position,
+			AccessToken: newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, items)
+}
+
+// function to move a blog to the index in the collection, the blogs are renumbered only when there is no gap left at the new place
+func (apiCfg *ApiConfig) moveCollectionItem(ctx context.Context, collectionID uuid.UUID, blogID uuid.UUID, index int) error {
+	for attempt := 0; attempt < 2; attempt++ {
+		items, err := apiCfg.DB.GetCollectionItemPositions(ctx, collectionID)
+		if err != nil {
+			return err
+		}
+
+		// leaving out the blog being moved
+		others := []database.GetCollectionItemPositionsRow{}
+		found := false
+		for _, item := range items {
+			if item.BlogID == blogID {
+				found = true
+				continue
+			}
+			others = append(others, item)
+		}
+		if !found {
+			return &requestError{http.StatusNotFound, "Blog is not in the collection"}
+		}
+		index = max(0, min(index, len(others)))
+
+		// finding the position between the new neighbours of the blog
+		var position int64
+		switch {
+		case len(others) == 0:
+			position = collectionPositionGap
+		case index == 0:
+			position = others[0].Position - collectionPositionGap
+		case index == len(others):
+			position = others[len(others)-1].Position + collectionPositionGap
+		default:
+			previous, next := others[index-1].Position, others[index].Position
+			if next-previous < 2 {
+				err = apiCfg.DB.RenumberCollectionItems(ctx, database.RenumberCollectionItemsParams{
+					CollectionID: collectionID,
+					PositionGap:  collectionPositionGap,
+				})
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			position = previous + (next-previous)/2
+		}
+
+		return apiCfg.DB.SetCollectionItemPosition(ctx, database.SetCollectionItemPositionParams{
+			Position:     position,
+			CollectionID: collectionID,
+			BlogID:       blogID,
+		})
+	}
+	return errors.New("unable to find a position for the blog in the collection")
+}
+
+// handler function to set the note on a blog in a collection
+func (apiCfg *ApiConfig) HandleSetCollectionNote(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	// decoding the request body
+	decoder := json.NewDecoder(r.Body)
+	params := CollectionBlogRequest{}
+	err := decoder.Decode(&params)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid information")
+		return
+	}
+	note := strings.TrimSpace(params.Note)
+	err = utility.ValidateCollectionNote(note)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// checking if the user is authorized to modify the collection, the owner and the editors can
+	role, err := apiCfg.collectionRole(r.Context(), params.CollectionID, user.ID)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+	if !canEditCollection(role) {
+		utility.RespondWithError(w, http.StatusUnauthorized, "You are not authorized to modify collection")
+		return
+	}
+
+	// saving the note
+	item, err := apiCfg.DB.SetCollectionItemNote(r.Context(), database.SetCollectionItemNoteParams{
+		Note:         note,
+		CollectionID: params.CollectionID,
+		BlogID:       params.BlogID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utility.RespondWithError(w, http.StatusNotFound, "Blog is not in the collection")
+			return
+		}
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, CollectionBlogResponse{
+		CollectionID: item.CollectionID,
+		BlogID:       item.BlogID,
+		Note:         item.Note,
+		AccessToken:  newAccessToken,
+	})
+}
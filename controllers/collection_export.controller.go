@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/harshvardha/blogs/export"
+	"github.com/harshvardha/blogs/internal/database"
+	"github.com/harshvardha/blogs/utility"
+)
+
+// formats a collection can be exported in
+const (
+	CollectionExportEPUB     = "epub"
+	CollectionExportHTML     = "html"
+	CollectionExportMarkdown = "markdown"
+)
+
+// content type, file extension and writer of every export format
+var collectionExportFormats = map[string]struct {
+	contentType string
+	extension   string
+	write       func(io.Writer, export.Book) error
+}{
+	CollectionExportEPUB:     {"application/epub+zip", ".epub", export.WriteEPUB},
+	CollectionExportHTML:     {"text/html; charset=utf-8", ".html", export.WriteHTML},
+	CollectionExportMarkdown: {"application/zip", ".zip", export.WriteMarkdown},
+}
+
+// handler function to download the blogs of a collection for reading offline as an epub, a single html document or a zip of markdown files
+// only the blogs the user can see are exported, unlisted collections are exported by passing their share token as the token query param
+func (apiCfg *ApiConfig) HandleExportCollection(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	formatName := r.URL.Query().Get("format")
+	if len(formatName) == 0 {
+		formatName = CollectionExportEPUB
+	}
+	format, ok := collectionExportFormats[formatName]
+	if !ok {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid export format, use epub, html or markdown")
+		return
+	}
+
+	collection, err := apiCfg.getViewableCollection(r, user)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+
+	// fetching the blogs in the collection, blogs the user cannot see are left out
+	allBlogs, err := apiCfg.DB.GetAllBlogsByCollectionId(r.Context(), database.GetAllBlogsByCollectionIdParams{
+		ID:       collection.ID,
+		ViewerID: user.ID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	owner, err := apiCfg.DB.GetUserById(r.Context(), collection.UserID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// putting the book together
+	book := export.Book{
+		ID:          collection.ID,
+		Title:       collection.Name,
+		Description: collection.Description,
+		Curator:     owner.Username,
+		Language:    "en",
+		Modified:    collection.UpdatedAt,
+	}
+	if len(owner.DisplayName) > 0 {
+		book.Curator = owner.DisplayName
+	}
+	for _, blog := range allBlogs {
+		book.Chapters = append(book.Chapters, export.Chapter{
+			ID:          blog.ID,
+			Title:       blog.Title,
+			Author:      blog.AuthorName,
+			Category:    blog.CategoryName,
+			Note:        blog.Note,
+			Content:     blog.Content,
+			PublishedAt: blog.CreatedAt,
+		})
+		if blog.UpdatedAt.After(book.Modified) {
+			book.Modified = blog.UpdatedAt
+		}
+	}
+
+	// streaming the file, the refreshed access token is sent in a header since the file has no place for it
+	if len(newAccessToken) > 0 {
+		w.Header().Set("X-Access-Token", newAccessToken)
+	}
+	w.Header().Set("Content-Type", format.contentType)
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+export.Slug(collection.Name, "collection")+format.extension+"\"")
+	w.WriteHeader(http.StatusOK)
+	err = format.write(w, book)
+	if err != nil {
+		log.Println("Error exporting collection: ", err)
+	}
+}
@@ -0,0 +1,362 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/harshvardha/blogs/internal/database"
+	"github.com/harshvardha/blogs/notifications"
+	"github.com/harshvardha/blogs/utility"
+)
+
+// roles of the users in a collection
+// editors can rename the collection and add or remove blogs, viewers can only read it even when it is private
+const (
+	CollectionRoleOwner  = "owner"
+	CollectionRoleEditor = "editor"
+	CollectionRoleViewer = "viewer"
+)
+
+// states of a collection membership
+const (
+	CollectionMemberInvited  = "invited"
+	CollectionMemberAccepted = "accepted"
+)
+
+// function to get the role of the user in a collection, users who are not members have no role
+func (apiCfg *ApiConfig) collectionRole(ctx context.Context, collectionID uuid.UUID, userID uuid.UUID) (string, error) {
+	role, err := apiCfg.DB.GetCollectionRole(ctx, database.GetCollectionRoleParams{
+		CollectionID: collectionID,
+		UserID:       userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", &requestError{http.StatusNotFound, "Collection not found"}
+		}
+		return "", err
+	}
+	return role, nil
+}
+
+// function to check if the role allows changing the blogs in a collection
+func canEditCollection(role string) bool {
+	return role == CollectionRoleOwner || role == CollectionRoleEditor
+}
+
+// function to create the collection response for a member, only the owner gets to see the share token
+func collectionResponseForRole(collection database.Collection, role string, newAccessToken string) CollectionResponse {
+	response := ownCollectionResponse(collection, newAccessToken)
+	response.Role = role
+	if role != CollectionRoleOwner {
+		response.ShareToken = ""
+	}
+	return response
+}
+
+// function to convert the nullable user who added a blog to a collection into its json form
+func addedBy(userID uuid.NullUUID) *uuid.UUID {
+	if !userID.Valid {
+		return nil
+	}
+	return &userID.UUID
+}
+
+// function to parse the collection id from url params and check that the user owns the collection, it responds on failure
+func (apiCfg *ApiConfig) parseOwnedCollectionID(w http.ResponseWriter, r *http.Request, user database.User) (uuid.UUID, bool) {
+	collectionID, err := uuid.Parse(r.PathValue("collectionID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid collection id")
+		return uuid.Nil, false
+	}
+	role, err := apiCfg.collectionRole(r.Context(), collectionID, user.ID)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return uuid.Nil, false
+	}
+	if role != CollectionRoleOwner {
+		utility.RespondWithError(w, http.StatusUnauthorized, "Only the owner can manage the members of the collection")
+		return uuid.Nil, false
+	}
+	return collectionID, true
+}
+
+// handler function to invite a user to a collection as an editor or a viewer, inviting a member again changes their role
+func (apiCfg *ApiConfig) HandleInviteCollectionMember(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	collectionID, ok := apiCfg.parseOwnedCollectionID(w, r, user)
+	if !ok {
+		return
+	}
+
+	// decoding the request body
+	decoder := json.NewDecoder(r.Body)
+	params := CollectionMemberRequest{}
+	err := decoder.Decode(&params)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid member details")
+		return
+	}
+	if params.Role != CollectionRoleEditor && params.Role != CollectionRoleViewer {
+		utility.RespondWithError(w, http.StatusBadRequest, "Role must be editor or viewer")
+		return
+	}
+	if params.UserID == user.ID {
+		utility.RespondWithError(w, http.StatusBadRequest, "You already own this collection")
+		return
+	}
+
+	// checking if the invited user exist and can be invited
+	invitedUser, err := apiCfg.DB.GetUserById(r.Context(), params.UserID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusNotFound, "User does not exist")
+		return
+	}
+	blocked, err := apiCfg.isBlockedEitherWay(r.Context(), user.ID, invitedUser.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if blocked {
+		utility.RespondWithError(w, http.StatusForbidden, "You cannot invite this user")
+		return
+	}
+
+	// inviting the user
+	member, err := apiCfg.DB.InviteCollectionMember(r.Context(), database.InviteCollectionMemberParams{
+		CollectionID: collectionID,
+		UserID:       invitedUser.ID,
+		Role:         params.Role,
+		InvitedBy:    uuid.NullUUID{UUID: user.ID, Valid: true},
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if member.Status == CollectionMemberInvited {
+		apiCfg.notify(r.Context(), notifications.Notification{
+			UserID:     invitedUser.ID,
+			ActorID:    user.ID,
+			Type:       notifications.TypeCollectionInvite,
+			EntityType: notifications.EntityCollection,
+			EntityID:   collectionID,
+		})
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, CollectionMemberResponse{
+		UserID:      invitedUser.ID,
+		Username:    invitedUser.Username,
+		DisplayName: invitedUser.DisplayName,
+		AvatarURL:   invitedUser.AvatarUrl,
+		Role:        member.Role,
+		Status:      member.Status,
+		Since:       member.CreatedAt,
+		AccessToken: newAccessToken,
+	})
+}
+
+// handler function to remove a member from a collection or take back their invite
+func (apiCfg *ApiConfig) HandleRemoveCollectionMember(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	collectionID, ok := apiCfg.parseOwnedCollectionID(w, r, user)
+	if !ok {
+		return
+	}
+	memberID, err := uuid.Parse(r.PathValue("userID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid user id")
+		return
+	}
+
+	removed, err := apiCfg.DB.RemoveCollectionMember(r.Context(), database.RemoveCollectionMemberParams{
+		CollectionID: collectionID,
+		UserID:       memberID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if removed == 0 {
+		utility.RespondWithError(w, http.StatusNotFound, "User is not a member of the collection")
+		return
+	}
+	apiCfg.retractNotification(r.Context(), notifications.Notification{
+		UserID:   memberID,
+		ActorID:  user.ID,
+		Type:     notifications.TypeCollectionInvite,
+		EntityID: collectionID,
+	})
+
+	utility.RespondWithJson(w, http.StatusOK, EmptyResponse{
+		AccessToken: newAccessToken,
+	})
+}
+
+// handler function to get the members and pending invites of a collection, any member can see them
+func (apiCfg *ApiConfig) HandleGetCollectionMembers(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	collectionID, err := uuid.Parse(r.PathValue("collectionID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid collection id")
+		return
+	}
+	role, err := apiCfg.collectionRole(r.Context(), collectionID, user.ID)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+	if len(role) == 0 {
+		utility.RespondWithError(w, http.StatusNotFound, "Collection not found")
+		return
+	}
+
+	// fetching the members
+	collectionMembers, err := apiCfg.DB.GetCollectionMembers(r.Context(), collectionID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	members := []CollectionMemberResponse{}
+	for _, member := range collectionMembers {
+		members = append(members, CollectionMemberResponse{
+			UserID:      member.ID,
+			Username:    member.Username,
+			DisplayName: member.DisplayName,
+			AvatarURL:   member.AvatarUrl,
+			Role:        member.Role,
+			Status:      member.Status,
+			Since:       member.CreatedAt,
+			AccessToken: newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, members)
+}
+
+// handler function to get the collection invites the user has not accepted yet
+func (apiCfg *ApiConfig) HandleGetCollectionInvites(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	pendingInvites, err := apiCfg.DB.GetCollectionInvites(r.Context(), user.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	invites := []CollectionInviteResponse{}
+	for _, invite := range pendingInvites {
+		invites = append(invites, CollectionInviteResponse{
+			CollectionID:   invite.ID,
+			CollectionName: invite.Name,
+			OwnerID:        invite.UserID,
+			OwnerName:      invite.OwnerName,
+			Role:           invite.Role,
+			InvitedAt:      invite.CreatedAt,
+			AccessToken:    newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, invites)
+}
+
+// handler function to accept an invite to a collection
+func (apiCfg *ApiConfig) HandleAcceptCollectionInvite(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	collectionID, err := uuid.Parse(r.PathValue("collectionID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid collection id")
+		return
+	}
+
+	_, err = apiCfg.DB.AcceptCollectionInvite(r.Context(), database.AcceptCollectionInviteParams{
+		CollectionID: collectionID,
+		UserID:       user.ID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utility.RespondWithError(w, http.StatusNotFound, "Invite not found")
+			return
+		}
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	collection, err := apiCfg.DB.GetCollectionById(r.Context(), collectionID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	role, err := apiCfg.collectionRole(r.Context(), collectionID, user.ID)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, collectionResponseForRole(collection, role, newAccessToken))
+}
+
+// handler function to leave a collection or decline an invite to it, the owner has to transfer the collection before leaving
+func (apiCfg *ApiConfig) HandleLeaveCollection(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	collectionID, err := uuid.Parse(r.PathValue("collectionID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid collection id")
+		return
+	}
+	role, err := apiCfg.collectionRole(r.Context(), collectionID, user.ID)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+	if role == CollectionRoleOwner {
+		utility.RespondWithError(w, http.StatusBadRequest, "Transfer the collection to another member before leaving it")
+		return
+	}
+
+	left, err := apiCfg.DB.RemoveCollectionMember(r.Context(), database.RemoveCollectionMemberParams{
+		CollectionID: collectionID,
+		UserID:       user.ID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if left == 0 {
+		utility.RespondWithError(w, http.StatusNotFound, "You are not a member of the collection")
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, EmptyResponse{
+		AccessToken: newAccessToken,
+	})
+}
+
+// handler function to make another member the owner of a collection, the previous owner stays on as an editor
+func (apiCfg *ApiConfig) HandleTransferCollection(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	collectionID, ok := apiCfg.parseOwnedCollectionID(w, r, user)
+	if !ok {
+		return
+	}
+
+	// decoding the request body
+	decoder := json.NewDecoder(r.Body)
+	params := CollectionMemberRequest{}
+	err := decoder.Decode(&params)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid new owner")
+		return
+	}
+
+	// transferring the collection, only members who accepted their invite can become the owner
+	transferredCollection, err := apiCfg.DB.TransferCollectionOwnership(r.Context(), database.TransferCollectionOwnershipParams{
+		CollectionID: collectionID,
+		OwnerID:      user.ID,
+		NewOwnerID:   params.UserID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utility.RespondWithError(w, http.StatusBadRequest, "The new owner must be a member of the collection")
+			return
+		}
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, collectionResponseForRole(transferredCollection, CollectionRoleEditor, newAccessToken))
+}
@@ -0,0 +1,314 @@
+package controllers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/harshvardha/blogs/internal/database"
+	"github.com/harshvardha/blogs/utility"
+)
+
+// visibility of a collection
+// public collections are listed on the profile of their owner, unlisted collections can only be reached with their share token
+const (
+	CollectionVisibilityPublic   = "public"
+	CollectionVisibilityUnlisted = "unlisted"
+	CollectionVisibilityPrivate  = "private"
+)
+
+// function to check if the visibility is one of the known collection visibilities
+func isValidCollectionVisibility(visibility string) bool {
+	switch visibility {
+	case CollectionVisibilityPublic, CollectionVisibilityUnlisted, CollectionVisibilityPrivate:
+		return true
+	}
+	return false
+}
+
+// function to generate the token which makes the link of an unlisted collection
+func generateShareToken() (sql.NullString, error) {
+	token := make([]byte, 16)
+	_, err := rand.Read(token)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+
+	return sql.NullString{String: hex.EncodeToString(token), Valid: true}, nil
+}
+
+// function to create the response for the owner of the collection, only the owner gets to see the share token
+func ownCollectionResponse(collection database.Collection, newAccessToken string) CollectionResponse {
+	return CollectionResponse{
+		ID:          collection.ID,
+		Name:        collection.Name,
+		UserID:      collection.UserID,
+		Visibility:  collection.Visibility,
+		Description: collection.Description,
+		CoverURL:    collection.CoverUrl,
+		Role:        CollectionRoleOwner,
+		ShareToken:  collection.ShareToken.String,
+		CreatedAt:   collection.CreatedAt,
+		UpdatedAt:   collection.UpdatedAt,
+		AccessToken: newAccessToken,
+	}
+}
+
+// function to check if the viewer can see a collection
+// private collections and unlisted collections asked for without their share token are not found for anyone but the owner and the members
+func (apiCfg *ApiConfig) checkCollectionAccess(ctx context.Context, viewer database.User, collection database.Collection, shareToken string) error {
+	role, err := apiCfg.collectionRole(ctx, collection.ID, viewer.ID)
+	if err != nil {
+		return err
+	}
+	if len(role) > 0 {
+		return nil
+	}
+
+	switch collection.Visibility {
+	case CollectionVisibilityPrivate:
+		return &requestError{http.StatusNotFound, "Collection not found"}
+	case CollectionVisibilityUnlisted:
+		if !collection.ShareToken.Valid || subtle.ConstantTimeCompare([]byte(collection.ShareToken.String), []byte(shareToken)) != 1 {
+			return &requestError{http.StatusNotFound, "Collection not found"}
+		}
+	}
+
+	// collections of users blocked by or blocking the viewer are not found, collections of private accounts are for their followers
+	blocked, err := apiCfg.isBlockedEitherWay(ctx, viewer.ID, collection.UserID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return &requestError{http.StatusNotFound, "Collection not found"}
+	}
+	allowed, err := apiCfg.canViewAuthorContent(ctx, viewer, collection.UserID)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return &requestError{http.StatusForbidden, "This account is private"}
+	}
+	return nil
+}
+
+// function to fetch the collection from the url params and check that the user can see it
+func (apiCfg *ApiConfig) getViewableCollection(r *http.Request, user database.User) (database.Collection, error) {
+	collectionID, err := uuid.Parse(r.PathValue("collectionID"))
+	if err != nil {
+		return database.Collection{}, &requestError{http.StatusBadRequest, "Invalid collection id"}
+	}
+	collection, err := apiCfg.DB.GetCollectionById(r.Context(), collectionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return database.Collection{}, &requestError{http.StatusNotFound, "Collection not found"}
+		}
+		return database.Collection{}, err
+	}
+
+	err = apiCfg.checkCollectionAccess(r.Context(), user, collection, r.URL.Query().Get("token"))
+	if err != nil {
+		return database.Collection{}, err
+	}
+	return collection, nil
+}
+
+// handler function to get a collection with the blogs in it which the user can see
+// unlisted collections are read by passing their share token as the token query param
+func (apiCfg *ApiConfig) HandleGetCollection(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	collection, err := apiCfg.getViewableCollection(r, user)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+
+	// fetching the blogs in the collection, blogs the user cannot see are left out
+	allBlogs, err := apiCfg.DB.GetAllBlogsByCollectionId(r.Context(), database.GetAllBlogsByCollectionIdParams{
+		ID:       collection.ID,
+		ViewerID: user.ID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	followersCount, err := apiCfg.DB.CountCollectionFollowers(r.Context(), collection.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	followedByMe, err := apiCfg.DB.IsCollectionFollowed(r.Context(), database.IsCollectionFollowedParams{
+		UserID:       user.ID,
+		CollectionID: collection.ID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	collectionBlogs := []BlogsInCollection{}
+	for _, blog := range allBlogs {
+		collectionBlogs = append(collectionBlogs, BlogsInCollection{
+			BlogID:           blog.ID,
+			BlogTitle:        blog.Title,
+			BlogAuthorID:     blog.AuthorID,
+			BlogAuthorName:   blog.AuthorName,
+			BlogThumbnailURL: blog.ThumbnailUrl,
+			BlogContent:      blog.Content,
+			BlogCategoryID:   blog.Category,
+			BlogCategoryName: blog.CategoryName,
+			AddedBy:          addedBy(blog.AddedBy),
+			Note:             blog.Note,
+			Position:         blog.Position,
+			BlogCreatedAt:    blog.CreatedAt,
+			BlogUpdatedAt:    blog.UpdatedAt,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, PublicCollectionResponse{
+		ID:             collection.ID,
+		Name:           collection.Name,
+		UserID:         collection.UserID,
+		Visibility:     collection.Visibility,
+		Description:    collection.Description,
+		CoverURL:       collection.CoverUrl,
+		BlogsCount:     int64(len(collectionBlogs)),
+		FollowersCount: followersCount,
+		FollowedByMe:   followedByMe,
+		Blogs:          collectionBlogs,
+		CreatedAt:      collection.CreatedAt,
+		UpdatedAt:      collection.UpdatedAt,
+		AccessToken:    newAccessToken,
+	})
+}
+
+// handler function to get the public collections of a user, shown on their profile
+func (apiCfg *ApiConfig) HandleGetUserPublicCollections(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	userID, ok := apiCfg.parseExistingUserID(w, r)
+	if !ok || !apiCfg.canViewFollowLists(w, r, user, userID) {
+		return
+	}
+	blocked, err := apiCfg.isBlockedEitherWay(r.Context(), user.ID, userID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if blocked {
+		utility.RespondWithError(w, http.StatusNotFound, "User does not exist")
+		return
+	}
+
+	// fetching the public collections
+	limit, offset := utility.ParsePagination(r)
+	publicCollections, err := apiCfg.DB.GetPublicCollectionsByUserId(r.Context(), database.GetPublicCollectionsByUserIdParams{
+		UserID: userID,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	collections := []PublicCollectionResponse{}
+	for _, collection := range publicCollections {
+		collections = append(collections, PublicCollectionResponse{
+			ID:             collection.ID,
+			Name:           collection.Name,
+			UserID:         collection.UserID,
+			Visibility:     collection.Visibility,
+			Description:    collection.Description,
+			CoverURL:       collection.CoverUrl,
+			BlogsCount:     collection.BlogsCount,
+			FollowersCount: collection.FollowersCount,
+			CreatedAt:      collection.CreatedAt,
+			UpdatedAt:      collection.UpdatedAt,
+			AccessToken:    newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, collections)
+}
+
+// handler function to follow or unfollow a collection, blogs added to followed collections show up in the feed
+func (apiCfg *ApiConfig) HandleFollowCollection(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	collection, err := apiCfg.getViewableCollection(r, user)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+	if collection.UserID == user.ID {
+		utility.RespondWithError(w, http.StatusBadRequest, "You cannot follow your own collection")
+		return
+	}
+
+	// unfollowing the collection if the user already follows it
+	unfollowed, err := apiCfg.DB.UnfollowCollection(r.Context(), database.UnfollowCollectionParams{
+		UserID:       user.ID,
+		CollectionID: collection.ID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if unfollowed > 0 {
+		utility.RespondWithJson(w, http.StatusOK, FollowStatusResponse{
+			Status:      FollowStatusNotFollowing,
+			AccessToken: newAccessToken,
+		})
+		return
+	}
+
+	// following the collection
+	err = apiCfg.DB.FollowCollection(r.Context(), database.FollowCollectionParams{
+		UserID:       user.ID,
+		CollectionID: collection.ID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	utility.RespondWithJson(w, http.StatusOK, FollowStatusResponse{
+		Status:      FollowStatusFollowing,
+		AccessToken: newAccessToken,
+	})
+}
+
+// handler function to replace the share token of an unlisted collection, links shared with the old token stop working
+func (apiCfg *ApiConfig) HandleRotateCollectionShareToken(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	collectionID, err := uuid.Parse(r.PathValue("collectionID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid collection id")
+		return
+	}
+	collection, err := apiCfg.DB.GetCollectionById(r.Context(), collectionID)
+	if err != nil || collection.UserID != user.ID {
+		utility.RespondWithError(w, http.StatusNotFound, "Collection not found")
+		return
+	}
+	if collection.Visibility != CollectionVisibilityUnlisted {
+		utility.RespondWithError(w, http.StatusBadRequest, "Only unlisted collections have a share link")
+		return
+	}
+
+	// replacing the share token
+	shareToken, err := generateShareToken()
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	updatedCollection, err := apiCfg.DB.SetCollectionVisibility(r.Context(), database.SetCollectionVisibilityParams{
+		Visibility: collection.Visibility,
+		ShareToken: shareToken,
+		ID:         collection.ID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	utility.RespondWithJson(w, http.StatusOK, ownCollectionResponse(updatedCollection, newAccessToken))
+}
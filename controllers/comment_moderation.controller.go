@@ -0,0 +1,360 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/harshvardha/blogs/internal/database"
+	"github.com/harshvardha/blogs/utility"
+)
+
+// user roles
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+// comment policies a blog author can choose from
+const (
+	CommentPolicyOpen          = "open"
+	CommentPolicyClosed        = "closed"
+	CommentPolicyFollowersOnly = "followers_only"
+)
+
+// states a comment can be in, only approved comments are visible to readers
+const (
+	CommentStatusPending  = "pending"
+	CommentStatusApproved = "approved"
+	CommentStatusRejected = "rejected"
+	CommentStatusHidden   = "hidden"
+)
+
+// function to check if the user is a platform moderator
+func isModerator(user database.User) bool {
+	return user.Role == RoleModerator || user.Role == RoleAdmin
+}
+
+// function to fetch the comment settings for a blog
+// blogs which never changed their settings get the default open settings
+func (apiCfg *ApiConfig) getCommentSettings(ctx context.Context, blogID uuid.UUID) (database.BlogCommentSetting, error) {
+	settings, err := apiCfg.DB.GetCommentSettings(ctx, blogID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return database.BlogCommentSetting{
+				BlogID:          blogID,
+				CommentPolicy:   CommentPolicyOpen,
+				RequireApproval: false,
+			}, nil
+		}
+		return database.BlogCommentSetting{}, err
+	}
+
+	return settings, nil
+}
+
+// function to check if the user can moderate the comments of a blog
+// only the blog author and the moderators are allowed to do that
+func (apiCfg *ApiConfig) canModerateBlogComments(ctx context.Context, user database.User, blogID uuid.UUID) (bool, error) {
+	if isModerator(user) {
+		return true, nil
+	}
+
+	authorID, err := apiCfg.DB.GetBlogAuthorId(ctx, blogID)
+	if err != nil {
+		return false, err
+	}
+
+	return authorID == user.ID, nil
+}
+
+// handler function to get the comment settings of a blog
+func (apiCfg *ApiConfig) HandleGetCommentSettings(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	// fetching the blog id from url params
+	blogID, err := uuid.Parse(r.PathValue("blogID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid blog id")
+		return
+	}
+
+	// fetching the settings
+	settings, err := apiCfg.getCommentSettings(r.Context(), blogID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, CommentSettingsResponse{
+		BlogID:          settings.BlogID,
+		CommentPolicy:   settings.CommentPolicy,
+		RequireApproval: settings.RequireApproval,
+		AccessToken:     newAccessToken,
+	})
+}
+
+// handler function to update the comment settings of a blog
+func (apiCfg *ApiConfig) HandleUpdateCommentSettings(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	// fetching the blog id from url params
+	blogID, err := uuid.Parse(r.PathValue("blogID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid blog id")
+		return
+	}
+
+	// checking if the user is authorized to change the settings
+	authorID, err := apiCfg.DB.GetBlogAuthorId(r.Context(), blogID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utility.RespondWithError(w, http.StatusNotFound, "Blog not found")
+			return
+		}
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if authorID != user.ID {
+		utility.RespondWithError(w, http.StatusUnauthorized, "You are not authorized to change the comment settings of this blog")
+		return
+	}
+
+	// decoding the request body
+	decoder := json.NewDecoder(r.Body)
+	params := CommentSettingsRequest{}
+	err = decoder.Decode(&params)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid comment settings")
+		return
+	}
+	if len(params.CommentPolicy) == 0 {
+		params.CommentPolicy = CommentPolicyOpen
+	}
+	if params.CommentPolicy != CommentPolicyOpen && params.CommentPolicy != CommentPolicyClosed && params.CommentPolicy != CommentPolicyFollowersOnly {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid comment policy")
+		return
+	}
+
+	// saving the settings
+	settings, err := apiCfg.DB.UpsertCommentSettings(r.Context(), database.UpsertCommentSettingsParams{
+		BlogID:          blogID,
+		CommentPolicy:   params.CommentPolicy,
+		RequireApproval: params.RequireApproval,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, CommentSettingsResponse{
+		BlogID:          settings.BlogID,
+		CommentPolicy:   settings.CommentPolicy,
+		RequireApproval: settings.RequireApproval,
+		AccessToken:     newAccessToken,
+	})
+}
+
+// handler function to get the comments waiting for approval on a blog
+func (apiCfg *ApiConfig) HandleGetPendingComments(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	// fetching the blog id from url params
+	blogID, err := uuid.Parse(r.PathValue("blogID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid blog id")
+		return
+	}
+
+	// checking if the user is authorized to see the approval queue
+	canModerate, err := apiCfg.canModerateBlogComments(r.Context(), user, blogID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusNotFound, "Blog not found")
+		return
+	}
+	if !canModerate {
+		utility.RespondWithError(w, http.StatusUnauthorized, "You are not authorized to moderate comments on this blog")
+		return
+	}
+
+	// fetching the pending comments
+	pendingComments, err := apiCfg.DB.GetPendingCommentsByBlogId(r.Context(), blogID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	comments := []ResponseComment{}
+	for _, comment := range pendingComments {
+		comments = append(comments, ResponseComment{
+			ID:          comment.ID,
+			Description: comment.Description,
+			BlogID:      comment.BlogID,
+			UserID:      comment.UserID,
+			Status:      comment.Status,
+			IsPinned:    comment.IsPinned,
+			CreatedAt:   comment.CreatedAt,
+			UpdatedAt:   comment.UpdatedAt,
+			AccessToken: newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, comments)
+}
+
+// handler function to approve a pending comment
+func (apiCfg *ApiConfig) HandleApproveComment(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	apiCfg.moderateComment(w, r, user, newAccessToken, CommentStatusApproved)
+}
+
+// handler function to reject a pending comment
+func (apiCfg *ApiConfig) HandleRejectComment(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	apiCfg.moderateComment(w, r, user, newAccessToken, CommentStatusRejected)
+}
+
+// handler function to hide a comment from the readers of the blog
+func (apiCfg *ApiConfig) HandleHideComment(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	apiCfg.moderateComment(w, r, user, newAccessToken, CommentStatusHidden)
+}
+
+// function to move a comment into the given status after checking that the user can moderate it
+func (apiCfg *ApiConfig) moderateComment(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string, status string) {
+	// fetching the comment id from url params
+	commentID, err := uuid.Parse(r.PathValue("commentID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid comment id")
+		return
+	}
+
+	// checking if the comment exist or not
+	commentExist, err := apiCfg.DB.GetCommentById(r.Context(), commentID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusNotFound, "Comment not found")
+		return
+	}
+
+	// checking if the user is authorized to moderate the comment
+	canModerate, err := apiCfg.canModerateBlogComments(r.Context(), user, commentExist.BlogID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if !canModerate {
+		utility.RespondWithError(w, http.StatusUnauthorized, "You are not authorized to moderate this comment")
+		return
+	}
+
+	// updating the comment status
+	moderatedComment, err := apiCfg.DB.UpdateCommentStatus(r.Context(), database.UpdateCommentStatusParams{
+		Status: status,
+		ModeratedBy: uuid.NullUUID{
+			UUID:  user.ID,
+			Valid: true,
+		},
+		ID: commentID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	apiCfg.announceCommentApproval(r.Context(), commentExist.Status, moderatedComment)
+	apiCfg.publishCommentWithdrawn(r.Context(), commentExist.Status, moderatedComment)
+
+	utility.RespondWithJson(w, http.StatusOK, ResponseComment{
+		ID:          moderatedComment.ID,
+		Description: moderatedComment.Description,
+		BlogID:      moderatedComment.BlogID,
+		UserID:      moderatedComment.UserID,
+		Status:      moderatedComment.Status,
+		IsPinned:    moderatedComment.IsPinned,
+		CreatedAt:   moderatedComment.CreatedAt,
+		UpdatedAt:   moderatedComment.UpdatedAt,
+		AccessToken: newAccessToken,
+	})
+}
+
+// function to notify the mentioned users, the blog author and the parent comment writer and push the comment to the readers once it becomes visible
+// comments which were pending or shadow hidden were never shown to anyone so they are announced like new comments
+// the status change is already saved at this point so failing to save the mentions is only logged
+func (apiCfg *ApiConfig) announceCommentApproval(ctx context.Context, previousStatus string, comment database.Comment) {
+	if comment.Status != CommentStatusApproved {
+		return
+	}
+
+	mentions, err := apiCfg.syncMentions(ctx, MentionSourceComment, comment.ID, comment.UserID, comment.Description)
+	if err != nil {
+		log.Println("Error saving mentions: ", err)
+		mentions = []ResponseMention{}
+	}
+	if previousStatus == CommentStatusPending || previousStatus == CommentStatusShadowHidden {
+		apiCfg.notifyNewComment(ctx, comment)
+		apiCfg.publishNewComment(ctx, comment, mentions)
+	}
+}
+
+// handler function to pin or unpin a comment at the top of a blog
+// only one comment can be pinned on a blog at a time
+func (apiCfg *ApiConfig) HandlePinComment(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	// fetching the comment id from url params
+	commentID, err := uuid.Parse(r.PathValue("commentID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid comment id")
+		return
+	}
+
+	// checking if the comment exist or not
+	commentExist, err := apiCfg.DB.GetCommentById(r.Context(), commentID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusNotFound, "Comment not found")
+		return
+	}
+	if commentExist.Status != CommentStatusApproved {
+		utility.RespondWithError(w, http.StatusBadRequest, "Only approved comments can be pinned")
+		return
+	}
+
+	// checking if the user is authorized to pin the comment
+	authorID, err := apiCfg.DB.GetBlogAuthorId(r.Context(), commentExist.BlogID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if authorID != user.ID {
+		utility.RespondWithError(w, http.StatusUnauthorized, "You are not authorized to pin comments on this blog")
+		return
+	}
+
+	// unpinning the comment if it was pinned otherwise replacing the currently pinned comment
+	if !commentExist.IsPinned {
+		err = apiCfg.DB.UnpinCommentsByBlogId(r.Context(), commentExist.BlogID)
+		if err != nil {
+			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+	pinnedComment, err := apiCfg.DB.SetCommentPinned(r.Context(), database.SetCommentPinnedParams{
+		IsPinned: !commentExist.IsPinned,
+		ID:       commentID,
+	})
+	if err != nil {
+		// the unique index on pinned comments stops two comments being pinned by requests running at the same time
+		if isUniqueViolation(err) {
+			utility.RespondWithError(w, http.StatusConflict, "Another comment was pinned at the same time, try again")
+			return
+		}
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, ResponseComment{
+		ID:          pinnedComment.ID,
+		Description: pinnedComment.Description,
+		BlogID:      pinnedComment.BlogID,
+		UserID:      pinnedComment.UserID,
+		Status:      pinnedComment.Status,
+		IsPinned:    pinnedComment.IsPinned,
+		CreatedAt:   pinnedComment.CreatedAt,
+		UpdatedAt:   pinnedComment.UpdatedAt,
+		AccessToken: newAccessToken,
+	})
+}
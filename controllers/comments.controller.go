@@ -1,16 +1,39 @@
 package controllers
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
 	"net/http"
 
 	"github.com/google/uuid"
+	"github.com/harshvardha/blogs/filters"
 	"github.com/harshvardha/blogs/internal/database"
+	"github.com/harshvardha/blogs/notifications"
 	"github.com/harshvardha/blogs/utility"
 )
 
+// error raised by the request paths shared between handlers, it carries the status code to respond with
+type requestError struct {
+	status  int
+	message string
+}
+
+func (e *requestError) Error() string {
+	return e.message
+}
+
+// function to respond with the status code carried by the error, any other error is an internal server error
+func respondWithRequestError(w http.ResponseWriter, err error) {
+	var reqErr *requestError
+	if errors.As(err, &reqErr) {
+		utility.RespondWithError(w, reqErr.status, reqErr.message)
+		return
+	}
+	utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+}
+
 // handler function to create a new comment
 func (apiCfg *ApiConfig) HandleCreateComment(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
 	// decoding the request body
@@ -23,26 +46,149 @@ func (apiCfg *ApiConfig) HandleCreateComment(w http.ResponseWriter, r *http.Requ
 	}
 
 	// adding comment to the blog
-	newComment, err := apiCfg.DB.CreateComment(r.Context(), database.CreateCommentParams{
+	newComment, err := apiCfg.createComment(r.Context(), user, params)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+
+	newComment.AccessToken = newAccessToken
+	utility.RespondWithJson(w, http.StatusCreated, newComment)
+}
+
+// function to create a comment, this is the path every new comment goes through whether it comes from the api or the live channel
+func (apiCfg *ApiConfig) createComment(ctx context.Context, user database.User, params RequestComment) (ResponseComment, error) {
+	// checking if the blog exist or not
+	blog, err := apiCfg.DB.GetBlogById(ctx, params.BlogID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ResponseComment{}, &requestError{http.StatusNotFound, "Blog not found"}
+		}
+		return ResponseComment{}, err
+	}
+	authorID := blog.AuthorID
+
+	// users blocked by or blocking the author cannot comment on the blog
+	blocked, err := apiCfg.isBlockedEitherWay(ctx, user.ID, authorID)
+	if err != nil {
+		return ResponseComment{}, err
+	}
+	if blocked {
+		return ResponseComment{}, &requestError{http.StatusForbidden, "You cannot comment on this blog"}
+	}
+
+	// only the users who can read the blog can comment on it
+	err = apiCfg.checkBlogAccess(ctx, user, blog)
+	if err != nil {
+		return ResponseComment{}, err
+	}
+
+	// checking if the comment being replied to exist on the same blog
+	parentID := uuid.NullUUID{}
+	if params.ParentID != nil {
+		parentComment, err := apiCfg.DB.GetCommentById(ctx, *params.ParentID)
+		if err != nil || parentComment.BlogID != params.BlogID || parentComment.Status != CommentStatusApproved {
+			return ResponseComment{}, &requestError{http.StatusBadRequest, "Invalid parent comment"}
+		}
+		blocked, err := apiCfg.isBlockedEitherWay(ctx, user.ID, parentComment.UserID)
+		if err != nil {
+			return ResponseComment{}, err
+		}
+		if blocked {
+			return ResponseComment{}, &requestError{http.StatusForbidden, "You cannot reply to this comment"}
+		}
+		parentID = uuid.NullUUID{
+			UUID:  parentComment.ID,
+			Valid: true,
+		}
+	}
+
+	// checking if the user is allowed to comment on the blog as per the blog comment settings
+	settings, err := apiCfg.getCommentSettings(ctx, params.BlogID)
+	if err != nil {
+		return ResponseComment{}, err
+	}
+	commentStatus := CommentStatusApproved
+	if authorID != user.ID {
+		switch settings.CommentPolicy {
+		case CommentPolicyClosed:
+			return ResponseComment{}, &requestError{http.StatusForbidden, "Comments are closed on this blog"}
+		case CommentPolicyFollowersOnly:
+			_, err = apiCfg.DB.GetPair(ctx, database.GetPairParams{
+				FollowerID:  user.ID,
+				FollowingID: authorID,
+			})
+			if err != nil {
+				return ResponseComment{}, &requestError{http.StatusForbidden, "Only followers of the author can comment on this blog"}
+			}
+		}
+		if settings.RequireApproval {
+			commentStatus = CommentStatusPending
+		}
+	}
+
+	// running the comment through the content filters
+	verdict, err := apiCfg.checkContent(ctx, filters.Content{
+		Kind:     filters.KindComment,
+		AuthorID: user.ID,
+		Body:     params.Description,
+	})
+	if err != nil {
+		return ResponseComment{}, err
+	}
+	if verdict.Action == filters.ActionReject {
+		return ResponseComment{}, &requestError{http.StatusUnprocessableEntity, "Comment rejected: " + verdict.Reason}
+	}
+	commentStatus = commentStatusForVerdict(verdict, commentStatus)
+
+	// adding comment to the blog
+	newComment, err := apiCfg.DB.CreateComment(ctx, database.CreateCommentParams{
 		Description: params.Description,
 		BlogID:      params.BlogID,
 		UserID:      user.ID,
+		Status:      commentStatus,
+		ParentID:    parentID,
 	})
 	if err != nil {
-		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
-		return
+		return ResponseComment{}, err
+	}
+	apiCfg.recordContent(filters.Content{
+		Kind:     filters.KindComment,
+		EntityID: newComment.ID,
+		AuthorID: user.ID,
+		Body:     newComment.Description,
+	}, true)
+	if verdict.Action != filters.ActionAllow {
+		err = apiCfg.recordContentFlag(ctx, filters.KindComment, newComment.ID, user.ID, verdict)
+		if err != nil {
+			return ResponseComment{}, err
+		}
 	}
 
-	// creating response
-	utility.RespondWithJson(w, http.StatusCreated, ResponseComment{
-		ID:          newComment.ID,
-		Description: newComment.Description,
-		BlogID:      newComment.BlogID,
-		UserID:      newComment.UserID,
-		CreatedAt:   newComment.CreatedAt,
-		UpdatedAt:   newComment.UpdatedAt,
-		AccessToken: newAccessToken,
-	})
+	// saving the mentions in the comment, comments waiting for approval notify once they are approved
+	mentions := []ResponseMention{}
+	if newComment.Status == CommentStatusApproved {
+		mentions, err = apiCfg.syncMentions(ctx, MentionSourceComment, newComment.ID, user.ID, newComment.Description)
+		if err != nil {
+			return ResponseComment{}, err
+		}
+		apiCfg.notifyNewComment(ctx, newComment)
+		apiCfg.publishNewComment(ctx, newComment, mentions)
+	}
+
+	return ResponseComment{
+		ID:                  newComment.ID,
+		Description:         newComment.Description,
+		BlogID:              newComment.BlogID,
+		ParentID:            parentCommentID(newComment.ParentID),
+		UserID:              newComment.UserID,
+		Status:              visibleCommentStatus(newComment.Status),
+		IsPinned:            newComment.IsPinned,
+		RenderedDescription: renderMentions(newComment.Description, mentions),
+		Mentions:            mentions,
+		CreatedAt:           newComment.CreatedAt,
+		UpdatedAt:           newComment.UpdatedAt,
+	}, nil
 }
 
 // handler function to edit a comment
@@ -68,26 +214,125 @@ func (apiCfg *ApiConfig) HandleEditComment(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	// checking if the user wrote this comment, only the writer can edit it
+	commentExist, err := apiCfg.DB.GetCommentById(r.Context(), commentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utility.RespondWithError(w, http.StatusNotFound, "Comment not found")
+			return
+		}
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if commentExist.UserID != user.ID {
+		utility.RespondWithError(w, http.StatusForbidden, "You are not authorized to edit this comment")
+		return
+	}
+
+	// running the edited comment through the content filters
+	verdict, err := apiCfg.checkContent(r.Context(), filters.Content{
+		Kind:     filters.KindComment,
+		EntityID: commentID,
+		AuthorID: user.ID,
+		Body:     params.Description,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if verdict.Action == filters.ActionReject {
+		utility.RespondWithError(w, http.StatusUnprocessableEntity, "Comment rejected: "+verdict.Reason)
+		return
+	}
+
 	// editing the comment
 	editedComment, err := apiCfg.DB.EditComment(r.Context(), database.EditCommentParams{
 		ID:          commentID,
-		BlogID:      params.BlogID,
+		BlogID:      commentExist.BlogID,
 		Description: params.Description,
 	})
 	if err != nil {
 		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	apiCfg.recordContent(filters.Content{
+		Kind:     filters.KindComment,
+		EntityID: commentID,
+		AuthorID: user.ID,
+		Body:     editedComment.Description,
+	}, false)
+
+	// on blogs which require approval edited comments of readers wait for approval again
+	commentStatus := commentStatusForVerdict(verdict, editedComment.Status)
+	if commentStatus == CommentStatusApproved {
+		settings, err := apiCfg.getCommentSettings(r.Context(), editedComment.BlogID)
+		if err != nil {
+			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if settings.RequireApproval {
+			authorID, err := apiCfg.DB.GetBlogAuthorId(r.Context(), editedComment.BlogID)
+			if err != nil {
+				utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			if authorID != user.ID {
+				commentStatus = CommentStatusPending
+			}
+		}
+	}
+	// the status is changed without touching the moderator fields so that an edit does not erase who moderated the comment
+	if commentStatus != editedComment.Status {
+		editedComment, err = apiCfg.DB.SetCommentStatus(r.Context(), database.SetCommentStatusParams{
+			Status: commentStatus,
+			ID:     commentID,
+		})
+		if err != nil {
+			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+	if verdict.Action != filters.ActionAllow {
+		err = apiCfg.recordContentFlag(r.Context(), filters.KindComment, commentID, user.ID, verdict)
+		if err != nil {
+			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	// updating the mentions in the comment, only newly mentioned users are notified
+	var mentions []ResponseMention
+	if editedComment.Status == CommentStatusApproved {
+		mentions, err = apiCfg.syncMentions(r.Context(), MentionSourceComment, commentID, editedComment.UserID, editedComment.Description)
+	} else {
+		mentions, err = apiCfg.getMentions(r.Context(), MentionSourceComment, commentID)
+	}
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// pushing the edit to the readers of the blog, comments which are no longer approved are taken off their views
+	if editedComment.Status == CommentStatusApproved {
+		apiCfg.publishCommentEdited(r.Context(), editedComment, mentions)
+	} else {
+		apiCfg.publishCommentWithdrawn(r.Context(), commentExist.Status, editedComment)
+	}
 
 	// creating response
 	utility.RespondWithJson(w, http.StatusOK, ResponseComment{
-		ID:          editedComment.ID,
-		Description: editedComment.Description,
-		BlogID:      editedComment.BlogID,
-		UserID:      editedComment.UserID,
-		CreatedAt:   editedComment.CreatedAt,
-		UpdatedAt:   editedComment.UpdatedAt,
-		AccessToken: newAccessToken,
+		ID:                  editedComment.ID,
+		Description:         editedComment.Description,
+		BlogID:              editedComment.BlogID,
+		ParentID:            parentCommentID(editedComment.ParentID),
+		UserID:              editedComment.UserID,
+		Status:              visibleCommentStatus(editedComment.Status),
+		IsPinned:            editedComment.IsPinned,
+		RenderedDescription: renderMentions(editedComment.Description, mentions),
+		Mentions:            mentions,
+		CreatedAt:           editedComment.CreatedAt,
+		UpdatedAt:           editedComment.UpdatedAt,
+		AccessToken:         newAccessToken,
 	})
 }
 
@@ -106,13 +351,31 @@ func (apiCfg *ApiConfig) HandleDeleteComment(w http.ResponseWriter, r *http.Requ
 	}
 
 	// checking if the user is authorized to delete this comment or not
+	// the comment writer, the blog author and moderators are allowed to delete a comment
 	commentExist, err := apiCfg.DB.GetCommentById(r.Context(), commentID)
 	if err != nil {
 		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 	if commentExist.UserID != user.ID {
-		utility.RespondWithError(w, http.StatusUnauthorized, "You are not authorized to delete this comment")
+		canModerate, err := apiCfg.canModerateBlogComments(r.Context(), user, commentExist.BlogID)
+		if err != nil {
+			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if !canModerate {
+			utility.RespondWithError(w, http.StatusUnauthorized, "You are not authorized to delete this comment")
+			return
+		}
+	}
+
+	// deleting the mentions in the comment
+	err = apiCfg.DB.DeleteMentionsBySource(r.Context(), database.DeleteMentionsBySourceParams{
+		SourceType: MentionSourceComment,
+		SourceID:   commentID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -122,11 +385,15 @@ func (apiCfg *ApiConfig) HandleDeleteComment(w http.ResponseWriter, r *http.Requ
 		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	apiCfg.publishCommentDeleted(r.Context(), deletedComment)
+
 	utility.RespondWithJson(w, http.StatusOK, ResponseComment{
 		ID:          deletedComment.ID,
 		Description: deletedComment.Description,
 		BlogID:      deletedComment.BlogID,
 		UserID:      deletedComment.UserID,
+		Status:      visibleCommentStatus(deletedComment.Status),
+		IsPinned:    deletedComment.IsPinned,
 		CreatedAt:   deletedComment.CreatedAt,
 		UpdatedAt:   deletedComment.UpdatedAt,
 		AccessToken: newAccessToken,
@@ -151,6 +418,20 @@ func (apiCfg *ApiConfig) HandleLikeComment(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	// checking if the comment exist or not
+	commentExist, err := apiCfg.DB.GetCommentById(r.Context(), commentID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusNotFound, "Comment not found")
+		return
+	}
+	likeNotification := notifications.Notification{
+		UserID:     commentExist.UserID,
+		ActorID:    user.ID,
+		Type:       notifications.TypeCommentLike,
+		EntityType: notifications.EntityComment,
+		EntityID:   commentID,
+	}
+
 	// checking if the comment is liked or not
 	commentLiked, err := apiCfg.DB.IsCommentLiked(r.Context(), database.IsCommentLikedParams{
 		UserID:    user.ID,
@@ -158,11 +439,33 @@ func (apiCfg *ApiConfig) HandleLikeComment(w http.ResponseWriter, r *http.Reques
 	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			// liking the comment if not liked yet
+			// liking the comment if not liked yet, users blocked by or blocking the writer cannot like it
+			blocked, err := apiCfg.isBlockedEitherWay(r.Context(), user.ID, commentExist.UserID)
+			if err != nil {
+				utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			if blocked {
+				utility.RespondWithError(w, http.StatusForbidden, "You cannot like this comment")
+				return
+			}
+			blog, err := apiCfg.DB.GetBlogById(r.Context(), commentExist.BlogID)
+			if err != nil {
+				utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			err = apiCfg.checkBlogAccess(r.Context(), user, blog)
+			if err != nil {
+				respondWithRequestError(w, err)
+				return
+			}
 			err = apiCfg.DB.LikeComment(r.Context(), database.LikeCommentParams{
 				UserID:    user.ID,
 				CommentID: commentID,
 			})
+			if err == nil {
+				apiCfg.notify(r.Context(), likeNotification)
+			}
 		} else {
 			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
 			return
@@ -173,12 +476,16 @@ func (apiCfg *ApiConfig) HandleLikeComment(w http.ResponseWriter, r *http.Reques
 			UserID:    user.ID,
 			CommentID: commentID,
 		})
+		if err == nil {
+			apiCfg.retractNotification(r.Context(), likeNotification)
+		}
 	}
 
 	if err != nil {
 		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	apiCfg.publishCommentLikes(r.Context(), commentExist)
 
 	utility.RespondWithJson(w, http.StatusOK, EmptyResponse{
 		AccessToken: newAccessToken,
@@ -203,8 +510,27 @@ func (apiCfg *ApiConfig) HandleGetAllCommentsByBlogId(w http.ResponseWriter, r *
 		return
 	}
 
+	// checking if the user can read the blog
+	blog, err := apiCfg.DB.GetBlogById(r.Context(), blogID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utility.RespondWithError(w, http.StatusNotFound, "Blog not found")
+			return
+		}
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	err = apiCfg.checkBlogAccess(r.Context(), user, blog)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+
 	// getting all the comments for the blog
-	allComments, err := apiCfg.DB.GetAllCommentsByBlogId(r.Context(), blogID)
+	allComments, err := apiCfg.DB.GetAllCommentsByBlogId(r.Context(), database.GetAllCommentsByBlogIdParams{
+		BlogID: blogID,
+		UserID: user.ID,
+	})
 	if err != nil {
 		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -219,17 +545,36 @@ func (apiCfg *ApiConfig) HandleGetAllCommentsByBlogId(w http.ResponseWriter, r *
 	// creating response
 	var blogComments []ResponseComment
 	for _, comment := range allComments {
+		mentions, err := apiCfg.getMentions(r.Context(), MentionSourceComment, comment.ID)
+		if err != nil {
+			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
 		blogComments = append(blogComments, ResponseComment{
-			ID:          comment.ID,
-			Description: comment.Description,
-			BlogID:      comment.BlogID,
-			UserID:      comment.UserID,
-			LikesCount:  comment.LikesCount,
-			CreatedAt:   comment.CreatedAt,
-			UpdatedAt:   comment.UpdatedAt,
-			AccessToken: newAccessToken,
+			ID:                  comment.ID,
+			Description:         comment.Description,
+			BlogID:              comment.BlogID,
+			ParentID:            parentCommentID(comment.ParentID),
+			UserID:              comment.UserID,
+			LikesCount:          comment.LikesCount,
+			LikedByMe:           comment.LikedByMe,
+			Status:              visibleCommentStatus(comment.Status),
+			IsPinned:            comment.IsPinned,
+			RenderedDescription: renderMentions(comment.Description, mentions),
+			Mentions:            mentions,
+			CreatedAt:           comment.CreatedAt,
+			UpdatedAt:           comment.UpdatedAt,
+			AccessToken:         newAccessToken,
 		})
 	}
 
 	utility.RespondWithJson(w, http.StatusOK, blogComments)
 }
+
+// function to convert the nullable parent id of a comment into its json form
+func parentCommentID(parentID uuid.NullUUID) *uuid.UUID {
+	if !parentID.Valid {
+		return nil
+	}
+	return &parentID.UUID
+}
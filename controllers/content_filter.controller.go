@@ -0,0 +1,257 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/harshvardha/blogs/filters"
+	"github.com/harshvardha/blogs/internal/database"
+	"github.com/harshvardha/blogs/utility"
+)
+
+// moderation states of a blog, shadow hidden and hidden blogs are only visible to their author
+const (
+	BlogModerationVisible      = "visible"
+	BlogModerationFlagged      = "flagged"
+	BlogModerationShadowHidden = "shadow_hidden"
+	BlogModerationHidden       = "hidden"
+)
+
+// comments caught by the spam filters are shadow hidden, they are only visible to their writer
+const CommentStatusShadowHidden = "shadow_hidden"
+
+// decisions a moderator can take on flagged content
+const (
+	FlagDecisionRestore = "restore"
+	FlagDecisionHide    = "hide"
+)
+
+// function to run a piece of content through the configured content filter
+func (apiCfg *ApiConfig) checkContent(ctx context.Context, content filters.Content) (filters.Verdict, error) {
+	if apiCfg.ContentFilter == nil {
+		return filters.Allowed, nil
+	}
+
+	return apiCfg.ContentFilter.Check(ctx, content)
+}
+
+// function to let the content filters remember a blog or comment once it was saved
+func (apiCfg *ApiConfig) recordContent(content filters.Content, created bool) {
+	if recorder, ok := apiCfg.ContentFilter.(filters.Recorder); ok {
+		recorder.Record(content, created)
+	}
+}
+
+// function to save a flag raised by the content filter so that moderators can review it
+func (apiCfg *ApiConfig) recordContentFlag(ctx context.Context, entityType string, entityID uuid.UUID, userID uuid.UUID, verdict filters.Verdict) error {
+	_, err := apiCfg.DB.CreateContentFlag(ctx, database.CreateContentFlagParams{
+		EntityType: entityType,
+		EntityID:   entityID,
+		UserID:     userID,
+		Action:     verdict.Action.String(),
+		Reason:     verdict.Reason,
+	})
+	return err
+}
+
+// how strongly each comment status keeps a comment away from the readers
+var commentStatusSeverity = map[string]int{
+	CommentStatusApproved:     0,
+	CommentStatusPending:      1,
+	CommentStatusShadowHidden: 2,
+	CommentStatusHidden:       3,
+	CommentStatusRejected:     3,
+}
+
+// function to map the filter verdict to the status of a comment
+// the verdict only makes the status stricter so that editing cannot undo a moderation decision
+func commentStatusForVerdict(verdict filters.Verdict, status string) string {
+	verdictStatus := status
+	switch verdict.Action {
+	case filters.ActionFlag:
+		verdictStatus = CommentStatusPending
+	case filters.ActionShadowHide:
+		verdictStatus = CommentStatusShadowHidden
+	}
+	if commentStatusSeverity[verdictStatus] < commentStatusSeverity[status] {
+		return status
+	}
+	return verdictStatus
+}
+
+// how strongly each moderation status keeps a blog away from the readers
+var blogModerationSeverity = map[string]int{
+	BlogModerationVisible:      0,
+	BlogModerationFlagged:      1,
+	BlogModerationShadowHidden: 2,
+	BlogModerationHidden:       3,
+}
+
+// function to map the filter verdict to the moderation status of a blog
+// the verdict only makes the status stricter so that editing cannot undo a moderation decision
+func blogModerationStatusForVerdict(verdict filters.Verdict, status string) string {
+	verdictStatus := BlogModerationVisible
+	switch verdict.Action {
+	case filters.ActionFlag:
+		verdictStatus = BlogModerationFlagged
+	case filters.ActionShadowHide:
+		verdictStatus = BlogModerationShadowHidden
+	}
+	if blogModerationSeverity[verdictStatus] < blogModerationSeverity[status] {
+		return status
+	}
+	return verdictStatus
+}
+
+// function to check if the user can see a blog, hidden blogs are only visible to their author and the moderators
+func canViewBlog(user database.User, authorID uuid.UUID, moderationStatus string) bool {
+	if moderationStatus != BlogModerationShadowHidden && moderationStatus != BlogModerationHidden {
+		return true
+	}
+	return authorID == user.ID || isModerator(user)
+}
+
+// function to hide the shadow hidden status from the writer of the comment
+func visibleCommentStatus(status string) string {
+	if status == CommentStatusShadowHidden {
+		return CommentStatusApproved
+	}
+	return status
+}
+
+// handler function to get the content flagged by the filters which is not yet reviewed
+func (apiCfg *ApiConfig) HandleGetContentFlags(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	if !isModerator(user) {
+		utility.RespondWithError(w, http.StatusUnauthorized, "You are not authorized to review flagged content")
+		return
+	}
+
+	// fetching the flags
+	limit, offset := utility.ParsePagination(r)
+	flags, err := apiCfg.DB.GetUnreviewedContentFlags(r.Context(), database.GetUnreviewedContentFlagsParams{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	contentFlags := []ContentFlagResponse{}
+	for _, flag := range flags {
+		contentFlags = append(contentFlags, ContentFlagResponse{
+			ID:          flag.ID,
+			EntityType:  flag.EntityType,
+			EntityID:    flag.EntityID,
+			UserID:      flag.UserID,
+			Action:      flag.Action,
+			Reason:      flag.Reason,
+			CreatedAt:   flag.CreatedAt,
+			AccessToken: newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, contentFlags)
+}
+
+// handler function to review a flag by either restoring or hiding the flagged content
+func (apiCfg *ApiConfig) HandleReviewContentFlag(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	if !isModerator(user) {
+		utility.RespondWithError(w, http.StatusUnauthorized, "You are not authorized to review flagged content")
+		return
+	}
+
+	// fetching the flag id from url params
+	flagID, err := uuid.Parse(r.PathValue("flagID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid flag id")
+		return
+	}
+
+	// decoding the request body
+	decoder := json.NewDecoder(r.Body)
+	params := ContentFlagReviewRequest{}
+	err = decoder.Decode(&params)
+	if err != nil || (params.Decision != FlagDecisionRestore && params.Decision != FlagDecisionHide) {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid review decision")
+		return
+	}
+
+	// marking the flag as reviewed
+	flag, err := apiCfg.DB.MarkContentFlagReviewed(r.Context(), database.MarkContentFlagReviewedParams{
+		ReviewedBy: uuid.NullUUID{
+			UUID:  user.ID,
+			Valid: true,
+		},
+		ID: flagID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusNotFound, "Flag not found")
+		return
+	}
+
+	// applying the decision to the flagged content
+	switch flag.EntityType {
+	case filters.KindBlog:
+		moderationStatus := BlogModerationVisible
+		if params.Decision == FlagDecisionHide {
+			moderationStatus = BlogModerationHidden
+		}
+		err = apiCfg.DB.SetBlogModerationStatus(r.Context(), database.SetBlogModerationStatusParams{
+			ModerationStatus: moderationStatus,
+			ID:               flag.EntityID,
+		})
+	case filters.KindComment:
+		err = apiCfg.reviewFlaggedComment(r.Context(), user, flag.EntityID, params.Decision)
+	}
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, EmptyResponse{
+		AccessToken: newAccessToken,
+	})
+}
+
+// function to apply a review decision to a flagged comment
+// restoring gives the comment the status it would have had without the flag, so it still goes through the approval queue of the blog
+// comments the blog author already rejected or hid after the flag keep that status
+func (apiCfg *ApiConfig) reviewFlaggedComment(ctx context.Context, user database.User, commentID uuid.UUID, decision string) error {
+	flaggedComment, err := apiCfg.DB.GetCommentById(ctx, commentID)
+	if err != nil {
+		return err
+	}
+
+	commentStatus := CommentStatusHidden
+	if decision == FlagDecisionRestore {
+		if flaggedComment.Status != CommentStatusPending && flaggedComment.Status != CommentStatusShadowHidden {
+			return nil
+		}
+		settings, err := apiCfg.getCommentSettings(ctx, flaggedComment.BlogID)
+		if err != nil {
+			return err
+		}
+		commentStatus = CommentStatusApproved
+		if settings.RequireApproval {
+			commentStatus = CommentStatusPending
+		}
+	}
+
+	reviewedComment, err := apiCfg.DB.UpdateCommentStatus(ctx, database.UpdateCommentStatusParams{
+		Status: commentStatus,
+		ModeratedBy: uuid.NullUUID{
+			UUID:  user.ID,
+			Valid: true,
+		},
+		ID: commentID,
+	})
+	if err != nil {
+		return err
+	}
+	apiCfg.announceCommentApproval(ctx, flaggedComment.Status, reviewedComment)
+	apiCfg.publishCommentWithdrawn(ctx, flaggedComment.Status, reviewedComment)
+	return nil
+}
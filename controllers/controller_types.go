@@ -4,21 +4,51 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/harshvardha/blogs/analytics"
+	"github.com/harshvardha/blogs/filters"
 	"github.com/harshvardha/blogs/internal/database"
+	"github.com/harshvardha/blogs/notifications"
+	"github.com/harshvardha/blogs/realtime"
 )
 
 type ApiConfig struct {
-	DB        *database.Queries
-	JwtSecret string
+	DB            *database.Queries
+	JwtSecret     string
+	ContentFilter filters.ContentFilter
+	Notifier      *notifications.Service
+	Broker        realtime.Broker
+	Presence      *realtime.Presence
+	Views         *analytics.ViewRecorder
 }
 
 type ResponseUser struct {
-	ID          uuid.UUID `json:"id"`
-	Email       string    `json:"email"`
-	Username    string    `json:"username"`
-	AccessToken string    `json:"access_token"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          uuid.UUID        `json:"id"`
+	Email       string           `json:"email"`
+	Username    string           `json:"username"`
+	Profile     *ResponseProfile `json:"profile,omitempty"`
+	AccessToken string           `json:"access_token"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+type ResponseProfile struct {
+	DisplayName string            `json:"display_name"`
+	Bio         string            `json:"bio"`
+	AvatarURL   string            `json:"avatar_url"`
+	Website     string            `json:"website"`
+	Location    string            `json:"location"`
+	SocialLinks map[string]string `json:"social_links"`
+	IsPrivate   bool              `json:"is_private"`
+}
+
+type PublicProfileResponse struct {
+	ID       uuid.UUID `json:"id"`
+	Username string    `json:"username"`
+	ResponseProfile
+	FollowersCount int64     `json:"followers_count"`
+	FollowingCount int64     `json:"following_count"`
+	PostsCount     int64     `json:"posts_count"`
+	CreatedAt      time.Time `json:"created_at"`
 }
 
 type EmptyResponse struct {
@@ -39,17 +69,48 @@ type BlogSearchResult struct {
 }
 
 type ResponseBlog struct {
-	ID           uuid.UUID `json:"id"`
-	Title        string    `json:"title"`
-	AuthorID     uuid.UUID `json:"author_id"`
-	AuthorName   string    `json:"author_name"`
-	ThumbnailURL string    `json:"thumbnail_url"`
-	Content      string    `json:"content"`
-	Category     string    `json:"category"`
-	Likes        int64     `json:"likes"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
-	AccessToken  string    `json:"access_token"`
+	ID              uuid.UUID         `json:"id"`
+	Title           string            `json:"title"`
+	AuthorID        uuid.UUID         `json:"author_id"`
+	AuthorName      string            `json:"author_name"`
+	ThumbnailURL    string            `json:"thumbnail_url"`
+	Content         string            `json:"content"`
+	Category        string            `json:"category"`
+	Visibility      string            `json:"visibility"`
+	Likes           int64             `json:"likes"`
+	LikedByMe       bool              `json:"liked_by_me"`
+	RenderedContent string            `json:"rendered_content"`
+	Mentions        []ResponseMention `json:"mentions"`
+	Authors         []BlogAuthor      `json:"authors,omitempty"`
+	Series          *BlogSeriesInfo   `json:"series,omitempty"`
+	PublicationID   *uuid.UUID        `json:"publication_id,omitempty"`
+	Draft           bool              `json:"draft,omitempty"`
+	ReviewStatus    string            `json:"review_status,omitempty"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+	AccessToken     string            `json:"access_token"`
+}
+
+type BlogAuthor struct {
+	UserID      uuid.UUID `json:"user_id"`
+	Username    string    `json:"username"`
+	DisplayName string    `json:"display_name"`
+	AvatarURL   string    `json:"avatar_url"`
+	Role        string    `json:"role"`
+}
+
+type BlogSeriesInfo struct {
+	ID         uuid.UUID       `json:"id"`
+	Title      string          `json:"title"`
+	Part       int             `json:"part"`
+	TotalParts int             `json:"total_parts"`
+	Previous   *BlogSeriesLink `json:"previous"`
+	Next       *BlogSeriesLink `json:"next"`
+}
+
+type BlogSeriesLink struct {
+	BlogID uuid.UUID `json:"blog_id"`
+	Title  string    `json:"title"`
 }
 
 type RequestBlog struct {
@@ -57,40 +118,131 @@ type RequestBlog struct {
 	ThumbnailURL string `json:"thumbnail_url"`
 	Content      string `json:"content"`
 	Category     string `json:"category"`
+	Visibility   string `json:"visibility"`
+	Publication  string `json:"publication"`
 }
 
 type RequestComment struct {
-	BlogID      uuid.UUID `json:"blog_id"`
-	Description string    `json:"description"`
+	BlogID      uuid.UUID  `json:"blog_id"`
+	ParentID    *uuid.UUID `json:"parent_id"`
+	Description string     `json:"description"`
 }
 
 type ResponseComment struct {
-	ID          uuid.UUID `json:"id"`
-	Description string    `json:"description"`
-	BlogID      uuid.UUID `json:"blog_id"`
-	UserID      uuid.UUID `json:"user_id"`
-	LikesCount  int64     `json:"likes_count"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	AccessToken string    `json:"access_token"`
+	ID                  uuid.UUID         `json:"id"`
+	Description         string            `json:"description"`
+	BlogID              uuid.UUID         `json:"blog_id"`
+	ParentID            *uuid.UUID        `json:"parent_id"`
+	UserID              uuid.UUID         `json:"user_id"`
+	LikesCount          int64             `json:"likes_count"`
+	LikedByMe           bool              `json:"liked_by_me"`
+	Status              string            `json:"status"`
+	IsPinned            bool              `json:"is_pinned"`
+	RenderedDescription string            `json:"rendered_description"`
+	Mentions            []ResponseMention `json:"mentions"`
+	CreatedAt           time.Time         `json:"created_at"`
+	UpdatedAt           time.Time         `json:"updated_at"`
+	AccessToken         string            `json:"access_token"`
+}
+
+type CommentSettingsRequest struct {
+	CommentPolicy   string `json:"comment_policy"`
+	RequireApproval bool   `json:"require_approval"`
+}
+
+type CommentSettingsResponse struct {
+	BlogID          uuid.UUID `json:"blog_id"`
+	CommentPolicy   string    `json:"comment_policy"`
+	RequireApproval bool      `json:"require_approval"`
+	AccessToken     string    `json:"access_token"`
 }
 
 type CollectionRequest struct {
-	Name string `json:"name"`
+	Name        string  `json:"name"`
+	Visibility  string  `json:"visibility"`
+	Description *string `json:"description"`
+	CoverURL    *string `json:"cover_url"`
 }
 
 type CollectionResponse struct {
 	ID          uuid.UUID `json:"id"`
 	Name        string    `json:"name"`
 	UserID      uuid.UUID `json:"user_id"`
+	Visibility  string    `json:"visibility"`
+	Description string    `json:"description"`
+	CoverURL    string    `json:"cover_url"`
+	Role        string    `json:"role"`
+	ShareToken  string    `json:"share_token,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 	AccessToken string    `json:"access_token"`
 }
 
+type PublicCollectionResponse struct {
+	ID             uuid.UUID           `json:"id"`
+	Name           string              `json:"name"`
+	UserID         uuid.UUID           `json:"user_id"`
+	Visibility     string              `json:"visibility"`
+	Description    string              `json:"description"`
+	CoverURL       string              `json:"cover_url"`
+	BlogsCount     int64               `json:"blogs_count"`
+	FollowersCount int64               `json:"followers_count"`
+	FollowedByMe   bool                `json:"followed_by_me"`
+	Blogs          []BlogsInCollection `json:"blogs,omitempty"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+	AccessToken    string              `json:"access_token"`
+}
+
+type SeriesRequest struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+type SeriesBlogRequest struct {
+	SeriesID uuid.UUID `json:"series_id"`
+	BlogID   uuid.UUID `json:"blog_id"`
+}
+
+type SeriesReorderRequest struct {
+	BlogIDs []uuid.UUID `json:"blog_ids"`
+}
+
+type SeriesPartResponse struct {
+	BlogID       uuid.UUID `json:"blog_id"`
+	Title        string    `json:"title"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	Part         int       `json:"part"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type SeriesResponse struct {
+	ID          uuid.UUID            `json:"id"`
+	AuthorID    uuid.UUID            `json:"author_id"`
+	Title       string               `json:"title"`
+	Description string               `json:"description"`
+	PartsCount  int64                `json:"parts_count"`
+	Parts       []SeriesPartResponse `json:"parts,omitempty"`
+	CreatedAt   time.Time            `json:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+	AccessToken string               `json:"access_token"`
+}
+
 type CollectionBlogRequest struct {
 	CollectionID uuid.UUID `json:"collection_id"`
 	BlogID       uuid.UUID `json:"blog_id"`
+	Note         string    `json:"note"`
+}
+
+type CollectionReorderRequest struct {
+	BlogID uuid.UUID `json:"blog_id"`
+	Index  int       `json:"index"`
+}
+
+type CollectionItemPositionResponse struct {
+	BlogID      uuid.UUID `json:"blog_id"`
+	Position    int64     `json:"position"`
+	AccessToken string    `json:"access_token"`
 }
 
 type CollectionBlogResponse struct {
@@ -98,29 +250,435 @@ type CollectionBlogResponse struct {
 	CollectionName string    `json:"collection_name"`
 	BlogID         uuid.UUID `json:"blog_id"`
 	BlogName       string    `json:"blog_name"`
+	Note           string    `json:"note"`
 	AccessToken    string    `json:"access_token"`
 }
 
 type CategoryRequest struct {
-	Name string `json:"name"`
+	Name        string `json:"name"`
+	Publication string `json:"publication"`
 }
 
 type CategoryResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	Name           string     `json:"name"`
+	RequiresReview bool       `json:"requires_review"`
+	PublicationID  *uuid.UUID `json:"publication_id,omitempty"`
+	AccessToken    string     `json:"access_token"`
+}
+
+type CategoryReviewRequest struct {
+	RequiresReview bool `json:"requires_review"`
+}
+
+type BlogReviewerRequest struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
+type BlogReviewerResponse struct {
+	UserID      uuid.UUID  `json:"user_id"`
+	Username    string     `json:"username"`
+	DisplayName string     `json:"display_name"`
+	AvatarURL   string     `json:"avatar_url"`
+	Decision    string     `json:"decision"`
+	DecidedAt   *time.Time `json:"decided_at"`
+	AssignedAt  time.Time  `json:"assigned_at"`
+}
+
+type BlogReviewResponse struct {
+	BlogID         uuid.UUID              `json:"blog_id"`
+	ReviewStatus   string                 `json:"review_status"`
+	Published      bool                   `json:"published"`
+	RequiresReview bool                   `json:"requires_review"`
+	Reviewers      []BlogReviewerResponse `json:"reviewers"`
+	AccessToken    string                 `json:"access_token"`
+}
+
+type ReviewDecisionRequest struct {
+	Decision string `json:"decision"`
+}
+
+type ReviewQueueResponse struct {
+	BlogID       uuid.UUID `json:"blog_id"`
+	Title        string    `json:"title"`
+	AuthorID     uuid.UUID `json:"author_id"`
+	AuthorName   string    `json:"author_name"`
+	ReviewStatus string    `json:"review_status"`
+	Decision     string    `json:"decision"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	AccessToken  string    `json:"access_token"`
+}
+
+type ReviewCommentRequest struct {
+	Body  string `json:"body"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+type ReviewCommentResolveRequest struct {
+	Resolved bool `json:"resolved"`
+}
+
+type ReviewCommentResponse struct {
 	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
+	BlogID      uuid.UUID `json:"blog_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Username    string    `json:"username"`
+	Body        string    `json:"body"`
+	Start       int32     `json:"start"`
+	End         int32     `json:"end"`
+	QuotedText  string    `json:"quoted_text"`
+	Resolved    bool      `json:"resolved"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
 	AccessToken string    `json:"access_token"`
 }
 
 type BlogsInCollection struct {
-	BlogID           uuid.UUID `json:"blog_id"`
-	BlogTitle        string    `json:"blog_title"`
-	BlogAuthorID     uuid.UUID `json:"blog_author_id"`
-	BlogAuthorName   string    `json:"blog_author_name"`
-	BlogThumbnailURL string    `json:"blog_thumbnail_url"`
-	BlogContent      string    `json:"blog_content"`
-	BlogCategoryID   uuid.UUID `json:"blog_category_id"`
-	BlogCategoryName string    `json:"blog_category_name"`
-	BlogCreatedAt    time.Time `json:"blog_created_at"`
-	BlogUpdatedAt    time.Time `json:"blog_updated_at"`
-	AccessToken      string    `json:"access_token"`
+	BlogID           uuid.UUID  `json:"blog_id"`
+	BlogTitle        string     `json:"blog_title"`
+	BlogAuthorID     uuid.UUID  `json:"blog_author_id"`
+	BlogAuthorName   string     `json:"blog_author_name"`
+	BlogThumbnailURL string     `json:"blog_thumbnail_url"`
+	BlogContent      string     `json:"blog_content"`
+	BlogCategoryID   uuid.UUID  `json:"blog_category_id"`
+	BlogCategoryName string     `json:"blog_category_name"`
+	AddedBy          *uuid.UUID `json:"added_by"`
+	Note             string     `json:"note"`
+	Position         int64      `json:"position"`
+	BlogCreatedAt    time.Time  `json:"blog_created_at"`
+	BlogUpdatedAt    time.Time  `json:"blog_updated_at"`
+	AccessToken      string     `json:"access_token"`
+}
+
+type ContentFlagResponse struct {
+	ID          uuid.UUID `json:"id"`
+	EntityType  string    `json:"entity_type"`
+	EntityID    uuid.UUID `json:"entity_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Action      string    `json:"action"`
+	Reason      string    `json:"reason"`
+	CreatedAt   time.Time `json:"created_at"`
+	AccessToken string    `json:"access_token"`
+}
+
+type ContentFlagReviewRequest struct {
+	Decision string `json:"decision"`
+}
+
+type ReportRequest struct {
+	EntityType string    `json:"entity_type"`
+	EntityID   uuid.UUID `json:"entity_id"`
+	ReasonCode string    `json:"reason_code"`
+	Details    string    `json:"details"`
+}
+
+type ReportResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	ReporterID     uuid.UUID  `json:"reporter_id"`
+	EntityType     string     `json:"entity_type"`
+	EntityID       uuid.UUID  `json:"entity_id"`
+	ReasonCode     string     `json:"reason_code"`
+	Details        string     `json:"details"`
+	Status         string     `json:"status"`
+	AssigneeID     *uuid.UUID `json:"assignee_id"`
+	ActionTaken    string     `json:"action_taken"`
+	ResolutionNote string     `json:"resolution_note"`
+	ResolvedAt     *time.Time `json:"resolved_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	AccessToken    string     `json:"access_token"`
+}
+
+type ReportAssignRequest struct {
+	AssigneeID uuid.UUID `json:"assignee_id"`
+}
+
+type ReportResolveRequest struct {
+	Action        string `json:"action"`
+	Message       string `json:"message"`
+	DurationHours int    `json:"duration_hours"`
+	Note          string `json:"note"`
+}
+
+type UserWarningResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Message     string    `json:"message"`
+	CreatedAt   time.Time `json:"created_at"`
+	AccessToken string    `json:"access_token"`
+}
+
+type ResponseMention struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Username string    `json:"username"`
+}
+
+type MentionResponse struct {
+	SourceType    string    `json:"source_type"`
+	SourceID      uuid.UUID `json:"source_id"`
+	MentionerID   uuid.UUID `json:"mentioner_id"`
+	MentionerName string    `json:"mentioner_name"`
+	CreatedAt     time.Time `json:"created_at"`
+	AccessToken   string    `json:"access_token"`
+}
+
+type NotificationResponse struct {
+	Type            string    `json:"type"`
+	EntityType      string    `json:"entity_type"`
+	EntityID        uuid.UUID `json:"entity_id"`
+	ActorsCount     int64     `json:"actors_count"`
+	LatestActorName string    `json:"latest_actor_name"`
+	Message         string    `json:"message"`
+	Unread          bool      `json:"unread"`
+	LatestAt        time.Time `json:"latest_at"`
+}
+
+type NotificationsResponse struct {
+	UnreadCount   int64                  `json:"unread_count"`
+	Notifications []NotificationResponse `json:"notifications"`
+	AccessToken   string                 `json:"access_token"`
+}
+
+type NotificationReadRequest struct {
+	Type       string    `json:"type"`
+	EntityType string    `json:"entity_type"`
+	EntityID   uuid.UUID `json:"entity_id"`
+}
+
+type NotificationPreferencesResponse struct {
+	Preferences map[string]bool `json:"preferences"`
+	AccessToken string          `json:"access_token"`
+}
+
+type BlogLikesEvent struct {
+	BlogID uuid.UUID `json:"blog_id"`
+	Likes  int64     `json:"likes"`
+}
+
+type CommentLikesEvent struct {
+	CommentID uuid.UUID `json:"comment_id"`
+	BlogID    uuid.UUID `json:"blog_id"`
+	Likes     int64     `json:"likes"`
+}
+
+type CommentDeletedEvent struct {
+	CommentID uuid.UUID `json:"comment_id"`
+	BlogID    uuid.UUID `json:"blog_id"`
+}
+
+type LiveMessage struct {
+	Type        string     `json:"type"`
+	Description string     `json:"description"`
+	ParentID    *uuid.UUID `json:"parent_id"`
+}
+
+type LivePresenceEvent struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Username string    `json:"username"`
+}
+
+type LiveErrorEvent struct {
+	Error string `json:"error"`
+}
+
+type FollowUserResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Username    string    `json:"username"`
+	DisplayName string    `json:"display_name"`
+	AvatarURL   string    `json:"avatar_url"`
+	FollowedAt  time.Time `json:"followed_at"`
+	IsFollowing bool      `json:"is_following"`
+	FollowsYou  bool      `json:"follows_you"`
+	Mutual      bool      `json:"mutual"`
+	AccessToken string    `json:"access_token"`
+}
+
+type RelationshipResponse struct {
+	UserID      uuid.UUID `json:"user_id"`
+	IsFollowing bool      `json:"is_following"`
+	FollowsYou  bool      `json:"follows_you"`
+	Mutual      bool      `json:"mutual"`
+	AccessToken string    `json:"access_token"`
+}
+
+type RestrictedUserResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Username    string    `json:"username"`
+	DisplayName string    `json:"display_name"`
+	AvatarURL   string    `json:"avatar_url"`
+	Since       time.Time `json:"since"`
+	AccessToken string    `json:"access_token"`
+}
+
+type FollowStatusResponse struct {
+	Status      string `json:"status"`
+	AccessToken string `json:"access_token"`
+}
+
+type FollowRequestResponse struct {
+	UserID      uuid.UUID `json:"user_id"`
+	Username    string    `json:"username"`
+	DisplayName string    `json:"display_name"`
+	AvatarURL   string    `json:"avatar_url"`
+	RequestedAt time.Time `json:"requested_at"`
+	AccessToken string    `json:"access_token"`
+}
+
+type UserRecommendationResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Username    string    `json:"username"`
+	DisplayName string    `json:"display_name"`
+	AvatarURL   string    `json:"avatar_url"`
+	Reason      string    `json:"reason"`
+	Score       float64   `json:"score"`
+	AccessToken string    `json:"access_token"`
+}
+
+type RelatedBlogResponse struct {
+	ID           uuid.UUID `json:"id"`
+	Title        string    `json:"title"`
+	AuthorID     uuid.UUID `json:"author_id"`
+	AuthorName   string    `json:"author_name"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	Score        float64   `json:"score"`
+	AccessToken  string    `json:"access_token"`
+}
+
+type RankedBlogResponse struct {
+	ID           uuid.UUID `json:"id"`
+	Title        string    `json:"title"`
+	AuthorID     uuid.UUID `json:"author_id"`
+	AuthorName   string    `json:"author_name"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	Category     string    `json:"category"`
+	Score        float64   `json:"score"`
+	AccessToken  string    `json:"access_token"`
+}
+
+type BlogAnalyticsResponse struct {
+	BlogID        uuid.UUID `json:"blog_id"`
+	Title         string    `json:"title"`
+	PublishedAt   time.Time `json:"published_at"`
+	Views         int64     `json:"views"`
+	UniqueReaders int64     `json:"unique_readers"`
+	Likes         int64     `json:"likes"`
+	Comments      int64     `json:"comments"`
+	AccessToken   string    `json:"access_token"`
+}
+
+type DailyAnalyticsResponse struct {
+	Day           string `json:"day"`
+	Views         int64  `json:"views"`
+	UniqueReaders int64  `json:"unique_readers"`
+	Likes         int64  `json:"likes"`
+	Comments      int64  `json:"comments"`
+	NewFollowers  int64  `json:"new_followers"`
+	AccessToken   string `json:"access_token"`
+}
+
+type CollectionMemberRequest struct {
+	UserID uuid.UUID `json:"user_id"`
+	Role   string    `json:"role"`
+}
+
+type CollectionMemberResponse struct {
+	UserID      uuid.UUID `json:"user_id"`
+	Username    string    `json:"username"`
+	DisplayName string    `json:"display_name"`
+	AvatarURL   string    `json:"avatar_url"`
+	Role        string    `json:"role"`
+	Status      string    `json:"status"`
+	Since       time.Time `json:"since"`
+	AccessToken string    `json:"access_token"`
+}
+
+type BlogContributorRequest struct {
+	UserID uuid.UUID `json:"user_id"`
+	Role   string    `json:"role"`
+}
+
+type BlogContributorResponse struct {
+	UserID      uuid.UUID `json:"user_id"`
+	Username    string    `json:"username"`
+	DisplayName string    `json:"display_name"`
+	AvatarURL   string    `json:"avatar_url"`
+	Role        string    `json:"role"`
+	Status      string    `json:"status"`
+	Since       time.Time `json:"since"`
+	AccessToken string    `json:"access_token"`
+}
+
+type BlogInviteResponse struct {
+	BlogID      uuid.UUID `json:"blog_id"`
+	BlogTitle   string    `json:"blog_title"`
+	AuthorID    uuid.UUID `json:"author_id"`
+	AuthorName  string    `json:"author_name"`
+	Role        string    `json:"role"`
+	InvitedAt   time.Time `json:"invited_at"`
+	AccessToken string    `json:"access_token"`
+}
+
+type CollectionInviteResponse struct {
+	CollectionID   uuid.UUID `json:"collection_id"`
+	CollectionName string    `json:"collection_name"`
+	OwnerID        uuid.UUID `json:"owner_id"`
+	OwnerName      string    `json:"owner_name"`
+	Role           string    `json:"role"`
+	InvitedAt      time.Time `json:"invited_at"`
+	AccessToken    string    `json:"access_token"`
+}
+
+type PublicationRequest struct {
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type PublicationResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Slug        string    `json:"slug"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Role        string    `json:"role,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	AccessToken string    `json:"access_token"`
+}
+
+type PublicationMemberRequest struct {
+	UserID uuid.UUID `json:"user_id"`
+	Role   string    `json:"role"`
+}
+
+type PublicationMemberResponse struct {
+	UserID      uuid.UUID `json:"user_id"`
+	Username    string    `json:"username"`
+	DisplayName string    `json:"display_name"`
+	AvatarURL   string    `json:"avatar_url"`
+	Role        string    `json:"role"`
+	Status      string    `json:"status"`
+	Since       time.Time `json:"since"`
+	AccessToken string    `json:"access_token"`
+}
+
+type PublicationInviteResponse struct {
+	PublicationID   uuid.UUID  `json:"publication_id"`
+	PublicationSlug string     `json:"publication_slug"`
+	PublicationName string     `json:"publication_name"`
+	Role            string     `json:"role"`
+	InvitedBy       *uuid.UUID `json:"invited_by"`
+	InvitedAt       time.Time  `json:"invited_at"`
+	AccessToken     string     `json:"access_token"`
+}
+
+type PublicationBlogResponse struct {
+	ID           uuid.UUID `json:"id"`
+	Title        string    `json:"title"`
+	AuthorID     uuid.UUID `json:"author_id"`
+	AuthorName   string    `json:"author_name"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	Category     string    `json:"category"`
+	Likes        int64     `json:"likes"`
+	CreatedAt    time.Time `json:"created_at"`
+	AccessToken  string    `json:"access_token"`
 }
@@ -0,0 +1,256 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/harshvardha/blogs/internal/database"
+	"github.com/harshvardha/blogs/realtime"
+	"github.com/harshvardha/blogs/utility"
+)
+
+// interval at which a comment is sent on idle event streams so that proxies do not close them
+const eventStreamKeepAliveInterval = 30 * time.Second
+
+// interval at which the blocks of the viewer of an event stream and their access to the blog are checked again
+const eventViewerRefreshInterval = time.Minute
+
+// eventViewer is the user reading an event stream along with what they are allowed to receive
+// the blocks and the blog access are cached for the connection so that events do not query the database for every subscriber
+type eventViewer struct {
+	apiCfg      *ApiConfig
+	user        database.User
+	blogID      uuid.UUID
+	blockedIDs  map[uuid.UUID]bool
+	refreshedAt time.Time
+}
+
+// function to publish an event to the clients subscribed to the topic, a failed publish should not fail the request which caused it
+func (apiCfg *ApiConfig) publish(ctx context.Context, topic string, eventType string, data any) {
+	apiCfg.publishBy(ctx, topic, eventType, uuid.Nil, data)
+}
+
+// function to publish an event caused by the actor, it does not reach the users blocked by or blocking the actor
+func (apiCfg *ApiConfig) publishBy(ctx context.Context, topic string, eventType string, actorID uuid.UUID, data any) {
+	if apiCfg.Broker == nil {
+		return
+	}
+
+	event, err := realtime.NewEvent(topic, eventType, data)
+	if err == nil {
+		if actorID != uuid.Nil {
+			event.ActorID = &actorID
+		}
+		err = apiCfg.Broker.Publish(ctx, event)
+	}
+	if err != nil {
+		log.Println("Error publishing event: ", err)
+	}
+}
+
+// function to push a comment which just became visible to the readers of the blog
+func (apiCfg *ApiConfig) publishNewComment(ctx context.Context, comment database.Comment, mentions []ResponseMention) {
+	apiCfg.publishComment(ctx, realtime.EventCommentCreated, comment, mentions)
+}
+
+// function to push an edited comment to the readers of the blog
+func (apiCfg *ApiConfig) publishCommentEdited(ctx context.Context, comment database.Comment, mentions []ResponseMention) {
+	apiCfg.publishComment(ctx, realtime.EventCommentEdited, comment, mentions)
+}
+
+// function to tell the readers of the blog that a comment was removed
+func (apiCfg *ApiConfig) publishCommentDeleted(ctx context.Context, comment database.Comment) {
+	apiCfg.publishBy(ctx, realtime.BlogTopic(comment.BlogID), realtime.EventCommentDeleted, comment.UserID, CommentDeletedEvent{
+		CommentID: comment.ID,
+		BlogID:    comment.BlogID,
+	})
+}
+
+// function to take a comment off the views of the readers of its blog once it is no longer approved
+func (apiCfg *ApiConfig) publishCommentWithdrawn(ctx context.Context, previousStatus string, comment database.Comment) {
+	if previousStatus == CommentStatusApproved && comment.Status != CommentStatusApproved {
+		apiCfg.publishCommentDeleted(ctx, comment)
+	}
+}
+
+// function to push a comment to the readers of its blog
+func (apiCfg *ApiConfig) publishComment(ctx context.Context, eventType string, comment database.Comment, mentions []ResponseMention) {
+	apiCfg.publishBy(ctx, realtime.BlogTopic(comment.BlogID), eventType, comment.UserID, ResponseComment{
+		ID:                  comment.ID,
+		Description:         comment.Description,
+		BlogID:              comment.BlogID,
+		ParentID:            parentCommentID(comment.ParentID),
+		UserID:              comment.UserID,
+		Status:              comment.Status,
+		IsPinned:            comment.IsPinned,
+		RenderedDescription: renderMentions(comment.Description, mentions),
+		Mentions:            mentions,
+		CreatedAt:           comment.CreatedAt,
+		UpdatedAt:           comment.UpdatedAt,
+	})
+}
+
+// function to push the new like count of a blog to its readers
+func (apiCfg *ApiConfig) publishBlogLikes(ctx context.Context, blogID uuid.UUID) {
+	noOfLikes, err := apiCfg.DB.GetNoOfLikes(ctx, blogID)
+	if err != nil {
+		log.Println("Error publishing event: ", err)
+		return
+	}
+	apiCfg.publish(ctx, realtime.BlogTopic(blogID), realtime.EventBlogLikes, BlogLikesEvent{
+		BlogID: blogID,
+		Likes:  noOfLikes,
+	})
+}
+
+// function to push the new like count of a comment to the readers of its blog
+func (apiCfg *ApiConfig) publishCommentLikes(ctx context.Context, comment database.Comment) {
+	noOfLikes, err := apiCfg.DB.GetNoOfCommentLikes(ctx, comment.ID)
+	if err != nil {
+		log.Println("Error publishing event: ", err)
+		return
+	}
+	apiCfg.publish(ctx, realtime.BlogTopic(comment.BlogID), realtime.EventCommentLikes, CommentLikesEvent{
+		CommentID: comment.ID,
+		BlogID:    comment.BlogID,
+		Likes:     noOfLikes,
+	})
+}
+
+// function to create the viewer of an event stream after checking that they can see the blog, blogID is uuid.Nil for streams not following a blog
+func (apiCfg *ApiConfig) newEventViewer(ctx context.Context, user database.User, blogID uuid.UUID) (*eventViewer, error) {
+	viewer := &eventViewer{
+		apiCfg: apiCfg,
+		user:   user,
+		blogID: blogID,
+	}
+	return viewer, viewer.refresh(ctx)
+}
+
+// function to check the access of the viewer to the blog again and reload the users blocked by or blocking them
+func (v *eventViewer) refresh(ctx context.Context) error {
+	if v.blogID != uuid.Nil {
+		blog, err := v.apiCfg.DB.GetBlogById(ctx, v.blogID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return &requestError{http.StatusNotFound, "Blog not found"}
+			}
+			return err
+		}
+		err = v.apiCfg.checkBlogAccess(ctx, v.user, blog)
+		if err != nil {
+			return err
+		}
+	}
+
+	blockedIDs, err := v.apiCfg.DB.GetBlockedEitherWayUserIds(ctx, v.user.ID)
+	if err != nil {
+		return err
+	}
+	v.blockedIDs = make(map[uuid.UUID]bool, len(blockedIDs))
+	for _, blockedID := range blockedIDs {
+		v.blockedIDs[blockedID] = true
+	}
+	v.refreshedAt = time.Now()
+	return nil
+}
+
+// function to check if the event should reach the viewer, events caused by users blocked by or blocking the viewer are dropped
+// an error means the viewer lost access to the blog or could not be checked again and the stream should be closed
+func (v *eventViewer) canReceive(ctx context.Context, event realtime.Event) (bool, error) {
+	if time.Since(v.refreshedAt) >= eventViewerRefreshInterval {
+		err := v.refresh(ctx)
+		if err != nil {
+			return false, err
+		}
+	}
+	if event.ActorID == nil || *event.ActorID == v.user.ID {
+		return true, nil
+	}
+	return !v.blockedIDs[*event.ActorID], nil
+}
+
+// handler function to stream the notifications of the user as server-sent events
+// passing the blogID query param also streams the new comments and like counts of that blog
+func (apiCfg *ApiConfig) HandleEvents(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok || apiCfg.Broker == nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, "Streaming events is not supported")
+		return
+	}
+
+	// collecting the topics to subscribe to
+	topics := []string{realtime.UserTopic(user.ID)}
+	blogID := uuid.Nil
+	if blogIDString := r.URL.Query().Get("blogID"); len(blogIDString) > 0 {
+		var err error
+		blogID, err = uuid.Parse(blogIDString)
+		if err != nil {
+			utility.RespondWithError(w, http.StatusBadRequest, "Invalid blog id")
+			return
+		}
+		topics = append(topics, realtime.BlogTopic(blogID))
+	}
+	viewer, err := apiCfg.newEventViewer(r.Context(), user, blogID)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+
+	subscription := apiCfg.Broker.Subscribe(topics...)
+	defer subscription.Close()
+
+	// opening the stream, the refreshed access token is sent first as the stream has no other response body
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	connected, err := json.Marshal(EmptyResponse{
+		AccessToken: newAccessToken,
+	})
+	if err != nil {
+		return
+	}
+	err = realtime.WriteEvent(w, "connected", connected)
+	if err != nil {
+		return
+	}
+	flusher.Flush()
+
+	// streaming the events until the client disconnects
+	keepAlive := time.NewTicker(eventStreamKeepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-subscription.Events():
+			if !ok {
+				return
+			}
+			var allowed bool
+			allowed, err = viewer.canReceive(r.Context(), event)
+			if err != nil {
+				return
+			}
+			if !allowed {
+				continue
+			}
+			err = realtime.WriteEvent(w, event.Type, event.Data)
+		case <-keepAlive.C:
+			_, err = fmt.Fprint(w, ": keep-alive\n\n")
+		}
+		if err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
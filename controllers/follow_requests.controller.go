@@ -0,0 +1,178 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/harshvardha/blogs/internal/database"
+	"github.com/harshvardha/blogs/notifications"
+	"github.com/harshvardha/blogs/utility"
+)
+
+// states of the follow between the user and another user after following or unfollowing
+const (
+	FollowStatusFollowing        = "following"
+	FollowStatusNotFollowing     = "not_following"
+	FollowStatusRequested        = "requested"
+	FollowStatusRequestCancelled = "request_cancelled"
+)
+
+// function to check if the viewer can see the content of an author
+// the content of private accounts is only visible to their approved followers, the author and the moderators
+func (apiCfg *ApiConfig) canViewAuthorContent(ctx context.Context, viewer database.User, authorID uuid.UUID) (bool, error) {
+	if isModerator(viewer) {
+		return true, nil
+	}
+
+	allowed, err := apiCfg.DB.CanViewAuthorContent(ctx, database.CanViewAuthorContentParams{
+		ViewerID: viewer.ID,
+		AuthorID: authorID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return allowed, nil
+}
+
+// function to build the notification telling the target about a follow request, the target is the entity so that the requests are grouped
+func followRequestNotification(requesterID uuid.UUID, targetID uuid.UUID) notifications.Notification {
+	return notifications.Notification{
+		UserID:     targetID,
+		ActorID:    requesterID,
+		Type:       notifications.TypeFollowRequest,
+		EntityType: notifications.EntityUser,
+		EntityID:   targetID,
+	}
+}
+
+// function to tell the requester that their follow request was approved and take the request off the notifications of the target
+func (apiCfg *ApiConfig) notifyFollowRequestApproved(ctx context.Context, requesterID uuid.UUID, targetID uuid.UUID) {
+	apiCfg.retractNotification(ctx, followRequestNotification(requesterID, targetID))
+	apiCfg.notify(ctx, notifications.Notification{
+		UserID:     requesterID,
+		ActorID:    targetID,
+		Type:       notifications.TypeFollowAccepted,
+		EntityType: notifications.EntityUser,
+		EntityID:   requesterID,
+	})
+}
+
+// function to send a follow request to a private account or cancel the one already sent
+func (apiCfg *ApiConfig) toggleFollowRequest(ctx context.Context, requesterID uuid.UUID, targetID uuid.UUID) (string, error) {
+	requestNotification := followRequestNotification(requesterID, targetID)
+	cancelled, err := apiCfg.DB.DeleteFollowRequest(ctx, database.DeleteFollowRequestParams{
+		RequesterID: requesterID,
+		TargetID:    targetID,
+	})
+	if err != nil {
+		return "", err
+	}
+	if cancelled > 0 {
+		apiCfg.retractNotification(ctx, requestNotification)
+		return FollowStatusRequestCancelled, nil
+	}
+
+	err = apiCfg.DB.CreateFollowRequest(ctx, database.CreateFollowRequestParams{
+		RequesterID: requesterID,
+		TargetID:    targetID,
+	})
+	if err != nil {
+		return "", err
+	}
+	apiCfg.notify(ctx, requestNotification)
+	return FollowStatusRequested, nil
+}
+
+// handler function to get the follow requests waiting for the approval of the user
+func (apiCfg *ApiConfig) HandleGetFollowRequests(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	limit, offset := utility.ParsePagination(r)
+	requests, err := apiCfg.DB.GetIncomingFollowRequests(r.Context(), database.GetIncomingFollowRequestsParams{
+		TargetID: user.ID,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	response := []FollowRequestResponse{}
+	for _, request := range requests {
+		response = append(response, FollowRequestResponse{
+			UserID:      request.ID,
+			Username:    request.Username,
+			DisplayName: request.DisplayName,
+			AvatarURL:   request.AvatarUrl,
+			RequestedAt: request.CreatedAt,
+			AccessToken: newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, response)
+}
+
+// handler function to approve a follow request, the requester becomes a follower of the user
+func (apiCfg *ApiConfig) HandleApproveFollowRequest(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	requesterID, ok := apiCfg.removeFollowRequest(w, r, user)
+	if !ok {
+		return
+	}
+
+	err := apiCfg.DB.FollowUser(r.Context(), database.FollowUserParams{
+		FollowerID:  requesterID,
+		FollowingID: user.ID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	apiCfg.notifyFollowRequestApproved(r.Context(), requesterID, user.ID)
+
+	utility.RespondWithJson(w, http.StatusOK, EmptyResponse{
+		AccessToken: newAccessToken,
+	})
+}
+
+// handler function to deny a follow request, the requester is not told about it
+func (apiCfg *ApiConfig) HandleDenyFollowRequest(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	requesterID, ok := apiCfg.removeFollowRequest(w, r, user)
+	if !ok {
+		return
+	}
+	apiCfg.retractNotification(r.Context(), followRequestNotification(requesterID, user.ID))
+
+	utility.RespondWithJson(w, http.StatusOK, EmptyResponse{
+		AccessToken: newAccessToken,
+	})
+}
+
+// function to remove the follow request of the user in url params, it responds on failure
+func (apiCfg *ApiConfig) removeFollowRequest(w http.ResponseWriter, r *http.Request, user database.User) (uuid.UUID, bool) {
+	// fetching the requester id from url params
+	requesterID, err := uuid.Parse(r.PathValue("userID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid user id")
+		return uuid.Nil, false
+	}
+
+	removed, err := apiCfg.DB.DeleteFollowRequest(r.Context(), database.DeleteFollowRequestParams{
+		RequesterID: requesterID,
+		TargetID:    user.ID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return uuid.Nil, false
+	}
+	if removed == 0 {
+		utility.RespondWithError(w, http.StatusNotFound, "Follow request not found")
+		return uuid.Nil, false
+	}
+
+	return requesterID, true
+}
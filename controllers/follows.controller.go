@@ -0,0 +1,150 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/harshvardha/blogs/internal/database"
+	"github.com/harshvardha/blogs/utility"
+)
+
+// handler function to get the users following a user
+func (apiCfg *ApiConfig) HandleGetFollowers(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	// fetching the user id from url params
+	userID, ok := apiCfg.parseExistingUserID(w, r)
+	if !ok || !apiCfg.canViewFollowLists(w, r, user, userID) {
+		return
+	}
+
+	// fetching the followers
+	limit, offset := utility.ParsePagination(r)
+	followers, err := apiCfg.DB.GetFollowers(r.Context(), database.GetFollowersParams{
+		ViewerID: user.ID,
+		UserID:   userID,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	response := []FollowUserResponse{}
+	for _, follower := range followers {
+		response = append(response, FollowUserResponse{
+			ID:          follower.ID,
+			Username:    follower.Username,
+			DisplayName: follower.DisplayName,
+			AvatarURL:   follower.AvatarUrl,
+			FollowedAt:  follower.FollowedAt,
+			IsFollowing: follower.FollowedByViewer,
+			FollowsYou:  follower.FollowsViewer,
+			Mutual:      follower.FollowedByViewer && follower.FollowsViewer,
+			AccessToken: newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, response)
+}
+
+// handler function to get the users a user is following
+func (apiCfg *ApiConfig) HandleGetFollowing(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	// fetching the user id from url params
+	userID, ok := apiCfg.parseExistingUserID(w, r)
+	if !ok || !apiCfg.canViewFollowLists(w, r, user, userID) {
+		return
+	}
+
+	// fetching the followed users
+	limit, offset := utility.ParsePagination(r)
+	following, err := apiCfg.DB.GetFollowing(r.Context(), database.GetFollowingParams{
+		ViewerID: user.ID,
+		UserID:   userID,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	response := []FollowUserResponse{}
+	for _, followedUser := range following {
+		response = append(response, FollowUserResponse{
+			ID:          followedUser.ID,
+			Username:    followedUser.Username,
+			DisplayName: followedUser.DisplayName,
+			AvatarURL:   followedUser.AvatarUrl,
+			FollowedAt:  followedUser.FollowedAt,
+			IsFollowing: followedUser.FollowedByViewer,
+			FollowsYou:  followedUser.FollowsViewer,
+			Mutual:      followedUser.FollowedByViewer && followedUser.FollowsViewer,
+			AccessToken: newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, response)
+}
+
+// handler function to get how the authenticated user and another user follow each other
+func (apiCfg *ApiConfig) HandleGetRelationship(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	// fetching the user id from url params
+	userID, ok := apiCfg.parseExistingUserID(w, r)
+	if !ok {
+		return
+	}
+
+	relationship, err := apiCfg.DB.GetRelationship(r.Context(), database.GetRelationshipParams{
+		ViewerID: user.ID,
+		UserID:   userID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, RelationshipResponse{
+		UserID:      userID,
+		IsFollowing: relationship.Following,
+		FollowsYou:  relationship.FollowedBy,
+		Mutual:      relationship.Following && relationship.FollowedBy,
+		AccessToken: newAccessToken,
+	})
+}
+
+// function to check that the viewer can see who a user follows and is followed by, it responds on failure
+// the lists of private accounts are only visible to their approved followers
+func (apiCfg *ApiConfig) canViewFollowLists(w http.ResponseWriter, r *http.Request, viewer database.User, userID uuid.UUID) bool {
+	allowed, err := apiCfg.canViewAuthorContent(r.Context(), viewer, userID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return false
+	}
+	if !allowed {
+		utility.RespondWithError(w, http.StatusForbidden, "This account is private")
+		return false
+	}
+	return true
+}
+
+// function to parse the user id from url params and check that the user exist, it responds on failure
+func (apiCfg *ApiConfig) parseExistingUserID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(r.PathValue("userID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid user id")
+		return uuid.Nil, false
+	}
+
+	_, err = apiCfg.DB.GetUserById(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utility.RespondWithError(w, http.StatusNotFound, "User does not exist")
+			return uuid.Nil, false
+		}
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return uuid.Nil, false
+	}
+	return userID, true
+}
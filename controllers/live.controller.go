@@ -0,0 +1,261 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/harshvardha/blogs/internal/database"
+	"github.com/harshvardha/blogs/realtime"
+	"github.com/harshvardha/blogs/utility"
+)
+
+// limits of a live channel connection
+const (
+	liveWriteWait         = 10 * time.Second
+	livePongWait          = 60 * time.Second
+	livePingInterval      = (livePongWait * 9) / 10
+	liveMaxMessageSize    = 8192
+	liveSendBufferSize    = 16
+	liveMessagesPerSecond = 1
+	liveMessageBurst      = 5
+	liveTypingInterval    = 3 * time.Second
+)
+
+// messages a client can send on the live channel
+const (
+	LiveMessageComment = "comment"
+	LiveMessageTyping  = "typing"
+)
+
+// events only sent to the connection they concern
+const (
+	liveEventConnected       = "connected"
+	liveEventPresence        = "presence"
+	liveEventCommentAccepted = "comment.accepted"
+	liveEventError           = "error"
+)
+
+var liveUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// handler function to open a live channel on a blog
+// the channel carries new, edited and deleted comments, like counts, typing indicators and the users present on the blog
+// presence is tracked per server, with the postgres broker the presence list only has the users connected to the same server
+// while joins and leaves are still shared through the broker
+func (apiCfg *ApiConfig) HandleLiveBlog(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	if apiCfg.Broker == nil || apiCfg.Presence == nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, "Live channels are not supported")
+		return
+	}
+
+	// fetching the blog id from url params
+	blogID, err := uuid.Parse(r.PathValue("blogID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid blog id")
+		return
+	}
+	viewer, err := apiCfg.newEventViewer(r.Context(), user, blogID)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+
+	// upgrading the connection, the upgrader responds with the error itself
+	conn, err := liveUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	topic := realtime.BlogTopic(blogID)
+	subscription := apiCfg.Broker.Subscribe(topic)
+	defer subscription.Close()
+
+	// frames only meant for this connection, a client which does not read them fast enough is disconnected
+	send := make(chan []byte, liveSendBufferSize)
+	queue := func(eventType string, data any) {
+		event, err := realtime.NewEvent(topic, eventType, data)
+		if err != nil {
+			log.Println("Error encoding live event: ", err)
+			return
+		}
+		frame, err := json.Marshal(event)
+		if err != nil {
+			log.Println("Error encoding live event: ", err)
+			return
+		}
+		select {
+		case send <- frame:
+		default:
+			conn.Close()
+		}
+	}
+
+	// writing the frames to the client, this is the only goroutine writing to the connection
+	done := make(chan struct{})
+	defer close(done)
+	go writeLiveFrames(r.Context(), viewer, conn, subscription, send, done)
+
+	// announcing the user to the other readers of the blog
+	member := realtime.Member{
+		UserID:   user.ID,
+		Username: user.Username,
+	}
+	presenceEvent := LivePresenceEvent{
+		UserID:   user.ID,
+		Username: user.Username,
+	}
+	queue(liveEventConnected, EmptyResponse{
+		AccessToken: newAccessToken,
+	})
+	if apiCfg.Presence.Join(topic, member) {
+		apiCfg.publishBy(r.Context(), topic, realtime.EventPresenceJoined, user.ID, presenceEvent)
+	}
+	defer func() {
+		if apiCfg.Presence.Leave(topic, user.ID) {
+			apiCfg.publishBy(r.Context(), topic, realtime.EventPresenceLeft, user.ID, presenceEvent)
+		}
+	}()
+	presentUsers := []LivePresenceEvent{}
+	for _, presentMember := range apiCfg.Presence.Members(topic) {
+		presentUsers = append(presentUsers, LivePresenceEvent{
+			UserID:   presentMember.UserID,
+			Username: presentMember.Username,
+		})
+	}
+	queue(liveEventPresence, presentUsers)
+
+	// reading the messages of the client until it disconnects or stops answering pings
+	conn.SetReadLimit(liveMaxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(livePongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(livePongWait))
+	})
+	limiter := realtime.NewLimiter(liveMessagesPerSecond, liveMessageBurst)
+	var lastTyping time.Time
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if !limiter.Allow() {
+			queue(liveEventError, LiveErrorEvent{
+				Error: "Too many messages, slow down",
+			})
+			continue
+		}
+		message := LiveMessage{}
+		err = json.Unmarshal(data, &message)
+		if err != nil {
+			queue(liveEventError, LiveErrorEvent{
+				Error: "Invalid message",
+			})
+			continue
+		}
+
+		switch message.Type {
+		case LiveMessageComment:
+			// the user is read again as they may have been suspended after the connection was opened
+			currentUser, err := apiCfg.DB.GetUserById(r.Context(), user.ID)
+			if err != nil {
+				queue(liveEventError, LiveErrorEvent{
+					Error: "Unable to create comment",
+				})
+				continue
+			}
+			if IsSuspended(currentUser) {
+				queue(liveEventError, LiveErrorEvent{
+					Error: "Your account is suspended until " + currentUser.SuspendedUntil.Time.Format(time.RFC1123),
+				})
+				continue
+			}
+			newComment, err := apiCfg.createComment(r.Context(), currentUser, RequestComment{
+				BlogID:      blogID,
+				ParentID:    message.ParentID,
+				Description: message.Description,
+			})
+			if err != nil {
+				var reqErr *requestError
+				if !errors.As(err, &reqErr) {
+					log.Println("Error creating live comment: ", err)
+					err = errors.New("Unable to create comment")
+				}
+				queue(liveEventError, LiveErrorEvent{
+					Error: err.Error(),
+				})
+				continue
+			}
+			queue(liveEventCommentAccepted, newComment)
+		case LiveMessageTyping:
+			// typing indicators are sent at most once every few seconds
+			if time.Since(lastTyping) < liveTypingInterval {
+				continue
+			}
+			lastTyping = time.Now()
+			apiCfg.publishBy(r.Context(), topic, realtime.EventTyping, user.ID, presenceEvent)
+		default:
+			queue(liveEventError, LiveErrorEvent{
+				Error: "Unknown message type",
+			})
+		}
+	}
+}
+
+// function to write the events of the blog and the frames meant for this connection to the client while pinging it
+// clients which fall behind the events of the blog or lose access to it are disconnected so that they reconnect and catch up
+func writeLiveFrames(ctx context.Context, viewer *eventViewer, conn *websocket.Conn, subscription *realtime.Subscription, send <-chan []byte, done <-chan struct{}) {
+	pingTicker := time.NewTicker(livePingInterval)
+	defer pingTicker.Stop()
+	defer conn.Close()
+
+	for {
+		var frame []byte
+		select {
+		case <-done:
+			conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(liveWriteWait))
+			return
+		case <-subscription.Lagged():
+			conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "Client too slow"), time.Now().Add(liveWriteWait))
+			return
+		case <-pingTicker.C:
+			err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(liveWriteWait))
+			if err != nil {
+				return
+			}
+			continue
+		case frame = <-send:
+		case event, ok := <-subscription.Events():
+			if !ok {
+				return
+			}
+			allowed, err := viewer.canReceive(ctx, event)
+			if err != nil {
+				conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "Unable to check access to the blog"), time.Now().Add(liveWriteWait))
+				return
+			}
+			if !allowed {
+				continue
+			}
+			encodedEvent, err := json.Marshal(event)
+			if err != nil {
+				log.Println("Error encoding live event: ", err)
+				continue
+			}
+			frame = encodedEvent
+		}
+
+		conn.SetWriteDeadline(time.Now().Add(liveWriteWait))
+		err := conn.WriteMessage(websocket.TextMessage, frame)
+		if err != nil {
+			return
+		}
+	}
+}
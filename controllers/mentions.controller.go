@@ -0,0 +1,173 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/harshvardha/blogs/internal/database"
+	"github.com/harshvardha/blogs/notifications"
+	"github.com/harshvardha/blogs/utility"
+)
+
+// sources a user can be mentioned in
+const (
+	MentionSourceBlog    = notifications.EntityBlog
+	MentionSourceComment = notifications.EntityComment
+)
+
+// function to resolve a mentioned username to a single user, usernames are matched case-insensitively
+// mentions of usernames which do not belong to any user are ignored
+func (apiCfg *ApiConfig) resolveMention(ctx context.Context, username string) (uuid.UUID, bool, error) {
+	userID, err := apiCfg.DB.GetUserIdByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, false, nil
+		}
+		return uuid.Nil, false, err
+	}
+
+	return userID, true, nil
+}
+
+// function to bring the stored mentions of a blog or comment in line with its text
+// only the users mentioned for the first time are notified so that edits keeping a mention do not notify again
+func (apiCfg *ApiConfig) syncMentions(ctx context.Context, sourceType string, sourceID uuid.UUID, mentionerID uuid.UUID, text string) ([]ResponseMention, error) {
+	// fetching the users mentioned before this change
+	existingMentions, err := apiCfg.DB.GetMentionsBySource(ctx, database.GetMentionsBySourceParams{
+		SourceType: sourceType,
+		SourceID:   sourceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	alreadyMentioned := make(map[uuid.UUID]bool)
+	for _, mention := range existingMentions {
+		alreadyMentioned[mention.MentionedUserID] = true
+	}
+
+	// saving the new mentions and notifying the mentioned users
+	mentions := []ResponseMention{}
+	stillMentioned := make(map[uuid.UUID]bool)
+	for _, username := range utility.ExtractMentions(text) {
+		mentionedUserID, ok, err := apiCfg.resolveMention(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+		if !ok || mentionedUserID == mentionerID || stillMentioned[mentionedUserID] {
+			continue
+		}
+
+		// users blocked by or blocking the writer cannot be mentioned by them
+		blocked, err := apiCfg.isBlockedEitherWay(ctx, mentionerID, mentionedUserID)
+		if err != nil {
+			return nil, err
+		}
+		if blocked {
+			continue
+		}
+		stillMentioned[mentionedUserID] = true
+		mentions = append(mentions, ResponseMention{
+			UserID:   mentionedUserID,
+			Username: username,
+		})
+		if alreadyMentioned[mentionedUserID] {
+			continue
+		}
+
+		err = apiCfg.DB.CreateMention(ctx, database.CreateMentionParams{
+			SourceType:      sourceType,
+			SourceID:        sourceID,
+			MentionedUserID: mentionedUserID,
+			MentionerID:     mentionerID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		apiCfg.notify(ctx, notifications.Notification{
+			UserID:     mentionedUserID,
+			ActorID:    mentionerID,
+			Type:       notifications.TypeMention,
+			EntityType: sourceType,
+			EntityID:   sourceID,
+		})
+	}
+
+	// removing the mentions which were edited out
+	for _, mention := range existingMentions {
+		if stillMentioned[mention.MentionedUserID] {
+			continue
+		}
+		err = apiCfg.DB.DeleteMention(ctx, database.DeleteMentionParams{
+			SourceType:      sourceType,
+			SourceID:        sourceID,
+			MentionedUserID: mention.MentionedUserID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return mentions, nil
+}
+
+// function to fetch the stored mentions of a blog or comment
+func (apiCfg *ApiConfig) getMentions(ctx context.Context, sourceType string, sourceID uuid.UUID) ([]ResponseMention, error) {
+	storedMentions, err := apiCfg.DB.GetMentionsBySource(ctx, database.GetMentionsBySourceParams{
+		SourceType: sourceType,
+		SourceID:   sourceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mentions := []ResponseMention{}
+	for _, mention := range storedMentions {
+		mentions = append(mentions, ResponseMention{
+			UserID:   mention.MentionedUserID,
+			Username: mention.Username,
+		})
+	}
+	return mentions, nil
+}
+
+// function to render the resolved mentions in the text as links to the profiles of the mentioned users
+func renderMentions(text string, mentions []ResponseMention) string {
+	usernames := make(map[string]bool)
+	for _, mention := range mentions {
+		usernames[strings.ToLower(mention.Username)] = true
+	}
+
+	return utility.RenderMentions(text, usernames)
+}
+
+// handler function to get the blogs and comments the user was mentioned in
+func (apiCfg *ApiConfig) HandleGetMyMentions(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	limit, offset := utility.ParsePagination(r)
+	mentions, err := apiCfg.DB.GetMentionsOfUser(r.Context(), database.GetMentionsOfUserParams{
+		MentionedUserID: user.ID,
+		Limit:           limit,
+		Offset:          offset,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	response := []MentionResponse{}
+	for _, mention := range mentions {
+		response = append(response, MentionResponse{
+			SourceType:    mention.SourceType,
+			SourceID:      mention.SourceID,
+			MentionerID:   mention.MentionerID,
+			MentionerName: mention.MentionerName,
+			CreatedAt:     mention.CreatedAt,
+			AccessToken:   newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, response)
+}
@@ -0,0 +1,212 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/harshvardha/blogs/internal/database"
+	"github.com/harshvardha/blogs/notifications"
+	"github.com/harshvardha/blogs/utility"
+)
+
+// function to emit a notification, a failed notification should not fail the request which caused it
+func (apiCfg *ApiConfig) notify(ctx context.Context, notification notifications.Notification) {
+	if apiCfg.Notifier == nil {
+		return
+	}
+	err := apiCfg.Notifier.Notify(ctx, notification)
+	if err != nil {
+		log.Println("Error creating notification: ", err)
+	}
+}
+
+// function to take back a notification whose cause was undone
+func (apiCfg *ApiConfig) retractNotification(ctx context.Context, notification notifications.Notification) {
+	if apiCfg.Notifier == nil {
+		return
+	}
+	err := apiCfg.Notifier.Retract(ctx, notification)
+	if err != nil {
+		log.Println("Error removing notification: ", err)
+	}
+}
+
+// function to notify the blog author and the writer of the parent comment about a new comment
+func (apiCfg *ApiConfig) notifyNewComment(ctx context.Context, comment database.Comment) {
+	authorID, err := apiCfg.DB.GetBlogAuthorId(ctx, comment.BlogID)
+	if err != nil {
+		log.Println("Error creating notification: ", err)
+		return
+	}
+	apiCfg.notify(ctx, notifications.Notification{
+		UserID:     authorID,
+		ActorID:    comment.UserID,
+		Type:       notifications.TypeBlogComment,
+		EntityType: notifications.EntityBlog,
+		EntityID:   comment.BlogID,
+	})
+
+	if !comment.ParentID.Valid {
+		return
+	}
+	parentComment, err := apiCfg.DB.GetCommentById(ctx, comment.ParentID.UUID)
+	if err != nil {
+		log.Println("Error creating notification: ", err)
+		return
+	}
+	apiCfg.notify(ctx, notifications.Notification{
+		UserID:     parentComment.UserID,
+		ActorID:    comment.UserID,
+		Type:       notifications.TypeCommentReply,
+		EntityType: notifications.EntityComment,
+		EntityID:   parentComment.ID,
+	})
+}
+
+// handler function to get the notifications of the user
+// notifications of the same type about the same entity are grouped together
+func (apiCfg *ApiConfig) HandleGetNotifications(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	// fetching the notification groups
+	limit, offset := utility.ParsePagination(r)
+	groups, err := apiCfg.DB.GetNotificationGroups(r.Context(), database.GetNotificationGroupsParams{
+		UserID: user.ID,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	unreadCount, err := apiCfg.DB.CountUnreadNotificationGroups(r.Context(), user.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	userNotifications := []NotificationResponse{}
+	for _, group := range groups {
+		userNotifications = append(userNotifications, NotificationResponse{
+			Type:            group.Type,
+			EntityType:      group.EntityType,
+			EntityID:        group.EntityID,
+			ActorsCount:     group.ActorsCount,
+			LatestActorName: group.LatestActorName,
+			Message:         notifications.Message(group.Type, group.LatestActorName, group.ActorsCount),
+			Unread:          group.Unread,
+			LatestAt:        group.LatestAt,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, NotificationsResponse{
+		UnreadCount:   unreadCount,
+		Notifications: userNotifications,
+		AccessToken:   newAccessToken,
+	})
+}
+
+// handler function to mark a group of notifications as read
+func (apiCfg *ApiConfig) HandleMarkNotificationRead(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	// decoding the request body
+	decoder := json.NewDecoder(r.Body)
+	params := NotificationReadRequest{}
+	err := decoder.Decode(&params)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid notification")
+		return
+	}
+
+	// marking the notifications as read
+	err = apiCfg.DB.MarkNotificationGroupRead(r.Context(), database.MarkNotificationGroupReadParams{
+		UserID:     user.ID,
+		Type:       params.Type,
+		EntityType: params.EntityType,
+		EntityID:   params.EntityID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, EmptyResponse{
+		AccessToken: newAccessToken,
+	})
+}
+
+// handler function to mark all the notifications of the user as read
+func (apiCfg *ApiConfig) HandleMarkAllNotificationsRead(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	err := apiCfg.DB.MarkAllNotificationsRead(r.Context(), user.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, EmptyResponse{
+		AccessToken: newAccessToken,
+	})
+}
+
+// handler function to get the notification preferences of the user
+func (apiCfg *ApiConfig) HandleGetNotificationPreferences(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	apiCfg.respondWithNotificationPreferences(w, r, user, newAccessToken)
+}
+
+// handler function to turn notification types on or off
+func (apiCfg *ApiConfig) HandleUpdateNotificationPreferences(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	// decoding the request body
+	decoder := json.NewDecoder(r.Body)
+	params := map[string]bool{}
+	err := decoder.Decode(&params)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid notification preferences")
+		return
+	}
+
+	// checking every notification type before saving any of the preferences
+	types := make([]string, 0, len(params))
+	enabled := make([]bool, 0, len(params))
+	for notificationType, isEnabled := range params {
+		if !notifications.IsValidType(notificationType) {
+			utility.RespondWithError(w, http.StatusBadRequest, "Invalid notification type: "+notificationType)
+			return
+		}
+		types = append(types, notificationType)
+		enabled = append(enabled, isEnabled)
+	}
+
+	// saving the preferences
+	err = apiCfg.DB.UpsertNotificationPreferences(r.Context(), database.UpsertNotificationPreferencesParams{
+		UserID:  user.ID,
+		Types:   types,
+		Enabled: enabled,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	apiCfg.respondWithNotificationPreferences(w, r, user, newAccessToken)
+}
+
+// function to respond with the preference of every notification type, types without a saved preference are enabled
+func (apiCfg *ApiConfig) respondWithNotificationPreferences(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	savedPreferences, err := apiCfg.DB.GetNotificationPreferences(r.Context(), user.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	preferences := make(map[string]bool)
+	for _, notificationType := range notifications.Types {
+		preferences[notificationType] = true
+	}
+	for _, preference := range savedPreferences {
+		preferences[preference.Type] = preference.Enabled
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, NotificationPreferencesResponse{
+		Preferences: preferences,
+		AccessToken: newAccessToken,
+	})
+}
@@ -0,0 +1,564 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/harshvardha/blogs/internal/database"
+	"github.com/harshvardha/blogs/notifications"
+	"github.com/harshvardha/blogs/utility"
+)
+
+// roles of the members of a publication
+// the owner manages the publication and its members, editors manage its categories and edit its blogs, writers publish blogs in it
+const (
+	PublicationRoleOwner  = "owner"
+	PublicationRoleEditor = "editor"
+	PublicationRoleWriter = "writer"
+)
+
+// states of a publication membership
+const (
+	PublicationMemberInvited  = "invited"
+	PublicationMemberAccepted = "accepted"
+)
+
+// function to check if the role can be given to a member, every publication has exactly one owner
+func isValidPublicationMemberRole(role string) bool {
+	return role == PublicationRoleEditor || role == PublicationRoleWriter
+}
+
+// function to check if the role allows managing the categories of the publication
+func canManagePublicationCategories(role string) bool {
+	return role == PublicationRoleOwner || role == PublicationRoleEditor
+}
+
+// function to get the id of the publication with the slug, an empty slug means no publication
+func (apiCfg *ApiConfig) resolvePublication(ctx context.Context, slug string) (uuid.NullUUID, error) {
+	if len(slug) == 0 {
+		return uuid.NullUUID{}, nil
+	}
+	publication, err := apiCfg.DB.GetPublicationBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.NullUUID{}, &requestError{http.StatusNotFound, "Publication not found"}
+		}
+		return uuid.NullUUID{}, err
+	}
+	return uuid.NullUUID{UUID: publication.ID, Valid: true}, nil
+}
+
+// function to get the role of the user in a publication, users who are not members or have not accepted their invite have no role
+func (apiCfg *ApiConfig) publicationRole(ctx context.Context, publicationID uuid.UUID, userID uuid.UUID) (string, error) {
+	role, err := apiCfg.DB.GetPublicationRole(ctx, database.GetPublicationRoleParams{
+		PublicationID: publicationID,
+		UserID:        userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", err
+	}
+	return role, nil
+}
+
+// function to convert a nullable publication id to the one used in the responses
+func publicationIDOrNil(publicationID uuid.NullUUID) *uuid.UUID {
+	if !publicationID.Valid {
+		return nil
+	}
+	return &publicationID.UUID
+}
+
+// function to fetch the publication with the slug in the url params, it responds on failure
+func (apiCfg *ApiConfig) parsePublication(w http.ResponseWriter, r *http.Request) (database.Publication, bool) {
+	publication, err := apiCfg.DB.GetPublicationBySlug(r.Context(), r.PathValue("slug"))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utility.RespondWithError(w, http.StatusNotFound, "Publication not found")
+			return database.Publication{}, false
+		}
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return database.Publication{}, false
+	}
+	return publication, true
+}
+
+// handler function to create a new publication, the creator becomes its owner
+func (apiCfg *ApiConfig) HandleCreatePublication(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	// decoding the request body
+	decoder := json.NewDecoder(r.Body)
+	params := PublicationRequest{}
+	err := decoder.Decode(&params)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid publication details")
+		return
+	}
+	params.Slug = strings.ToLower(params.Slug)
+	if err = utility.ValidatePublicationSlug(params.Slug); err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err = utility.ValidatePublicationName(params.Name); err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err = utility.ValidatePublicationDescription(params.Description); err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// creating the publication and making the user its owner
+	// both rows are inserted by one statement so a failure never leaves a publication without its owner
+	publication, err := apiCfg.DB.CreatePublication(r.Context(), database.CreatePublicationParams{
+		Slug:        params.Slug,
+		Name:        params.Name,
+		Description: params.Description,
+		OwnerID:     user.ID,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			utility.RespondWithError(w, http.StatusConflict, "Slug is already taken")
+			return
+		}
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusCreated, PublicationResponse{
+		ID:          publication.ID,
+		Slug:        publication.Slug,
+		Name:        publication.Name,
+		Description: publication.Description,
+		Role:        PublicationRoleOwner,
+		CreatedAt:   publication.CreatedAt,
+		UpdatedAt:   publication.UpdatedAt,
+		AccessToken: newAccessToken,
+	})
+}
+
+// handler function to edit the name and description of a publication, the slug cannot change
+func (apiCfg *ApiConfig) HandleEditPublication(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	publication, ok := apiCfg.parsePublication(w, r)
+	if !ok {
+		return
+	}
+	role, err := apiCfg.publicationRole(r.Context(), publication.ID, user.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if role != PublicationRoleOwner {
+		utility.RespondWithError(w, http.StatusUnauthorized, "Only the owner can edit the publication")
+		return
+	}
+
+	// decoding the request body
+	decoder := json.NewDecoder(r.Body)
+	params := PublicationRequest{}
+	err = decoder.Decode(&params)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid publication details")
+		return
+	}
+
+	// checking which part of the publication to update
+	if len(params.Name) > 0 {
+		if err = utility.ValidatePublicationName(params.Name); err != nil {
+			utility.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		publication.Name = params.Name
+	}
+	if len(params.Description) > 0 {
+		if err = utility.ValidatePublicationDescription(params.Description); err != nil {
+			utility.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		publication.Description = params.Description
+	}
+
+	updatedPublication, err := apiCfg.DB.EditPublication(r.Context(), database.EditPublicationParams{
+		Name:        publication.Name,
+		Description: publication.Description,
+		ID:          publication.ID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, PublicationResponse{
+		ID:          updatedPublication.ID,
+		Slug:        updatedPublication.Slug,
+		Name:        updatedPublication.Name,
+		Description: updatedPublication.Description,
+		Role:        role,
+		CreatedAt:   updatedPublication.CreatedAt,
+		UpdatedAt:   updatedPublication.UpdatedAt,
+		AccessToken: newAccessToken,
+	})
+}
+
+// handler function to get a publication by its slug along with the role of the viewer in it
+func (apiCfg *ApiConfig) HandleGetPublication(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	publication, ok := apiCfg.parsePublication(w, r)
+	if !ok {
+		return
+	}
+	role, err := apiCfg.publicationRole(r.Context(), publication.ID, user.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, PublicationResponse{
+		ID:          publication.ID,
+		Slug:        publication.Slug,
+		Name:        publication.Name,
+		Description: publication.Description,
+		Role:        role,
+		CreatedAt:   publication.CreatedAt,
+		UpdatedAt:   publication.UpdatedAt,
+		AccessToken: newAccessToken,
+	})
+}
+
+// handler function to get the publications the user is a member of
+func (apiCfg *ApiConfig) HandleGetMyPublications(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	memberships, err := apiCfg.DB.GetPublicationsByUserId(r.Context(), user.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	publications := []PublicationResponse{}
+	for _, publication := range memberships {
+		publications = append(publications, PublicationResponse{
+			ID:          publication.ID,
+			Slug:        publication.Slug,
+			Name:        publication.Name,
+			Description: publication.Description,
+			Role:        publication.Role,
+			CreatedAt:   publication.CreatedAt,
+			UpdatedAt:   publication.UpdatedAt,
+			AccessToken: newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, publications)
+}
+
+// handler function to get a page of the blogs of a publication, optionally in one of its categories
+// blogs of users blocked by or blocking the viewer and blogs the viewer cannot see are left out
+func (apiCfg *ApiConfig) HandleGetPublicationBlogs(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	publication, ok := apiCfg.parsePublication(w, r)
+	if !ok {
+		return
+	}
+
+	// checking if the category exist in the publication
+	category := uuid.NullUUID{}
+	if categoryName := r.URL.Query().Get("category"); len(categoryName) > 0 {
+		categoryID, err := apiCfg.DB.GetCategoryIdByName(r.Context(), database.GetCategoryIdByNameParams{
+			CategoryName:  strings.ToUpper(categoryName),
+			PublicationID: uuid.NullUUID{UUID: publication.ID, Valid: true},
+		})
+		if err != nil {
+			utility.RespondWithError(w, http.StatusBadRequest, "Invalid category")
+			return
+		}
+		category = uuid.NullUUID{UUID: categoryID, Valid: true}
+	}
+
+	// fetching the blogs
+	limit, offset := utility.ParsePagination(r)
+	publicationBlogs, err := apiCfg.DB.GetPublicationBlogs(r.Context(), database.GetPublicationBlogsParams{
+		PublicationID: uuid.NullUUID{UUID: publication.ID, Valid: true},
+		Category:      category,
+		ViewerID:      user.ID,
+		Limit:         limit,
+		Offset:        offset,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	blogs := []PublicationBlogResponse{}
+	for _, blog := range publicationBlogs {
+		blogs = append(blogs, PublicationBlogResponse{
+			ID:           blog.ID,
+			Title:        blog.Title,
+			AuthorID:     blog.AuthorID,
+			AuthorName:   blog.AuthorName,
+			ThumbnailURL: blog.ThumbnailUrl,
+			Category:     blog.CategoryName,
+			Likes:        blog.LikesCount,
+			CreatedAt:    blog.CreatedAt,
+			AccessToken:  newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, blogs)
+}
+
+// handler function to get the categories of a publication
+func (apiCfg *ApiConfig) HandleGetPublicationCategories(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	publication, ok := apiCfg.parsePublication(w, r)
+	if !ok {
+		return
+	}
+
+	publicationCategories, err := apiCfg.DB.GetPublicationCategories(r.Context(), uuid.NullUUID{UUID: publication.ID, Valid: true})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	categories := []CategoryResponse{}
+	for _, category := range publicationCategories {
+		categories = append(categories, CategoryResponse{
+			ID:             category.ID,
+			Name:           category.CategoryName,
+			RequiresReview: category.RequiresReview,
+			PublicationID:  publicationIDOrNil(category.PublicationID),
+			AccessToken:    newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, categories)
+}
+
+// handler function to get the members of a publication, pending invites are only shown to the owner
+func (apiCfg *ApiConfig) HandleGetPublicationMembers(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	publication, ok := apiCfg.parsePublication(w, r)
+	if !ok {
+		return
+	}
+	role, err := apiCfg.publicationRole(r.Context(), publication.ID, user.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	publicationMembers, err := apiCfg.DB.GetPublicationMembers(r.Context(), publication.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	members := []PublicationMemberResponse{}
+	for _, member := range publicationMembers {
+		if member.Status != PublicationMemberAccepted && role != PublicationRoleOwner {
+			continue
+		}
+		members = append(members, PublicationMemberResponse{
+			UserID:      member.ID,
+			Username:    member.Username,
+			DisplayName: member.DisplayName,
+			AvatarURL:   member.AvatarUrl,
+			Role:        member.Role,
+			Status:      member.Status,
+			Since:       member.CreatedAt,
+			AccessToken: newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, members)
+}
+
+// handler function to invite a user to join a publication, inviting a member again changes their role
+// the user only becomes a member once they accept the invite
+func (apiCfg *ApiConfig) HandleInvitePublicationMember(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	publication, ok := apiCfg.parsePublication(w, r)
+	if !ok {
+		return
+	}
+	role, err := apiCfg.publicationRole(r.Context(), publication.ID, user.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if role != PublicationRoleOwner {
+		utility.RespondWithError(w, http.StatusUnauthorized, "Only the owner can manage the members of the publication")
+		return
+	}
+
+	// decoding the request body
+	decoder := json.NewDecoder(r.Body)
+	params := PublicationMemberRequest{}
+	err = decoder.Decode(&params)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid member details")
+		return
+	}
+	if !isValidPublicationMemberRole(params.Role) {
+		utility.RespondWithError(w, http.StatusBadRequest, "Role must be editor or writer")
+		return
+	}
+	if params.UserID == user.ID {
+		utility.RespondWithError(w, http.StatusBadRequest, "You already own this publication")
+		return
+	}
+
+	// checking if the invited user exist and can be invited
+	member, err := apiCfg.DB.GetUserById(r.Context(), params.UserID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusNotFound, "User does not exist")
+		return
+	}
+	blocked, err := apiCfg.isBlockedEitherWay(r.Context(), user.ID, member.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if blocked {
+		utility.RespondWithError(w, http.StatusForbidden, "You cannot invite this user")
+		return
+	}
+
+	// inviting the user
+	membership, err := apiCfg.DB.InvitePublicationMember(r.Context(), database.InvitePublicationMemberParams{
+		PublicationID: publication.ID,
+		UserID:        member.ID,
+		Role:          params.Role,
+		InvitedBy:     uuid.NullUUID{UUID: user.ID, Valid: true},
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if membership.Status == PublicationMemberInvited {
+		apiCfg.notify(r.Context(), notifications.Notification{
+			UserID:     member.ID,
+			ActorID:    user.ID,
+			Type:       notifications.TypePublicationInvite,
+			EntityType: notifications.EntityPublication,
+			EntityID:   publication.ID,
+		})
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, PublicationMemberResponse{
+		UserID:      member.ID,
+		Username:    member.Username,
+		DisplayName: member.DisplayName,
+		AvatarURL:   member.AvatarUrl,
+		Role:        membership.Role,
+		Status:      membership.Status,
+		Since:       membership.CreatedAt,
+		AccessToken: newAccessToken,
+	})
+}
+
+// handler function to remove a member from a publication or take back their invite
+// members can also leave on their own or decline their invite, the owner cannot be removed and blogs stay in the publication
+func (apiCfg *ApiConfig) HandleRemovePublicationMember(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	publication, ok := apiCfg.parsePublication(w, r)
+	if !ok {
+		return
+	}
+	memberID, err := uuid.Parse(r.PathValue("userID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid user id")
+		return
+	}
+	if memberID != user.ID {
+		role, err := apiCfg.publicationRole(r.Context(), publication.ID, user.ID)
+		if err != nil {
+			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if role != PublicationRoleOwner {
+			utility.RespondWithError(w, http.StatusUnauthorized, "Only the owner can manage the members of the publication")
+			return
+		}
+	}
+
+	removed, err := apiCfg.DB.RemovePublicationMember(r.Context(), database.RemovePublicationMemberParams{
+		PublicationID: publication.ID,
+		UserID:        memberID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if removed == 0 {
+		utility.RespondWithError(w, http.StatusNotFound, "User is not a removable member of the publication")
+		return
+	}
+	if memberID != user.ID {
+		apiCfg.retractNotification(r.Context(), notifications.Notification{
+			UserID:   memberID,
+			ActorID:  user.ID,
+			Type:     notifications.TypePublicationInvite,
+			EntityID: publication.ID,
+		})
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, EmptyResponse{
+		AccessToken: newAccessToken,
+	})
+}
+
+// handler function to get the publication invites the user has not accepted yet
+func (apiCfg *ApiConfig) HandleGetPublicationInvites(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	pendingInvites, err := apiCfg.DB.GetPublicationInvites(r.Context(), user.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	invites := []PublicationInviteResponse{}
+	for _, invite := range pendingInvites {
+		invites = append(invites, PublicationInviteResponse{
+			PublicationID:   invite.ID,
+			PublicationSlug: invite.Slug,
+			PublicationName: invite.Name,
+			Role:            invite.Role,
+			InvitedBy:       addedBy(invite.InvitedBy),
+			InvitedAt:       invite.CreatedAt,
+			AccessToken:     newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, invites)
+}
+
+// handler function to accept an invite to join a publication
+func (apiCfg *ApiConfig) HandleAcceptPublicationInvite(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	publication, ok := apiCfg.parsePublication(w, r)
+	if !ok {
+		return
+	}
+
+	membership, err := apiCfg.DB.AcceptPublicationInvite(r.Context(), database.AcceptPublicationInviteParams{
+		PublicationID: publication.ID,
+		UserID:        user.ID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utility.RespondWithError(w, http.StatusNotFound, "Invite not found")
+			return
+		}
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, PublicationMemberResponse{
+		UserID:      user.ID,
+		Username:    user.Username,
+		DisplayName: user.DisplayName,
+		AvatarURL:   user.AvatarUrl,
+		Role:        membership.Role,
+		Status:      membership.Status,
+		Since:       membership.CreatedAt,
+		AccessToken: newAccessToken,
+	})
+}
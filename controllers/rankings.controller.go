@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/harshvardha/blogs/internal/database"
+	"github.com/harshvardha/blogs/rankings"
+	"github.com/harshvardha/blogs/utility"
+)
+
+// handler function to get the trending blogs, optionally in a single category
+func (apiCfg *ApiConfig) HandleGetTrendingBlogs(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	apiCfg.respondWithRankedBlogs(w, r, user, newAccessToken, rankings.Trending)
+}
+
+// handler function to get the most popular blogs of the last day, week or month, optionally in a single category
+func (apiCfg *ApiConfig) HandleGetPopularBlogs(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	window := r.URL.Query().Get("window")
+	if len(window) == 0 {
+		window = "week"
+	}
+	if _, ok := rankings.PopularWindows[window]; !ok {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid window, use day, week or month")
+		return
+	}
+
+	apiCfg.respondWithRankedBlogs(w, r, user, newAccessToken, rankings.Popular(window))
+}
+
+// function to respond with a page of the blogs in a ranking which the user can see
+func (apiCfg *ApiConfig) respondWithRankedBlogs(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string, ranking string) {
+	// checking if the category exist or not, categories of a publication are looked up by its slug
+	category := uuid.NullUUID{}
+	if categoryName := r.URL.Query().Get("category"); len(categoryName) > 0 {
+		publicationID, err := apiCfg.resolvePublication(r.Context(), r.URL.Query().Get("publication"))
+		if err != nil {
+			respondWithRequestError(w, err)
+			return
+		}
+		categoryID, err := apiCfg.DB.GetCategoryIdByName(r.Context(), database.GetCategoryIdByNameParams{
+			CategoryName:  categoryName,
+			PublicationID: publicationID,
+		})
+		if err != nil {
+			utility.RespondWithError(w, http.StatusBadRequest, "Invalid category")
+			return
+		}
+		category = uuid.NullUUID{UUID: categoryID, Valid: true}
+	}
+
+	// fetching the ranked blogs
+	limit, offset := utility.ParsePagination(r)
+	rankedBlogs, err := apiCfg.DB.GetRankedBlogs(r.Context(), database.GetRankedBlogsParams{
+		Ranking:  ranking,
+		Category: category,
+		ViewerID: user.ID,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	blogs := []RankedBlogResponse{}
+	for _, blog := range rankedBlogs {
+		blogs = append(blogs, RankedBlogResponse{
+			ID:           blog.ID,
+			Title:        blog.Title,
+			AuthorID:     blog.AuthorID,
+			AuthorName:   blog.AuthorName,
+			ThumbnailURL: blog.ThumbnailUrl,
+			Category:     blog.CategoryName,
+			Score:        blog.Score,
+			AccessToken:  newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, blogs)
+}
@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/harshvardha/blogs/internal/database"
+	"github.com/harshvardha/blogs/utility"
+)
+
+// handler function to get the users recommended for the user to follow
+// recommendations are refreshed periodically, users followed or blocked since the last refresh are left out
+func (apiCfg *ApiConfig) HandleGetUserRecommendations(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	limit, offset := utility.ParsePagination(r)
+	recommendedUsers, err := apiCfg.DB.GetUserRecommendations(r.Context(), database.GetUserRecommendationsParams{
+		UserID: user.ID,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	recommendations := []UserRecommendationResponse{}
+	for _, recommendedUser := range recommendedUsers {
+		recommendations = append(recommendations, UserRecommendationResponse{
+			ID:          recommendedUser.ID,
+			Username:    recommendedUser.Username,
+			DisplayName: recommendedUser.DisplayName,
+			AvatarURL:   recommendedUser.AvatarUrl,
+			Reason:      recommendedUser.Reason,
+			Score:       recommendedUser.Score,
+			AccessToken: newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, recommendations)
+}
+
+// handler function to get the blogs related to a blog
+func (apiCfg *ApiConfig) HandleGetRelatedBlogs(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	// fetching the blog id from url params
+	blogID, err := uuid.Parse(r.PathValue("blogID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid blog id")
+		return
+	}
+
+	// checking if the user can read the blog
+	blog, err := apiCfg.DB.GetBlogById(r.Context(), blogID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utility.RespondWithError(w, http.StatusNotFound, "Blog not found")
+			return
+		}
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	err = apiCfg.checkBlogAccess(r.Context(), user, blog)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+
+	// fetching the related blogs the user can see
+	limit, _ := utility.ParsePagination(r)
+	relatedBlogs, err := apiCfg.DB.GetRelatedBlogs(r.Context(), database.GetRelatedBlogsParams{
+		BlogID:   blogID,
+		ViewerID: user.ID,
+		Limit:    limit,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	related := []RelatedBlogResponse{}
+	for _, relatedBlog := range relatedBlogs {
+		related = append(related, RelatedBlogResponse{
+			ID:           relatedBlog.ID,
+			Title:        relatedBlog.Title,
+			AuthorID:     relatedBlog.AuthorID,
+			AuthorName:   relatedBlog.AuthorName,
+			ThumbnailURL: relatedBlog.ThumbnailUrl,
+			Score:        relatedBlog.Score,
+			AccessToken:  newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, related)
+}
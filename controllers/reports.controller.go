@@ -0,0 +1,524 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/harshvardha/blogs/internal/database"
+	"github.com/harshvardha/blogs/utility"
+)
+
+// entities which can be reported
+const (
+	ReportEntityBlog    = "blog"
+	ReportEntityComment = "comment"
+	ReportEntityUser    = "user"
+)
+
+// states a report moves through
+const (
+	ReportStatusOpen      = "open"
+	ReportStatusAssigned  = "assigned"
+	ReportStatusResolved  = "resolved"
+	ReportStatusDismissed = "dismissed"
+)
+
+// actions a moderator can take while resolving a report
+const (
+	ReportActionNone        = "none"
+	ReportActionHideContent = "hide_content"
+	ReportActionWarn        = "warn"
+	ReportActionSuspend     = "suspend"
+)
+
+// longest suspension a moderator can give, a year keeps the end of the suspension well within the range of time.Duration
+const MaxSuspensionHours = 24 * 365
+
+// how much authority each role has, moderators can only suspend users with less authority than themselves
+var roleRank = map[string]int{
+	RoleUser:      0,
+	RoleModerator: 1,
+	RoleAdmin:     2,
+}
+
+// reasons a reader can choose from while reporting
+var reportReasonCodes = map[string]bool{
+	"spam":           true,
+	"harassment":     true,
+	"hate_speech":    true,
+	"sexual_content": true,
+	"violence":       true,
+	"misinformation": true,
+	"impersonation":  true,
+	"other":          true,
+}
+
+// IsSuspended checks if the user is currently suspended by a moderator
+func IsSuspended(user database.User) bool {
+	return user.SuspendedUntil.Valid && time.Now().UTC().Before(user.SuspendedUntil.Time)
+}
+
+// function to find the user responsible for the reported entity
+func (apiCfg *ApiConfig) getReportedUserID(ctx context.Context, entityType string, entityID uuid.UUID) (uuid.UUID, error) {
+	switch entityType {
+	case ReportEntityBlog:
+		return apiCfg.DB.GetBlogAuthorId(ctx, entityID)
+	case ReportEntityComment:
+		comment, err := apiCfg.DB.GetCommentById(ctx, entityID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return comment.UserID, nil
+	case ReportEntityUser:
+		reportedUser, err := apiCfg.DB.GetUserById(ctx, entityID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return reportedUser.ID, nil
+	}
+
+	return uuid.Nil, sql.ErrNoRows
+}
+
+// function to check if the reporter can see the reported blog or comment, reporting content they cannot see would confirm that it exist
+func (apiCfg *ApiConfig) checkReportAccess(ctx context.Context, reporter database.User, entityType string, entityID uuid.UUID) error {
+	notFound := &requestError{http.StatusNotFound, "Reported " + entityType + " not found"}
+	blogID := entityID
+	switch entityType {
+	case ReportEntityBlog:
+	case ReportEntityComment:
+		comment, err := apiCfg.DB.GetCommentById(ctx, entityID)
+		if err != nil {
+			return err
+		}
+		if comment.Status != CommentStatusApproved {
+			return notFound
+		}
+		blogID = comment.BlogID
+	default:
+		return nil
+	}
+
+	blog, err := apiCfg.DB.GetBlogById(ctx, blogID)
+	if err != nil {
+		return err
+	}
+	err = apiCfg.checkBlogAccess(ctx, reporter, blog)
+	var reqErr *requestError
+	if errors.As(err, &reqErr) {
+		return notFound
+	}
+	return err
+}
+
+// function to convert a report into its response format
+func toReportResponse(report database.Report, newAccessToken string) ReportResponse {
+	response := ReportResponse{
+		ID:             report.ID,
+		ReporterID:     report.ReporterID,
+		EntityType:     report.EntityType,
+		EntityID:       report.EntityID,
+		ReasonCode:     report.ReasonCode,
+		Details:        report.Details,
+		Status:         report.Status,
+		ActionTaken:    report.ActionTaken,
+		ResolutionNote: report.ResolutionNote,
+		CreatedAt:      report.CreatedAt,
+		UpdatedAt:      report.UpdatedAt,
+		AccessToken:    newAccessToken,
+	}
+	if report.AssigneeID.Valid {
+		response.AssigneeID = &report.AssigneeID.UUID
+	}
+	if report.ResolvedAt.Valid {
+		response.ResolvedAt = &report.ResolvedAt.Time
+	}
+
+	return response
+}
+
+// handler function to report a blog, comment or user
+func (apiCfg *ApiConfig) HandleCreateReport(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	// decoding the request body
+	decoder := json.NewDecoder(r.Body)
+	params := ReportRequest{}
+	err := decoder.Decode(&params)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid report")
+		return
+	}
+	if params.EntityType != ReportEntityBlog && params.EntityType != ReportEntityComment && params.EntityType != ReportEntityUser {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid entity type")
+		return
+	}
+	if !reportReasonCodes[params.ReasonCode] {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid reason code")
+		return
+	}
+
+	// checking if the reported entity exist or not
+	reportedUserID, err := apiCfg.getReportedUserID(r.Context(), params.EntityType, params.EntityID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utility.RespondWithError(w, http.StatusNotFound, "Reported "+params.EntityType+" not found")
+			return
+		}
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if reportedUserID == user.ID {
+		utility.RespondWithError(w, http.StatusBadRequest, "You cannot report yourself")
+		return
+	}
+	err = apiCfg.checkReportAccess(r.Context(), user, params.EntityType, params.EntityID)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+
+	// creating the report
+	report, err := apiCfg.DB.CreateReport(r.Context(), database.CreateReportParams{
+		ReporterID: user.ID,
+		EntityType: params.EntityType,
+		EntityID:   params.EntityID,
+		ReasonCode: params.ReasonCode,
+		Details:    params.Details,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusCreated, toReportResponse(report, newAccessToken))
+}
+
+// handler function to list reports for moderators
+// reports can be filtered by status or by the reports assigned to the moderator with ?assigned=me
+func (apiCfg *ApiConfig) HandleGetReports(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	if !isModerator(user) {
+		utility.RespondWithError(w, http.StatusUnauthorized, "You are not authorized to view reports")
+		return
+	}
+
+	// fetching the reports
+	limit, offset := utility.ParsePagination(r)
+	var reports []database.Report
+	var err error
+	if r.URL.Query().Get("assigned") == "me" {
+		reports, err = apiCfg.DB.GetReportsByAssignee(r.Context(), database.GetReportsByAssigneeParams{
+			AssigneeID: uuid.NullUUID{
+				UUID:  user.ID,
+				Valid: true,
+			},
+			Limit:  limit,
+			Offset: offset,
+		})
+	} else {
+		status := r.URL.Query().Get("status")
+		if len(status) == 0 {
+			status = ReportStatusOpen
+		}
+		reports, err = apiCfg.DB.GetReportsByStatus(r.Context(), database.GetReportsByStatusParams{
+			Status: status,
+			Limit:  limit,
+			Offset: offset,
+		})
+	}
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	response := []ReportResponse{}
+	for _, report := range reports {
+		response = append(response, toReportResponse(report, newAccessToken))
+	}
+	utility.RespondWithJson(w, http.StatusOK, response)
+}
+
+// handler function to get a single report
+func (apiCfg *ApiConfig) HandleGetReportById(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	if !isModerator(user) {
+		utility.RespondWithError(w, http.StatusUnauthorized, "You are not authorized to view reports")
+		return
+	}
+
+	// fetching the report id from url params
+	reportID, err := uuid.Parse(r.PathValue("reportID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid report id")
+		return
+	}
+
+	report, err := apiCfg.DB.GetReportById(r.Context(), reportID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusNotFound, "Report not found")
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, toReportResponse(report, newAccessToken))
+}
+
+// handler function to assign a report to a moderator, the report is assigned to the requesting moderator if no assignee is given
+func (apiCfg *ApiConfig) HandleAssignReport(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	if !isModerator(user) {
+		utility.RespondWithError(w, http.StatusUnauthorized, "You are not authorized to assign reports")
+		return
+	}
+
+	// fetching the report id from url params
+	reportID, err := uuid.Parse(r.PathValue("reportID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid report id")
+		return
+	}
+
+	// decoding the request body
+	decoder := json.NewDecoder(r.Body)
+	params := ReportAssignRequest{}
+	err = decoder.Decode(&params)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid assignee")
+		return
+	}
+	assigneeID := user.ID
+	if params.AssigneeID != uuid.Nil && params.AssigneeID != user.ID {
+		assignee, err := apiCfg.DB.GetUserById(r.Context(), params.AssigneeID)
+		if err != nil || !isModerator(assignee) {
+			utility.RespondWithError(w, http.StatusBadRequest, "Reports can only be assigned to moderators")
+			return
+		}
+		assigneeID = assignee.ID
+	}
+
+	// assigning the report
+	report, err := apiCfg.DB.AssignReport(r.Context(), database.AssignReportParams{
+		AssigneeID: uuid.NullUUID{
+			UUID:  assigneeID,
+			Valid: true,
+		},
+		ID: reportID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utility.RespondWithError(w, http.StatusNotFound, "Report not found or already closed")
+			return
+		}
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, toReportResponse(report, newAccessToken))
+}
+
+// handler function to resolve a report by taking an action against the reported content or its owner
+func (apiCfg *ApiConfig) HandleResolveReport(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	if !isModerator(user) {
+		utility.RespondWithError(w, http.StatusUnauthorized, "You are not authorized to resolve reports")
+		return
+	}
+
+	// fetching the report id from url params
+	reportID, err := uuid.Parse(r.PathValue("reportID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid report id")
+		return
+	}
+
+	// decoding the request body
+	decoder := json.NewDecoder(r.Body)
+	params := ReportResolveRequest{}
+	err = decoder.Decode(&params)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid resolution")
+		return
+	}
+	if len(params.Action) == 0 {
+		params.Action = ReportActionNone
+	}
+
+	// checking if the report exist and is still open
+	report, err := apiCfg.DB.GetReportById(r.Context(), reportID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusNotFound, "Report not found")
+		return
+	}
+	if report.Status == ReportStatusResolved || report.Status == ReportStatusDismissed {
+		utility.RespondWithError(w, http.StatusBadRequest, "Report is already closed")
+		return
+	}
+	reportedUserID, err := apiCfg.getReportedUserID(r.Context(), report.EntityType, report.EntityID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusNotFound, "Reported "+report.EntityType+" no longer exist")
+		return
+	}
+
+	// taking the action
+	moderatorID := uuid.NullUUID{
+		UUID:  user.ID,
+		Valid: true,
+	}
+	switch params.Action {
+	case ReportActionNone:
+	case ReportActionHideContent:
+		switch report.EntityType {
+		case ReportEntityBlog:
+			err = apiCfg.DB.SetBlogModerationStatus(r.Context(), database.SetBlogModerationStatusParams{
+				ModerationStatus: BlogModerationHidden,
+				ID:               report.EntityID,
+			})
+		case ReportEntityComment:
+			var reportedComment, hiddenComment database.Comment
+			reportedComment, err = apiCfg.DB.GetCommentById(r.Context(), report.EntityID)
+			if err != nil {
+				break
+			}
+			hiddenComment, err = apiCfg.DB.UpdateCommentStatus(r.Context(), database.UpdateCommentStatusParams{
+				Status:      CommentStatusHidden,
+				ModeratedBy: moderatorID,
+				ID:          report.EntityID,
+			})
+			if err == nil {
+				apiCfg.publishCommentWithdrawn(r.Context(), reportedComment.Status, hiddenComment)
+			}
+		default:
+			utility.RespondWithError(w, http.StatusBadRequest, "Only blogs and comments can be hidden")
+			return
+		}
+	case ReportActionWarn:
+		if len(params.Message) == 0 {
+			utility.RespondWithError(w, http.StatusBadRequest, "Warning message is required")
+			return
+		}
+		_, err = apiCfg.DB.CreateUserWarning(r.Context(), database.CreateUserWarningParams{
+			UserID:      reportedUserID,
+			ModeratorID: moderatorID,
+			ReportID: uuid.NullUUID{
+				UUID:  report.ID,
+				Valid: true,
+			},
+			Message: params.Message,
+		})
+	case ReportActionSuspend:
+		if params.DurationHours <= 0 {
+			utility.RespondWithError(w, http.StatusBadRequest, "Suspension duration is required")
+			return
+		}
+		if params.DurationHours > MaxSuspensionHours {
+			utility.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Suspension can be at most %d hours", MaxSuspensionHours))
+			return
+		}
+		var reportedUser database.User
+		reportedUser, err = apiCfg.DB.GetUserById(r.Context(), reportedUserID)
+		if err != nil {
+			break
+		}
+		if roleRank[reportedUser.Role] >= roleRank[user.Role] {
+			utility.RespondWithError(w, http.StatusForbidden, "You cannot suspend this user")
+			return
+		}
+		err = apiCfg.DB.SuspendUser(r.Context(), database.SuspendUserParams{
+			SuspendedUntil: sql.NullTime{
+				Time:  time.Now().UTC().Add(time.Duration(params.DurationHours) * time.Hour),
+				Valid: true,
+			},
+			ID: reportedUserID,
+		})
+	default:
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid action")
+		return
+	}
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// closing the report
+	report, err = apiCfg.DB.CloseReport(r.Context(), database.CloseReportParams{
+		Status:         ReportStatusResolved,
+		ActionTaken:    params.Action,
+		ResolutionNote: params.Note,
+		ResolvedBy:     moderatorID,
+		ID:             reportID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, toReportResponse(report, newAccessToken))
+}
+
+// handler function to dismiss a report without taking any action
+func (apiCfg *ApiConfig) HandleDismissReport(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	if !isModerator(user) {
+		utility.RespondWithError(w, http.StatusUnauthorized, "You are not authorized to dismiss reports")
+		return
+	}
+
+	// fetching the report id from url params
+	reportID, err := uuid.Parse(r.PathValue("reportID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid report id")
+		return
+	}
+
+	// decoding the request body
+	decoder := json.NewDecoder(r.Body)
+	params := ReportResolveRequest{}
+	err = decoder.Decode(&params)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid dismissal")
+		return
+	}
+
+	// closing the report
+	report, err := apiCfg.DB.CloseReport(r.Context(), database.CloseReportParams{
+		Status:         ReportStatusDismissed,
+		ActionTaken:    ReportActionNone,
+		ResolutionNote: params.Note,
+		ResolvedBy: uuid.NullUUID{
+			UUID:  user.ID,
+			Valid: true,
+		},
+		ID: reportID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utility.RespondWithError(w, http.StatusNotFound, "Report not found or already closed")
+			return
+		}
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, toReportResponse(report, newAccessToken))
+}
+
+// handler function to get the warnings a user received from the moderators
+func (apiCfg *ApiConfig) HandleGetUserWarnings(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	warnings, err := apiCfg.DB.GetUserWarnings(r.Context(), user.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	response := []UserWarningResponse{}
+	for _, warning := range warnings {
+		response = append(response, UserWarningResponse{
+			ID:          warning.ID,
+			Message:     warning.Message,
+			CreatedAt:   warning.CreatedAt,
+			AccessToken: newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, response)
+}
@@ -0,0 +1,670 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/harshvardha/blogs/internal/database"
+	"github.com/harshvardha/blogs/notifications"
+	"github.com/harshvardha/blogs/utility"
+)
+
+// states of the editorial review of a blog
+// blogs in categories which require review stay unpublished until a reviewer approves them
+const (
+	ReviewStatusNone             = "none"
+	ReviewStatusPending          = "pending"
+	ReviewStatusChangesRequested = "changes_requested"
+	ReviewStatusApproved         = "approved"
+)
+
+// decisions a reviewer can make on a blog
+const (
+	ReviewDecisionApproved         = "approved"
+	ReviewDecisionChangesRequested = "changes_requested"
+)
+
+// function to check if the role allows submitting, assigning reviewers to and publishing a blog, only the authors can
+func canManageBlogReview(role string) bool {
+	return role == BlogRoleOwner || role == BlogRoleCoAuthor
+}
+
+// function to check if the category of a blog requires an approved review before the blog is published
+func (apiCfg *ApiConfig) categoryRequiresReview(ctx context.Context, categoryID uuid.UUID) (bool, error) {
+	category, err := apiCfg.DB.GetCategoryById(ctx, categoryID)
+	if err != nil {
+		return false, err
+	}
+	return category.RequiresReview, nil
+}
+
+// function to check if moving a blog into a category takes it back off the readers until its review is approved
+// unpublished blogs are already held back by the publish handler so only published blogs without an approval are affected
+func needsReviewAfterCategoryChange(isPublished bool, reviewStatus string, categoryRequiresReview bool) bool {
+	return categoryRequiresReview && isPublished && reviewStatus != ReviewStatusApproved
+}
+
+// function to take a published blog back off the readers and send it for review, the earlier decisions no longer count
+func (apiCfg *ApiConfig) holdBlogForReview(ctx context.Context, blogID uuid.UUID, actorID uuid.UUID) (database.Blog, error) {
+	blog, err := apiCfg.DB.UnpublishBlogForReview(ctx, blogID)
+	if err != nil {
+		return database.Blog{}, err
+	}
+	err = apiCfg.DB.ClearReviewDecisions(ctx, blogID)
+	if err != nil {
+		return database.Blog{}, err
+	}
+	return blog, apiCfg.notifyReviewers(ctx, blogID, actorID)
+}
+
+// function to fetch the blog from url params along with the role of the user on it, moderators can take part in every review
+// it responds on failure and when the user has nothing to do with the blog
+func (apiCfg *ApiConfig) parseReviewedBlog(w http.ResponseWriter, r *http.Request, user database.User) (database.GetBlogByIdRow, string, bool) {
+	blogID, err := uuid.Parse(r.PathValue("blogID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid blog id")
+		return database.GetBlogByIdRow{}, "", false
+	}
+	blog, err := apiCfg.DB.GetBlogById(r.Context(), blogID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utility.RespondWithError(w, http.StatusNotFound, "Blog not found")
+			return database.GetBlogByIdRow{}, "", false
+		}
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return database.GetBlogByIdRow{}, "", false
+	}
+	role, err := apiCfg.blogRole(r.Context(), blogID, user.ID)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return database.GetBlogByIdRow{}, "", false
+	}
+	if len(role) == 0 && !isModerator(user) {
+		utility.RespondWithError(w, http.StatusNotFound, "Blog not found")
+		return database.GetBlogByIdRow{}, "", false
+	}
+	return blog, role, true
+}
+
+// function to respond with the review state of a blog and its reviewers
+func (apiCfg *ApiConfig) respondWithBlogReview(w http.ResponseWriter, r *http.Request, blogID uuid.UUID, newAccessToken string) {
+	blog, err := apiCfg.DB.GetBlogById(r.Context(), blogID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	requiresReview, err := apiCfg.categoryRequiresReview(r.Context(), blog.Category)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	blogReviewers, err := apiCfg.DB.GetBlogReviewers(r.Context(), blogID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	reviewers := []BlogReviewerResponse{}
+	for _, reviewer := range blogReviewers {
+		response := BlogReviewerResponse{
+			UserID:      reviewer.ID,
+			Username:    reviewer.Username,
+			DisplayName: reviewer.DisplayName,
+			AvatarURL:   reviewer.AvatarUrl,
+			Decision:    reviewer.Decision.String,
+			AssignedAt:  reviewer.CreatedAt,
+		}
+		if reviewer.DecidedAt.Valid {
+			response.DecidedAt = &reviewer.DecidedAt.Time
+		}
+		reviewers = append(reviewers, response)
+	}
+	utility.RespondWithJson(w, http.StatusOK, BlogReviewResponse{
+		BlogID:         blog.ID,
+		ReviewStatus:   blog.ReviewStatus,
+		Published:      blog.IsPublished,
+		RequiresReview: requiresReview,
+		Reviewers:      reviewers,
+		AccessToken:    newAccessToken,
+	})
+}
+
+// function to tell the reviewers of a blog that it is waiting for their review
+func (apiCfg *ApiConfig) notifyReviewers(ctx context.Context, blogID uuid.UUID, actorID uuid.UUID) error {
+	reviewers, err := apiCfg.DB.GetBlogReviewers(ctx, blogID)
+	if err != nil {
+		return err
+	}
+	for _, reviewer := range reviewers {
+		apiCfg.notify(ctx, notifications.Notification{
+			UserID:     reviewer.ID,
+			ActorID:    actorID,
+			Type:       notifications.TypeReviewRequest,
+			EntityType: notifications.EntityBlog,
+			EntityID:   blogID,
+		})
+	}
+	return nil
+}
+
+// handler function to make a category require an approved review before its blogs are published
+// only moderators can, or the owner and editors for the categories of a publication
+func (apiCfg *ApiConfig) HandleSetCategoryReview(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	categoryID, err := uuid.Parse(r.PathValue("categoryID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid category id")
+		return
+	}
+
+	// categories of a publication are configured by its owner and editors, the rest by moderators
+	category, err := apiCfg.DB.GetCategoryById(r.Context(), categoryID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utility.RespondWithError(w, http.StatusNotFound, "Category not found")
+			return
+		}
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if category.PublicationID.Valid {
+		err = apiCfg.checkPublicationCategoryAccess(r.Context(), user, category.PublicationID)
+		if err != nil {
+			respondWithRequestError(w, err)
+			return
+		}
+	} else if !isModerator(user) {
+		utility.RespondWithError(w, http.StatusUnauthorized, "You are not authorized to configure categories")
+		return
+	}
+
+	// decoding the request body
+	decoder := json.NewDecoder(r.Body)
+	params := CategoryReviewRequest{}
+	err = decoder.Decode(&params)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid category review setting")
+		return
+	}
+
+	requiredReview := category.RequiresReview
+	category, err = apiCfg.DB.SetCategoryRequiresReview(r.Context(), database.SetCategoryRequiresReviewParams{
+		RequiresReview: params.RequiresReview,
+		ID:             categoryID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utility.RespondWithError(w, http.StatusNotFound, "Category not found")
+			return
+		}
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// turning the review on holds back the published blogs of the category without an approval, like moving them into it would
+	if category.RequiresReview && !requiredReview {
+		publishedBlogs, err := apiCfg.DB.GetPublishedCategoryBlogs(r.Context(), categoryID)
+		if err != nil {
+			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		for _, blog := range publishedBlogs {
+			if !needsReviewAfterCategoryChange(blog.IsPublished, blog.ReviewStatus, category.RequiresReview) {
+				continue
+			}
+			_, err = apiCfg.holdBlogForReview(r.Context(), blog.ID, user.ID)
+			if err != nil {
+				utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+		}
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, CategoryResponse{
+		ID:             category.ID,
+		Name:           category.CategoryName,
+		RequiresReview: category.RequiresReview,
+		PublicationID:  publicationIDOrNil(category.PublicationID),
+		AccessToken:    newAccessToken,
+	})
+}
+
+// handler function to submit an unpublished blog for review, submitting again clears the earlier decisions
+func (apiCfg *ApiConfig) HandleSubmitBlogForReview(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	blog, role, ok := apiCfg.parseReviewedBlog(w, r, user)
+	if !ok {
+		return
+	}
+	if !canManageBlogReview(role) {
+		utility.RespondWithError(w, http.StatusUnauthorized, "Only the authors can submit this blog for review")
+		return
+	}
+	if blog.IsPublished {
+		utility.RespondWithError(w, http.StatusBadRequest, "Blog is already published")
+		return
+	}
+
+	// submitting the blog
+	err := apiCfg.DB.ClearReviewDecisions(r.Context(), blog.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	_, err = apiCfg.DB.SubmitBlogForReview(r.Context(), blog.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	err = apiCfg.notifyReviewers(r.Context(), blog.ID, user.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	apiCfg.respondWithBlogReview(w, r, blog.ID, newAccessToken)
+}
+
+// handler function to get the review state of a blog and its reviewers
+func (apiCfg *ApiConfig) HandleGetBlogReview(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	blog, _, ok := apiCfg.parseReviewedBlog(w, r, user)
+	if !ok {
+		return
+	}
+	apiCfg.respondWithBlogReview(w, r, blog.ID, newAccessToken)
+}
+
+// handler function to assign a reviewer to a blog, the authors of the blog cannot review it themselves
+func (apiCfg *ApiConfig) HandleAssignBlogReviewer(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	blog, role, ok := apiCfg.parseReviewedBlog(w, r, user)
+	if !ok {
+		return
+	}
+	if !canManageBlogReview(role) && !isModerator(user) {
+		utility.RespondWithError(w, http.StatusUnauthorized, "You are not authorized to assign reviewers to this blog")
+		return
+	}
+
+	// decoding the request body
+	decoder := json.NewDecoder(r.Body)
+	params := BlogReviewerRequest{}
+	err := decoder.Decode(&params)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid reviewer")
+		return
+	}
+
+	// checking if the reviewer exist and can review the blog
+	reviewer, err := apiCfg.DB.GetUserById(r.Context(), params.UserID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusNotFound, "User does not exist")
+		return
+	}
+	reviewerRole, err := apiCfg.blogRole(r.Context(), blog.ID, reviewer.ID)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+	if canManageBlogReview(reviewerRole) {
+		utility.RespondWithError(w, http.StatusBadRequest, "The authors of a blog cannot review it")
+		return
+	}
+	blocked, err := apiCfg.isBlockedEitherWay(r.Context(), blog.AuthorID, reviewer.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if blocked {
+		utility.RespondWithError(w, http.StatusForbidden, "This user cannot review this blog")
+		return
+	}
+
+	// assigning the reviewer
+	_, err = apiCfg.DB.AssignBlogReviewer(r.Context(), database.AssignBlogReviewerParams{
+		BlogID:     blog.ID,
+		ReviewerID: reviewer.ID,
+		AssignedBy: uuid.NullUUID{UUID: user.ID, Valid: true},
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if blog.ReviewStatus == ReviewStatusPending {
+		apiCfg.notify(r.Context(), notifications.Notification{
+			UserID:     reviewer.ID,
+			ActorID:    user.ID,
+			Type:       notifications.TypeReviewRequest,
+			EntityType: notifications.EntityBlog,
+			EntityID:   blog.ID,
+		})
+	}
+
+	apiCfg.respondWithBlogReview(w, r, blog.ID, newAccessToken)
+}
+
+// handler function to remove a reviewer from a blog, the review state is worked out again from the remaining decisions
+func (apiCfg *ApiConfig) HandleUnassignBlogReviewer(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	blog, role, ok := apiCfg.parseReviewedBlog(w, r, user)
+	if !ok {
+		return
+	}
+	if !canManageBlogReview(role) && !isModerator(user) {
+		utility.RespondWithError(w, http.StatusUnauthorized, "You are not authorized to remove reviewers from this blog")
+		return
+	}
+	reviewerID, err := uuid.Parse(r.PathValue("userID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid user id")
+		return
+	}
+
+	removed, err := apiCfg.DB.UnassignBlogReviewer(r.Context(), database.UnassignBlogReviewerParams{
+		BlogID:     blog.ID,
+		ReviewerID: reviewerID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if removed == 0 {
+		utility.RespondWithError(w, http.StatusNotFound, "User is not a reviewer of the blog")
+		return
+	}
+	apiCfg.retractNotification(r.Context(), notifications.Notification{
+		UserID:   reviewerID,
+		ActorID:  user.ID,
+		Type:     notifications.TypeReviewRequest,
+		EntityID: blog.ID,
+	})
+	if blog.ReviewStatus != ReviewStatusNone && !blog.IsPublished {
+		_, err = apiCfg.DB.UpdateBlogReviewStatus(r.Context(), blog.ID)
+		if err != nil {
+			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	apiCfg.respondWithBlogReview(w, r, blog.ID, newAccessToken)
+}
+
+// handler function for a reviewer to approve a blog or request changes to it
+// a blog is approved once a reviewer approves it and no reviewer is waiting for changes
+func (apiCfg *ApiConfig) HandleDecideBlogReview(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	blog, _, ok := apiCfg.parseReviewedBlog(w, r, user)
+	if !ok {
+		return
+	}
+	if blog.IsPublished || blog.ReviewStatus == ReviewStatusNone {
+		utility.RespondWithError(w, http.StatusBadRequest, "Blog is not waiting for review")
+		return
+	}
+
+	// decoding the request body
+	decoder := json.NewDecoder(r.Body)
+	params := ReviewDecisionRequest{}
+	err := decoder.Decode(&params)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid decision")
+		return
+	}
+	if params.Decision != ReviewDecisionApproved && params.Decision != ReviewDecisionChangesRequested {
+		utility.RespondWithError(w, http.StatusBadRequest, "Decision must be approved or changes_requested")
+		return
+	}
+
+	// saving the decision, only the assigned reviewers can decide
+	_, err = apiCfg.DB.DecideBlogReview(r.Context(), database.DecideBlogReviewParams{
+		Decision:   sql.NullString{String: params.Decision, Valid: true},
+		BlogID:     blog.ID,
+		ReviewerID: user.ID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utility.RespondWithError(w, http.StatusUnauthorized, "You are not a reviewer of this blog")
+			return
+		}
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	_, err = apiCfg.DB.UpdateBlogReviewStatus(r.Context(), blog.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	apiCfg.notify(r.Context(), notifications.Notification{
+		UserID:     blog.AuthorID,
+		ActorID:    user.ID,
+		Type:       notifications.TypeReviewDecision,
+		EntityType: notifications.EntityBlog,
+		EntityID:   blog.ID,
+	})
+
+	apiCfg.respondWithBlogReview(w, r, blog.ID, newAccessToken)
+}
+
+// handler function to get the blogs waiting for the review of the user
+func (apiCfg *ApiConfig) HandleGetReviewQueue(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	limit, offset := utility.ParsePagination(r)
+	queue, err := apiCfg.DB.GetReviewQueue(r.Context(), database.GetReviewQueueParams{
+		ReviewerID: user.ID,
+		Limit:      limit,
+		Offset:     offset,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	blogs := []ReviewQueueResponse{}
+	for _, blog := range queue {
+		blogs = append(blogs, ReviewQueueResponse{
+			BlogID:       blog.ID,
+			Title:        blog.Title,
+			AuthorID:     blog.AuthorID,
+			AuthorName:   blog.AuthorName,
+			ReviewStatus: blog.ReviewStatus,
+			Decision:     blog.Decision.String,
+			UpdatedAt:    blog.UpdatedAt,
+			AccessToken:  newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, blogs)
+}
+
+// handler function to publish a blog, blogs in categories which require review can only be published once approved
+func (apiCfg *ApiConfig) HandlePublishBlog(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	blog, role, ok := apiCfg.parseReviewedBlog(w, r, user)
+	if !ok {
+		return
+	}
+	if !canManageBlogReview(role) {
+		utility.RespondWithError(w, http.StatusUnauthorized, "Only the authors can publish this blog")
+		return
+	}
+	if blog.IsPublished {
+		utility.RespondWithError(w, http.StatusBadRequest, "Blog is already published")
+		return
+	}
+	requiresReview, err := apiCfg.categoryRequiresReview(r.Context(), blog.Category)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if requiresReview && blog.ReviewStatus != ReviewStatusApproved {
+		utility.RespondWithError(w, http.StatusConflict, "Blogs in this category can only be published after their review is approved")
+		return
+	}
+
+	// publishing the blog, the users mentioned in it are notified now that they can read it
+	_, err = apiCfg.DB.PublishBlog(r.Context(), blog.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if blog.ModerationStatus != BlogModerationShadowHidden && blog.ModerationStatus != BlogModerationHidden {
+		_, err = apiCfg.syncMentions(r.Context(), MentionSourceBlog, blog.ID, blog.AuthorID, blog.Content)
+		if err != nil {
+			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	apiCfg.respondWithBlogReview(w, r, blog.ID, newAccessToken)
+}
+
+// handler function to leave a review comment on a range of the content of a blog
+// the range is given in characters and the quoted text is kept so the comment can be placed again after the blog is edited
+func (apiCfg *ApiConfig) HandleCreateReviewComment(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	blog, _, ok := apiCfg.parseReviewedBlog(w, r, user)
+	if !ok {
+		return
+	}
+
+	// decoding the request body
+	decoder := json.NewDecoder(r.Body)
+	params := ReviewCommentRequest{}
+	err := decoder.Decode(&params)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid review comment")
+		return
+	}
+	params.Body = strings.TrimSpace(params.Body)
+	err = utility.ValidateReviewComment(params.Body)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if params.Start < 0 || params.End < params.Start || params.End > utf8.RuneCountInString(blog.Content) {
+		utility.RespondWithError(w, http.StatusBadRequest, "Comment range is outside the content of the blog")
+		return
+	}
+
+	// saving the comment
+	content := []rune(blog.Content)
+	comment, err := apiCfg.DB.CreateReviewComment(r.Context(), database.CreateReviewCommentParams{
+		BlogID:      blog.ID,
+		UserID:      user.ID,
+		Body:        params.Body,
+		AnchorStart: int32(params.Start),
+		AnchorEnd:   int32(params.End),
+		QuotedText:  string(content[params.Start:params.End]),
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusCreated, reviewCommentResponse(comment, user.Username, newAccessToken))
+}
+
+// handler function to get the review comments of a blog in the order they appear in the content
+func (apiCfg *ApiConfig) HandleGetReviewComments(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	blog, _, ok := apiCfg.parseReviewedBlog(w, r, user)
+	if !ok {
+		return
+	}
+
+	reviewComments, err := apiCfg.DB.GetReviewComments(r.Context(), blog.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	comments := []ReviewCommentResponse{}
+	for _, comment := range reviewComments {
+		comments = append(comments, ReviewCommentResponse{
+			ID:          comment.ID,
+			BlogID:      comment.BlogID,
+			UserID:      comment.UserID,
+			Username:    comment.Username,
+			Body:        comment.Body,
+			Start:       comment.AnchorStart,
+			End:         comment.AnchorEnd,
+			QuotedText:  comment.QuotedText,
+			Resolved:    comment.Resolved,
+			CreatedAt:   comment.CreatedAt,
+			UpdatedAt:   comment.UpdatedAt,
+			AccessToken: newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, comments)
+}
+
+// handler function to mark a review comment as resolved or open it again
+func (apiCfg *ApiConfig) HandleResolveReviewComment(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	commentID, err := uuid.Parse(r.PathValue("commentID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid comment id")
+		return
+	}
+	comment, err := apiCfg.DB.GetReviewCommentById(r.Context(), commentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utility.RespondWithError(w, http.StatusNotFound, "Review comment not found")
+			return
+		}
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// checking if the user takes part in the review of the blog
+	role, err := apiCfg.blogRole(r.Context(), comment.BlogID, user.ID)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+	if len(role) == 0 && !isModerator(user) {
+		utility.RespondWithError(w, http.StatusNotFound, "Review comment not found")
+		return
+	}
+
+	// decoding the request body
+	decoder := json.NewDecoder(r.Body)
+	params := ReviewCommentResolveRequest{}
+	err = decoder.Decode(&params)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid information")
+		return
+	}
+
+	comment, err = apiCfg.DB.SetReviewCommentResolved(r.Context(), database.SetReviewCommentResolvedParams{
+		Resolved: params.Resolved,
+		ID:       comment.ID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	commenter, err := apiCfg.DB.GetUserById(r.Context(), comment.UserID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, reviewCommentResponse(comment, commenter.Username, newAccessToken))
+}
+
+// function to create the response of a review comment
+func reviewCommentResponse(comment database.ReviewComment, username string, newAccessToken string) ReviewCommentResponse {
+	return ReviewCommentResponse{
+		ID:          comment.ID,
+		BlogID:      comment.BlogID,
+		UserID:      comment.UserID,
+		Username:    username,
+		Body:        comment.Body,
+		Start:       comment.AnchorStart,
+		End:         comment.AnchorEnd,
+		QuotedText:  comment.QuotedText,
+		Resolved:    comment.Resolved,
+		CreatedAt:   comment.CreatedAt,
+		UpdatedAt:   comment.UpdatedAt,
+		AccessToken: newAccessToken,
+	}
+}
@@ -0,0 +1,29 @@
+package controllers
+
+import "testing"
+
+func TestNeedsReviewAfterCategoryChange(t *testing.T) {
+	tests := []struct {
+		name                   string
+		isPublished            bool
+		reviewStatus           string
+		categoryRequiresReview bool
+		want                   bool
+	}{
+		{"published blog moved into a gated category", true, ReviewStatusNone, true, true},
+		{"published blog with a pending review moved into a gated category", true, ReviewStatusPending, true, true},
+		{"published blog with changes requested moved into a gated category", true, ReviewStatusChangesRequested, true, true},
+		{"approved blog moved into a gated category", true, ReviewStatusApproved, true, false},
+		{"published blog moved into an open category", true, ReviewStatusNone, false, false},
+		{"unpublished blog moved into a gated category", false, ReviewStatusNone, true, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := needsReviewAfterCategoryChange(test.isPublished, test.reviewStatus, test.categoryRequiresReview)
+			if got != test.want {
+				t.Errorf("needsReviewAfterCategoryChange(%v, %q, %v) = %v, want %v", test.isPublished, test.reviewStatus, test.categoryRequiresReview, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,491 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/harshvardha/blogs/internal/database"
+	"github.com/harshvardha/blogs/utility"
+)
+
+// function to check that the viewer can see the series of its author, blocked users do not get to know it exists
+func (apiCfg *ApiConfig) checkSeriesAccess(ctx context.Context, viewer database.User, series database.Series) error {
+	blocked, err := apiCfg.isBlockedEitherWay(ctx, viewer.ID, series.AuthorID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return &requestError{http.StatusNotFound, "Series not found"}
+	}
+
+	allowed, err := apiCfg.canViewAuthorContent(ctx, viewer, series.AuthorID)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return &requestError{http.StatusForbidden, "This account is private, only approved followers can see its series"}
+	}
+	return nil
+}
+
+// function to get the parts of the series in order, parts the viewer cannot read are left out so the numbering matches what they see
+func (apiCfg *ApiConfig) visibleSeriesParts(ctx context.Context, viewer database.User, series database.Series) ([]database.GetSeriesPartsRow, error) {
+	parts, err := apiCfg.DB.GetSeriesParts(ctx, series.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	visibleParts := []database.GetSeriesPartsRow{}
+	for _, part := range parts {
+		if !canViewBlog(viewer, series.AuthorID, part.ModerationStatus) {
+			continue
+		}
+		if !part.IsPublished && viewer.ID != series.AuthorID && !isModerator(viewer) {
+			continue
+		}
+		allowed, err := apiCfg.canViewBlogVisibility(ctx, viewer, series.AuthorID, part.Visibility)
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			visibleParts = append(visibleParts, part)
+		}
+	}
+	return visibleParts, nil
+}
+
+// function to get the series a blog belongs to along with its neighbouring parts, blogs outside a series have no series info
+func (apiCfg *ApiConfig) blogSeriesInfo(ctx context.Context, viewer database.User, blogID uuid.UUID) (*BlogSeriesInfo, error) {
+	series, err := apiCfg.DB.GetSeriesByBlogId(ctx, blogID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	parts, err := apiCfg.visibleSeriesParts(ctx, viewer, series)
+	if err != nil {
+		return nil, err
+	}
+
+	for position, part := range parts {
+		if part.ID != blogID {
+			continue
+		}
+		info := &BlogSeriesInfo{
+			ID:         series.ID,
+			Title:      series.Title,
+			Part:       position + 1,
+			TotalParts: len(parts),
+		}
+		if position > 0 {
+			info.Previous = &BlogSeriesLink{
+				BlogID: parts[position-1].ID,
+				Title:  parts[position-1].Title,
+			}
+		}
+		if position < len(parts)-1 {
+			info.Next = &BlogSeriesLink{
+				BlogID: parts[position+1].ID,
+				Title:  parts[position+1].Title,
+			}
+		}
+		return info, nil
+	}
+	return nil, nil
+}
+
+// function to create the series response with its parts numbered in order
+func seriesResponse(series database.Series, parts []database.GetSeriesPartsRow, newAccessToken string) SeriesResponse {
+	response := SeriesResponse{
+		ID:          series.ID,
+		AuthorID:    series.AuthorID,
+		Title:       series.Title,
+		Description: series.Description,
+		PartsCount:  int64(len(parts)),
+		Parts:       []SeriesPartResponse{},
+		CreatedAt:   series.CreatedAt,
+		UpdatedAt:   series.UpdatedAt,
+		AccessToken: newAccessToken,
+	}
+	for position, part := range parts {
+		response.Parts = append(response.Parts, SeriesPartResponse{
+			BlogID:       part.ID,
+			Title:        part.Title,
+			ThumbnailURL: part.ThumbnailUrl,
+			Part:         position + 1,
+			CreatedAt:    part.CreatedAt,
+		})
+	}
+	return response
+}
+
+// function to check the title and description of a series
+func validateSeries(params SeriesRequest) error {
+	err := utility.ValidateSeriesTitle(params.Title)
+	if err != nil {
+		return err
+	}
+	return utility.ValidateSeriesDescription(params.Description)
+}
+
+// function to fetch the series and check that the user wrote it
+func (apiCfg *ApiConfig) getOwnedSeries(ctx context.Context, seriesID uuid.UUID, user database.User) (database.Series, error) {
+	series, err := apiCfg.DB.GetSeriesById(ctx, seriesID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return database.Series{}, &requestError{http.StatusNotFound, "Series not found"}
+		}
+		return database.Series{}, err
+	}
+	if series.AuthorID != user.ID {
+		return database.Series{}, &requestError{http.StatusUnauthorized, "You are not authorized to modify series"}
+	}
+	return series, nil
+}
+
+// function to parse the series id from url params and fetch the series the user wrote, it responds on failure
+func (apiCfg *ApiConfig) parseOwnedSeries(w http.ResponseWriter, r *http.Request, user database.User) (database.Series, bool) {
+	seriesID, err := uuid.Parse(r.PathValue("seriesID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid series id")
+		return database.Series{}, false
+	}
+	series, err := apiCfg.getOwnedSeries(r.Context(), seriesID, user)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return database.Series{}, false
+	}
+	return series, true
+}
+
+// handler function to create a new series
+func (apiCfg *ApiConfig) HandleCreateSeries(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	// decoding the request body
+	decoder := json.NewDecoder(r.Body)
+	params := SeriesRequest{}
+	err := decoder.Decode(&params)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid series details")
+		return
+	}
+	params.Title = strings.TrimSpace(params.Title)
+	params.Description = strings.TrimSpace(params.Description)
+	err = validateSeries(params)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// creating the series
+	series, err := apiCfg.DB.CreateSeries(r.Context(), database.CreateSeriesParams{
+		AuthorID:    user.ID,
+		Title:       params.Title,
+		Description: params.Description,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusCreated, seriesResponse(series, nil, newAccessToken))
+}
+
+// handler function to edit the title and description of a series
+func (apiCfg *ApiConfig) HandleEditSeries(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	series, ok := apiCfg.parseOwnedSeries(w, r, user)
+	if !ok {
+		return
+	}
+
+	// decoding the request body, empty fields keep their current value
+	decoder := json.NewDecoder(r.Body)
+	params := SeriesRequest{}
+	err := decoder.Decode(&params)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid series details")
+		return
+	}
+	params.Title = strings.TrimSpace(params.Title)
+	params.Description = strings.TrimSpace(params.Description)
+	if len(params.Title) == 0 {
+		params.Title = series.Title
+	}
+	if len(params.Description) == 0 {
+		params.Description = series.Description
+	}
+	err = validateSeries(params)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// updating the series
+	series, err = apiCfg.DB.EditSeries(r.Context(), database.EditSeriesParams{
+		Title:       params.Title,
+		Description: params.Description,
+		ID:          series.ID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	parts, err := apiCfg.DB.GetSeriesParts(r.Context(), series.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, seriesResponse(series, parts, newAccessToken))
+}
+
+// handler function to delete a series, its blogs are kept and only leave the series
+func (apiCfg *ApiConfig) HandleDeleteSeries(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	series, ok := apiCfg.parseOwnedSeries(w, r, user)
+	if !ok {
+		return
+	}
+
+	err := apiCfg.DB.DeleteSeries(r.Context(), series.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, EmptyResponse{
+		AccessToken: newAccessToken,
+	})
+}
+
+// handler function to get a series with the parts the user can read
+func (apiCfg *ApiConfig) HandleGetSeries(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	seriesID, err := uuid.Parse(r.PathValue("seriesID"))
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid series id")
+		return
+	}
+	series, err := apiCfg.DB.GetSeriesById(r.Context(), seriesID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utility.RespondWithError(w, http.StatusNotFound, "Series not found")
+			return
+		}
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	err = apiCfg.checkSeriesAccess(r.Context(), user, series)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+
+	// fetching the parts
+	parts, err := apiCfg.visibleSeriesParts(r.Context(), user, series)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, seriesResponse(series, parts, newAccessToken))
+}
+
+// handler function to get the series written by a user
+func (apiCfg *ApiConfig) HandleGetUserSeries(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	userID, ok := apiCfg.parseExistingUserID(w, r)
+	if !ok || !apiCfg.canViewFollowLists(w, r, user, userID) {
+		return
+	}
+	blocked, err := apiCfg.isBlockedEitherWay(r.Context(), user.ID, userID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if blocked {
+		utility.RespondWithError(w, http.StatusNotFound, "User does not exist")
+		return
+	}
+
+	// fetching the series
+	limit, offset := utility.ParsePagination(r)
+	authorSeries, err := apiCfg.DB.GetSeriesByAuthorId(r.Context(), database.GetSeriesByAuthorIdParams{
+		AuthorID: userID,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// creating response
+	allSeries := []SeriesResponse{}
+	for _, series := range authorSeries {
+		allSeries = append(allSeries, SeriesResponse{
+			ID:          series.ID,
+			AuthorID:    series.AuthorID,
+			Title:       series.Title,
+			Description: series.Description,
+			PartsCount:  series.PartsCount,
+			CreatedAt:   series.CreatedAt,
+			UpdatedAt:   series.UpdatedAt,
+			AccessToken: newAccessToken,
+		})
+	}
+	utility.RespondWithJson(w, http.StatusOK, allSeries)
+}
+
+// handler function to add a blog as the last part of a series, a blog can only be part of one series
+func (apiCfg *ApiConfig) HandleAddBlogToSeries(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	// decoding the request body
+	decoder := json.NewDecoder(r.Body)
+	params := SeriesBlogRequest{}
+	err := decoder.Decode(&params)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid information")
+		return
+	}
+
+	// checking that the user wrote both the series and the blog
+	series, err := apiCfg.getOwnedSeries(r.Context(), params.SeriesID, user)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+	blog, err := apiCfg.DB.GetBlogById(r.Context(), params.BlogID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utility.RespondWithError(w, http.StatusNotFound, "Blog not found")
+			return
+		}
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if blog.AuthorID != user.ID {
+		utility.RespondWithError(w, http.StatusUnauthorized, "Only your own blogs can be added to your series")
+		return
+	}
+
+	// adding the blog
+	_, err = apiCfg.DB.AddBlogToSeries(r.Context(), database.AddBlogToSeriesParams{
+		SeriesID: series.ID,
+		BlogID:   blog.ID,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			utility.RespondWithError(w, http.StatusConflict, "Blog is already part of a series")
+			return
+		}
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	apiCfg.respondWithSeries(w, r, series, newAccessToken)
+}
+
+// handler function to remove a blog from a series
+func (apiCfg *ApiConfig) HandleRemoveBlogFromSeries(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	// decoding the request body
+	decoder := json.NewDecoder(r.Body)
+	params := SeriesBlogRequest{}
+	err := decoder.Decode(&params)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid information")
+		return
+	}
+
+	series, err := apiCfg.getOwnedSeries(r.Context(), params.SeriesID, user)
+	if err != nil {
+		respondWithRequestError(w, err)
+		return
+	}
+
+	// removing the blog
+	removed, err := apiCfg.DB.RemoveBlogFromSeries(r.Context(), database.RemoveBlogFromSeriesParams{
+		SeriesID: series.ID,
+		BlogID:   params.BlogID,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if removed == 0 {
+		utility.RespondWithError(w, http.StatusNotFound, "Blog is not part of the series")
+		return
+	}
+	apiCfg.respondWithSeries(w, r, series, newAccessToken)
+}
+
+// handler function to set the order of the parts of a series, the request lists every blog of the series in the new order
+func (apiCfg *ApiConfig) HandleReorderSeries(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	series, ok := apiCfg.parseOwnedSeries(w, r, user)
+	if !ok {
+		return
+	}
+
+	// decoding the request body
+	decoder := json.NewDecoder(r.Body)
+	params := SeriesReorderRequest{}
+	err := decoder.Decode(&params)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid reorder details")
+		return
+	}
+
+	// checking that the new order has every part of the series exactly once
+	parts, err := apiCfg.DB.GetSeriesParts(r.Context(), series.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	remaining := make(map[uuid.UUID]bool, len(parts))
+	for _, part := range parts {
+		remaining[part.ID] = true
+	}
+	for _, blogID := range params.BlogIDs {
+		if !remaining[blogID] {
+			utility.RespondWithError(w, http.StatusBadRequest, "Blog ids must list every part of the series once")
+			return
+		}
+		delete(remaining, blogID)
+	}
+	if len(remaining) > 0 {
+		utility.RespondWithError(w, http.StatusBadRequest, "Blog ids must list every part of the series once")
+		return
+	}
+
+	// saving the new order
+	err = apiCfg.DB.ReorderSeries(r.Context(), database.ReorderSeriesParams{
+		SeriesID: series.ID,
+		BlogIds:  params.BlogIDs,
+	})
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	apiCfg.respondWithSeries(w, r, series, newAccessToken)
+}
+
+// function to mark the series as updated after its parts changed and respond with all of its parts
+func (apiCfg *ApiConfig) respondWithSeries(w http.ResponseWriter, r *http.Request, series database.Series, newAccessToken string) {
+	err := apiCfg.DB.TouchSeries(r.Context(), series.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	series, err = apiCfg.DB.GetSeriesById(r.Context(), series.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	parts, err := apiCfg.DB.GetSeriesParts(r.Context(), series.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utility.RespondWithJson(w, http.StatusOK, seriesResponse(series, parts, newAccessToken))
+}
@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/harshvardha/blogs/internal/database"
+	"github.com/harshvardha/blogs/utility"
+	"github.com/lib/pq"
+)
+
+// a username can only be changed once in this period
+const UsernameChangeCooldown = 30 * 24 * time.Hour
+
+// an old username is held for its previous owner for this period before anyone else can take it
+const UsernameHoldPeriod = 90 * 24 * time.Hour
+
+// postgres error code raised when a unique constraint is violated
+const uniqueViolationCode = "23505"
+
+// function to check if the error was raised by a unique constraint
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode
+}
+
+// function to check that the username follows the username rules and is free for the user to take
+// usernames are compared case-insensitively and old usernames of other users are held for a while
+func (apiCfg *ApiConfig) checkUsernameAvailable(ctx context.Context, username string, userID uuid.UUID) error {
+	err := utility.ValidateUsername(username)
+	if err != nil {
+		return &requestError{http.StatusBadRequest, err.Error()}
+	}
+
+	ownerID, err := apiCfg.DB.GetUserIdByUsername(ctx, username)
+	if err == nil && ownerID != userID {
+		return &requestError{http.StatusConflict, "Username is already taken"}
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	held, err := apiCfg.DB.IsUsernameHeld(ctx, database.IsUsernameHeldParams{
+		Username:  username,
+		UserID:    userID,
+		HeldSince: time.Now().UTC().Add(-UsernameHoldPeriod),
+	})
+	if err != nil {
+		return err
+	}
+	if held {
+		return &requestError{http.StatusConflict, "Username is already taken"}
+	}
+	return nil
+}
+
+// function to change the username of the user, the old username keeps redirecting to the user until someone else takes it
+func (apiCfg *ApiConfig) changeUsername(ctx context.Context, user database.User, newUsername string) error {
+	if user.UsernameChangedAt.Valid && time.Now().UTC().Before(user.UsernameChangedAt.Time.Add(UsernameChangeCooldown)) {
+		return &requestError{http.StatusTooManyRequests, "Username can only be changed once every 30 days, next change allowed after " + user.UsernameChangedAt.Time.Add(UsernameChangeCooldown).Format(time.RFC1123)}
+	}
+	err := apiCfg.checkUsernameAvailable(ctx, newUsername, user.ID)
+	if err != nil {
+		return err
+	}
+
+	// taking the username away from the redirect history of its previous owner
+	err = apiCfg.DB.ReleaseUsername(ctx, newUsername)
+	if err != nil {
+		return err
+	}
+	err = apiCfg.DB.ChangeUsername(ctx, database.ChangeUsernameParams{
+		Username: newUsername,
+		ID:       user.ID,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return &requestError{http.StatusConflict, "Username is already taken"}
+		}
+		return err
+	}
+
+	// a change in letter case only does not free the old username
+	if strings.EqualFold(user.Username, newUsername) {
+		return nil
+	}
+	return apiCfg.DB.RecordUsernameChange(ctx, database.RecordUsernameChangeParams{
+		OldUsername: user.Username,
+		UserID:      user.ID,
+	})
+}
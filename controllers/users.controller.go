@@ -1,20 +1,34 @@
 package controllers
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/harshvardha/blogs/internal/database"
+	"github.com/harshvardha/blogs/notifications"
 	"github.com/harshvardha/blogs/utility"
 )
 
 // update user profile handler function
+// profile fields which are left out of the request body are not changed
 func (apiCfg *ApiConfig) HandleUpdateProfile(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
 	// request body will be decoded into this format
 	type UpdateUser struct {
-		Email    string `json:"email"`
-		Username string `json:"username"`
+		Email       string            `json:"email"`
+		Username    string            `json:"username"`
+		IsPrivate   *bool             `json:"is_private"`
+		DisplayName *string           `json:"display_name"`
+		Bio         *string           `json:"bio"`
+		AvatarURL   *string           `json:"avatar_url"`
+		Website     *string           `json:"website"`
+		Location    *string           `json:"location"`
+		SocialLinks map[string]string `json:"social_links"`
 	}
 
 	// decoding request body
@@ -25,53 +39,239 @@ func (apiCfg *ApiConfig) HandleUpdateProfile(w http.ResponseWriter, r *http.Requ
 		utility.RespondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	profileChanged := params.IsPrivate != nil || params.DisplayName != nil || params.Bio != nil || params.AvatarURL != nil || params.Website != nil || params.Location != nil || params.SocialLinks != nil
 
-	// checking which fields to update
-	var updatedUser database.UpdateUserEmailOrUsernameRow
-	if len(params.Email) > 0 && len(params.Username) == 0 {
-		updatedUser, err = apiCfg.DB.UpdateUserEmailOrUsername(r.Context(), database.UpdateUserEmailOrUsernameParams{
+	// validating the profile fields
+	if params.DisplayName != nil {
+		user.DisplayName = strings.TrimSpace(*params.DisplayName)
+	}
+	if params.Bio != nil {
+		user.Bio = strings.TrimSpace(*params.Bio)
+	}
+	if params.AvatarURL != nil {
+		user.AvatarUrl = strings.TrimSpace(*params.AvatarURL)
+	}
+	if params.Website != nil {
+		user.Website = strings.TrimSpace(*params.Website)
+	}
+	if params.Location != nil {
+		user.Location = strings.TrimSpace(*params.Location)
+	}
+	validationErrors := []error{
+		utility.ValidateDisplayName(user.DisplayName),
+		utility.ValidateBio(user.Bio),
+		utility.ValidateURL("avatar url", user.AvatarUrl),
+		utility.ValidateURL("website", user.Website),
+		utility.ValidateLocation(user.Location),
+	}
+	for platform, link := range params.SocialLinks {
+		validationErrors = append(validationErrors, utility.ValidateSocialLink(platform, strings.TrimSpace(link)))
+	}
+	for _, err := range validationErrors {
+		if err != nil {
+			utility.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	// checking if there is anything to update
+	if len(params.Email) == 0 && len(params.Username) == 0 && !profileChanged {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid user data to update")
+		return
+	}
+
+	// changing the username, it goes through the username rules and can only be changed once in a while
+	if len(params.Username) > 0 && params.Username != user.Username {
+		err = apiCfg.changeUsername(r.Context(), user, params.Username)
+		if err != nil {
+			respondWithRequestError(w, err)
+			return
+		}
+		user.Username = params.Username
+	}
+
+	// changing the email
+	if len(params.Email) > 0 {
+		_, err = apiCfg.DB.UpdateUserEmailOrUsername(r.Context(), database.UpdateUserEmailOrUsernameParams{
 			Email:    params.Email,
 			Username: user.Username,
 			ID:       user.ID,
 		})
-	} else if len(params.Username) > 0 && len(params.Email) == 0 {
-		updatedUser, err = apiCfg.DB.UpdateUserEmailOrUsername(r.Context(), database.UpdateUserEmailOrUsernameParams{
-			Email:    user.Email,
-			Username: params.Username,
-			ID:       user.ID,
+		if err != nil {
+			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	// updating the profile fields
+	if profileChanged {
+		_, err = apiCfg.DB.UpdateUserProfile(r.Context(), database.UpdateUserProfileParams{
+			DisplayName: user.DisplayName,
+			Bio:         user.Bio,
+			AvatarUrl:   user.AvatarUrl,
+			Website:     user.Website,
+			Location:    user.Location,
+			ID:          user.ID,
 		})
-	} else if len(params.Email) > 0 && len(params.Username) > 0 {
-		updatedUser, err = apiCfg.DB.UpdateUserEmailOrUsername(r.Context(), database.UpdateUserEmailOrUsernameParams{
-			Email:    params.Email,
-			Username: params.Username,
-			ID:       user.ID,
+		if err != nil {
+			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	// changing the account privacy, making the account public approves every pending follow request
+	if params.IsPrivate != nil && *params.IsPrivate != user.IsPrivate {
+		err = apiCfg.DB.SetUserPrivacy(r.Context(), database.SetUserPrivacyParams{
+			IsPrivate: *params.IsPrivate,
+			ID:        user.ID,
 		})
-	} else {
-		utility.RespondWithError(w, http.StatusBadRequest, "Invalid user data to update")
+		if err != nil {
+			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if !*params.IsPrivate {
+			approvedRequesterIDs, err := apiCfg.DB.ApproveAllFollowRequests(r.Context(), user.ID)
+			if err != nil {
+				utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			for _, requesterID := range approvedRequesterIDs {
+				apiCfg.notifyFollowRequestApproved(r.Context(), requesterID, user.ID)
+			}
+		}
+	}
+
+	// updating the social links, an empty link removes the platform from the profile
+	for platform, link := range params.SocialLinks {
+		link = strings.TrimSpace(link)
+		if len(link) == 0 {
+			err = apiCfg.DB.DeleteSocialLink(r.Context(), database.DeleteSocialLinkParams{
+				UserID:   user.ID,
+				Platform: platform,
+			})
+		} else {
+			err = apiCfg.DB.UpsertSocialLink(r.Context(), database.UpsertSocialLinkParams{
+				UserID:   user.ID,
+				Platform: platform,
+				Url:      link,
+			})
+		}
+		if err != nil {
+			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	// creating response
+	updatedUser, err := apiCfg.DB.GetUserById(r.Context(), user.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	profile, err := apiCfg.getProfile(r.Context(), updatedUser.ID, updatedUser.DisplayName, updatedUser.Bio, updatedUser.AvatarUrl, updatedUser.Website, updatedUser.Location)
 	if err != nil {
 		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-
+	profile.IsPrivate = updatedUser.IsPrivate
 	utility.RespondWithJson(w, http.StatusOK, ResponseUser{
 		ID:          updatedUser.ID,
 		Email:       updatedUser.Email,
 		Username:    updatedUser.Username,
+		Profile:     &profile,
 		AccessToken: newAccessToken,
 		CreatedAt:   updatedUser.CreatedAt,
 		UpdatedAt:   updatedUser.UpdatedAt,
 	})
 }
 
+// function to collect the profile fields of a user along with their social links
+func (apiCfg *ApiConfig) getProfile(ctx context.Context, userID uuid.UUID, displayName string, bio string, avatarURL string, website string, location string) (ResponseProfile, error) {
+	links, err := apiCfg.DB.GetSocialLinks(ctx, userID)
+	if err != nil {
+		return ResponseProfile{}, err
+	}
+
+	socialLinks := make(map[string]string)
+	for _, link := range links {
+		socialLinks[link.Platform] = link.Url
+	}
+	return ResponseProfile{
+		DisplayName: displayName,
+		Bio:         bio,
+		AvatarURL:   avatarURL,
+		Website:     website,
+		Location:    location,
+		SocialLinks: socialLinks,
+	}, nil
+}
+
+// handler function to get the public profile of a user, the email of the user is never part of it
+func (apiCfg *ApiConfig) HandleGetPublicProfile(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
+	username := r.PathValue("username")
+	if len(username) == 0 {
+		utility.RespondWithError(w, http.StatusBadRequest, "Invalid username")
+		return
+	}
+
+	// fetching the profile
+	userProfile, err := apiCfg.DB.GetPublicProfileByUsername(r.Context(), username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// redirecting old usernames to the current username of their owner
+			// the redirect is temporary as the old username can be taken by someone else later
+			currentUsername, err := apiCfg.DB.GetCurrentUsernameByOldUsername(r.Context(), username)
+			if err == nil {
+				http.Redirect(w, r, "/api/users/"+url.PathEscape(currentUsername), http.StatusFound)
+				return
+			}
+			utility.RespondWithError(w, http.StatusNotFound, "User not found")
+			return
+		}
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// profiles of users blocked by or blocking the viewer are not visible
+	blocked, err := apiCfg.isBlockedEitherWay(r.Context(), user.ID, userProfile.ID)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if blocked {
+		utility.RespondWithError(w, http.StatusNotFound, "User not found")
+		return
+	}
+	profile, err := apiCfg.getProfile(r.Context(), userProfile.ID, userProfile.DisplayName, userProfile.Bio, userProfile.AvatarUrl, userProfile.Website, userProfile.Location)
+	if err != nil {
+		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	profile.IsPrivate = userProfile.IsPrivate
+
+	utility.RespondWithJson(w, http.StatusOK, PublicProfileResponse{
+		ID:              userProfile.ID,
+		Username:        userProfile.Username,
+		ResponseProfile: profile,
+		FollowersCount:  userProfile.FollowersCount,
+		FollowingCount:  userProfile.FollowingCount,
+		PostsCount:      userProfile.PostsCount,
+		CreatedAt:       userProfile.CreatedAt,
+	})
+}
+
 // follow user handler function
+// following a private account sends a follow request instead, calling it again cancels the request
 func (apiCfg *ApiConfig) HandleFollowUnFollowUser(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
 	followingUserID, err := uuid.Parse(r.PathValue("followingID"))
 	if err != nil {
 		utility.RespondWithError(w, http.StatusBadRequest, "Invalid user id to follow")
 		return
 	}
+	if followingUserID == user.ID {
+		utility.RespondWithError(w, http.StatusBadRequest, "You cannot follow yourself")
+		return
+	}
 
 	// checking if the user with followingUserID exist or not
 	userExist, err := apiCfg.DB.GetUserById(r.Context(), followingUserID)
@@ -84,21 +284,54 @@ func (apiCfg *ApiConfig) HandleFollowUnFollowUser(w http.ResponseWriter, r *http
 		return
 	}
 
+	// the followed user is the entity so that all the followers are grouped into one notification
+	followNotification := notifications.Notification{
+		UserID:     followingUserID,
+		ActorID:    user.ID,
+		Type:       notifications.TypeFollow,
+		EntityType: notifications.EntityUser,
+		EntityID:   followingUserID,
+	}
+	var followStatus string
+
 	// checking if the follower and following pair already exist
 	followPair, err := apiCfg.DB.GetPair(r.Context(), database.GetPairParams{
 		FollowerID:  user.ID,
 		FollowingID: followingUserID,
 	})
 	if err != nil {
-		err = apiCfg.DB.FollowUser(r.Context(), database.FollowUserParams{
-			FollowerID:  user.ID,
-			FollowingID: followingUserID,
-		})
+		// users blocked by or blocking the other user cannot follow them
+		blocked, err := apiCfg.isBlockedEitherWay(r.Context(), user.ID, followingUserID)
+		if err != nil {
+			utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if blocked {
+			utility.RespondWithError(w, http.StatusForbidden, "You cannot follow this user")
+			return
+		}
+
+		if userExist.IsPrivate {
+			followStatus, err = apiCfg.toggleFollowRequest(r.Context(), user.ID, followingUserID)
+		} else {
+			err = apiCfg.DB.FollowUser(r.Context(), database.FollowUserParams{
+				FollowerID:  user.ID,
+				FollowingID: followingUserID,
+			})
+			if err == nil {
+				apiCfg.notify(r.Context(), followNotification)
+			}
+			followStatus = FollowStatusFollowing
+		}
 	} else if followPair.FollowerID == user.ID && followPair.FollowingID == followingUserID {
 		err = apiCfg.DB.UnfollowUser(r.Context(), database.UnfollowUserParams{
 			FollowerID:  user.ID,
 			FollowingID: followingUserID,
 		})
+		if err == nil {
+			apiCfg.retractNotification(r.Context(), followNotification)
+		}
+		followStatus = FollowStatusNotFollowing
 	} else {
 		utility.RespondWithError(w, http.StatusBadRequest, "Invalid id to follow or unfollow")
 		return
@@ -108,7 +341,8 @@ func (apiCfg *ApiConfig) HandleFollowUnFollowUser(w http.ResponseWriter, r *http
 		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	utility.RespondWithJson(w, http.StatusOK, EmptyResponse{
+	utility.RespondWithJson(w, http.StatusOK, FollowStatusResponse{
+		Status:      followStatus,
 		AccessToken: newAccessToken,
 	})
 }
@@ -132,7 +366,8 @@ func (apiCfg *ApiConfig) HandleDeleteUserAccount(w http.ResponseWriter, r *http.
 }
 
 // search user handler function
-func (apiCfg *ApiConfig) HandleSearch(w http.ResponseWriter, r *http.Request) {
+// users blocked by or blocking the viewer are left out
+func (apiCfg *ApiConfig) HandleSearch(w http.ResponseWriter, r *http.Request, user database.User, newAccessToken string) {
 	searchQuery := r.URL.Query().Get("username")
 	if len(searchQuery) == 0 {
 		utility.RespondWithError(w, http.StatusBadRequest, "Invalid user id to search for")
@@ -140,7 +375,10 @@ func (apiCfg *ApiConfig) HandleSearch(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// searching for the user
-	usersExist, err := apiCfg.DB.GetUsersByUsername(r.Context(), searchQuery)
+	usersExist, err := apiCfg.DB.SearchUsersByUsername(r.Context(), database.SearchUsersByUsernameParams{
+		Username: searchQuery,
+		ViewerID: user.ID,
+	})
 	if err != nil {
 		utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -0,0 +1,140 @@
+package export
+
+import (
+	"archive/zip"
+	"fmt"
+	"hash/crc32"
+	"html"
+	"io"
+	"strings"
+)
+
+// content of the mimetype file identifying the archive as an epub
+const epubMimetype = "application/epub+zip"
+
+// container pointing readers to the package document of the epub
+const epubContainer = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+// a file inside the epub archive
+type epubFile struct {
+	name    string
+	content string
+}
+
+// WriteEPUB writes the book as an epub 3 file with a title page, a table of contents and one chapter per blog
+func WriteEPUB(w io.Writer, book Book) error {
+	archive := zip.NewWriter(w)
+
+	// the mimetype has to be the first file, stored uncompressed and without a data descriptor so it is written raw
+	mimetype, err := archive.CreateRaw(&zip.FileHeader{
+		Name:               "mimetype",
+		Method:             zip.Store,
+		Modified:           book.Modified,
+		CRC32:              crc32.ChecksumIEEE([]byte(epubMimetype)),
+		CompressedSize64:   uint64(len(epubMimetype)),
+		UncompressedSize64: uint64(len(epubMimetype)),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(mimetype, epubMimetype)
+	if err != nil {
+		return err
+	}
+
+	files := []epubFile{
+		{"META-INF/container.xml", epubContainer},
+		{"OEBPS/content.opf", epubPackage(book)},
+		{"OEBPS/style.css", stylesheet},
+		{"OEBPS/nav.xhtml", epubNavigation(book)},
+		{"OEBPS/title.xhtml", epubTitlePage(book)},
+	}
+	for position, chapter := range book.Chapters {
+		var body strings.Builder
+		renderChapter(&body, chapter)
+		files = append(files, epubFile{"OEBPS/" + epubChapterFile(position), xhtmlDocument(book.Language, chapter.Title, body.String())})
+	}
+	for _, file := range files {
+		err = writeZipFile(archive, file.name, file.content, book)
+		if err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+// function to name the file of the chapter at the position
+func epubChapterFile(position int) string {
+	return fmt.Sprintf("chapter-%03d.xhtml", position+1)
+}
+
+// function to create the package document with the metadata, manifest and reading order of the epub
+func epubPackage(book Book) string {
+	var opf strings.Builder
+	opf.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	opf.WriteString("<package xmlns=\"http://www.idpf.org/2007/opf\" version=\"3.0\" unique-identifier=\"book-id\">\n")
+	opf.WriteString("  <metadata xmlns:dc=\"http://purl.org/dc/elements/1.1/\">\n")
+	fmt.Fprintf(&opf, "    <dc:identifier id=\"book-id\">urn:uuid:%s</dc:identifier>\n", book.ID)
+	fmt.Fprintf(&opf, "    <dc:title>%s</dc:title>\n", html.EscapeString(book.Title))
+	fmt.Fprintf(&opf, "    <dc:language>%s</dc:language>\n", html.EscapeString(book.Language))
+	if len(book.Curator) > 0 {
+		fmt.Fprintf(&opf, "    <dc:creator>%s</dc:creator>\n", html.EscapeString(book.Curator))
+	}
+	if len(book.Description) > 0 {
+		fmt.Fprintf(&opf, "    <dc:description>%s</dc:description>\n", html.EscapeString(book.Description))
+	}
+	fmt.Fprintf(&opf, "    <meta property=\"dcterms:modified\">%s</meta>\n", book.Modified.UTC().Format("2006-01-02T15:04:05Z"))
+	opf.WriteString("  </metadata>\n  <manifest>\n")
+	opf.WriteString("    <item id=\"nav\" href=\"nav.xhtml\" media-type=\"application/xhtml+xml\" properties=\"nav\"/>\n")
+	opf.WriteString("    <item id=\"style\" href=\"style.css\" media-type=\"text/css\"/>\n")
+	opf.WriteString("    <item id=\"title\" href=\"title.xhtml\" media-type=\"application/xhtml+xml\"/>\n")
+	for position := range book.Chapters {
+		fmt.Fprintf(&opf, "    <item id=\"chapter-%d\" href=\"%s\" media-type=\"application/xhtml+xml\"/>\n", position+1, epubChapterFile(position))
+	}
+	opf.WriteString("  </manifest>\n  <spine>\n    <itemref idref=\"title\"/>\n    <itemref idref=\"nav\"/>\n")
+	for position := range book.Chapters {
+		fmt.Fprintf(&opf, "    <itemref idref=\"chapter-%d\"/>\n", position+1)
+	}
+	opf.WriteString("  </spine>\n</package>\n")
+	return opf.String()
+}
+
+// function to create the table of contents of the epub
+func epubNavigation(book Book) string {
+	var body strings.Builder
+	body.WriteString("<nav epub:type=\"toc\" id=\"toc\">\n<h1>Contents</h1>\n<ol>\n")
+	body.WriteString("<li><a href=\"title.xhtml\">" + html.EscapeString(book.Title) + "</a></li>\n")
+	for position, chapter := range book.Chapters {
+		fmt.Fprintf(&body, "<li><a href=\"%s\">%s</a></li>\n", epubChapterFile(position), html.EscapeString(chapter.Title))
+	}
+	body.WriteString("</ol>\n</nav>\n")
+	return xhtmlDocument(book.Language, "Contents", body.String())
+}
+
+// function to create the title page of the epub
+func epubTitlePage(book Book) string {
+	var body strings.Builder
+	fmt.Fprintf(&body, "<h1>%s</h1>\n", html.EscapeString(book.Title))
+	if len(book.Curator) > 0 {
+		fmt.Fprintf(&body, "<p class=\"byline\">Collected by %s</p>\n", html.EscapeString(book.Curator))
+	}
+	renderParagraphs(&body, book.Description)
+	return xhtmlDocument(book.Language, book.Title, body.String())
+}
+
+// function to wrap the body in an xhtml document using the shared stylesheet
+func xhtmlDocument(language string, title string, body string) string {
+	var document strings.Builder
+	document.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<!DOCTYPE html>\n")
+	fmt.Fprintf(&document, "<html xmlns=\"http://www.w3.org/1999/xhtml\" xmlns:epub=\"http://www.idpf.org/2007/ops\" xml:lang=\"%s\" lang=\"%s\">\n", html.EscapeString(language), html.EscapeString(language))
+	fmt.Fprintf(&document, "<head>\n<meta charset=\"utf-8\"/>\n<title>%s</title>\n<link rel=\"stylesheet\" type=\"text/css\" href=\"style.css\"/>\n</head>\n", html.EscapeString(title))
+	fmt.Fprintf(&document, "<body>\n%s</body>\n</html>\n", body)
+	return document.String()
+}
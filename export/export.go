@@ -0,0 +1,106 @@
+package export
+
+import (
+	"html"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// Book is a collection put together for reading offline, the chapters are its blogs in the order of the collection
+type Book struct {
+	ID          uuid.UUID
+	Title       string
+	Description string
+	Curator     string
+	Language    string
+	Modified    time.Time
+	Chapters    []Chapter
+}
+
+// Chapter is a blog in the book along with the note the collection has on it
+type Chapter struct {
+	ID          uuid.UUID
+	Title       string
+	Author      string
+	Category    string
+	Note        string
+	Content     string
+	PublishedAt time.Time
+}
+
+// maximum length of the slugs used for file names
+const maxSlugLength = 60
+
+// Slug turns the title into a lowercase file name made of letters, digits and hyphens
+func Slug(title string, fallback string) string {
+	var slug strings.Builder
+	pendingHyphen := false
+	for _, character := range strings.ToLower(title) {
+		if character < unicode.MaxASCII && (unicode.IsLetter(character) || unicode.IsDigit(character)) {
+			if pendingHyphen && slug.Len() > 0 {
+				slug.WriteByte('-')
+			}
+			pendingHyphen = false
+			slug.WriteRune(character)
+			if slug.Len() >= maxSlugLength {
+				break
+			}
+			continue
+		}
+		pendingHyphen = true
+	}
+	if slug.Len() == 0 {
+		return fallback
+	}
+	return slug.String()
+}
+
+// function to split the text of a blog into paragraphs on blank lines
+func paragraphs(text string) [][]string {
+	result := [][]string{}
+	current := []string{}
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if len(strings.TrimSpace(line)) == 0 {
+			if len(current) > 0 {
+				result = append(result, current)
+				current = []string{}
+			}
+			continue
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		result = append(result, current)
+	}
+	return result
+}
+
+// function to render the text as escaped paragraphs which are valid in both html and xhtml
+func renderParagraphs(builder *strings.Builder, text string) {
+	for _, paragraph := range paragraphs(text) {
+		builder.WriteString("<p>")
+		for position, line := range paragraph {
+			if position > 0 {
+				builder.WriteString("<br/>\n")
+			}
+			builder.WriteString(html.EscapeString(line))
+		}
+		builder.WriteString("</p>\n")
+	}
+}
+
+// function to describe the author, category and publishing date of a chapter in one line
+func byline(chapter Chapter) string {
+	parts := []string{}
+	if len(chapter.Author) > 0 {
+		parts = append(parts, "by "+chapter.Author)
+	}
+	if len(chapter.Category) > 0 {
+		parts = append(parts, "in "+chapter.Category)
+	}
+	parts = append(parts, "on "+chapter.PublishedAt.Format("January 2, 2006"))
+	return strings.Join(parts, " ")
+}
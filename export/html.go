@@ -0,0 +1,60 @@
+package export
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// styles shared by the html document and the epub chapters
+const stylesheet = `body { font-family: Georgia, serif; line-height: 1.6; max-width: 42em; margin: 0 auto; padding: 1em; }
+h1, h2 { line-height: 1.2; }
+.byline { color: #555; font-style: italic; }
+.note { border-left: 3px solid #ccc; padding-left: 1em; color: #333; }
+nav ol { padding-left: 1.5em; }
+article { page-break-before: always; }
+`
+
+// WriteHTML writes the book as a single html document with a table of contents
+// the document has no external resources so that it can be read offline or printed to pdf
+func WriteHTML(w io.Writer, book Book) error {
+	var document strings.Builder
+	document.WriteString("<!DOCTYPE html>\n")
+	fmt.Fprintf(&document, "<html lang=\"%s\">\n<head>\n<meta charset=\"utf-8\"/>\n", html.EscapeString(book.Language))
+	fmt.Fprintf(&document, "<title>%s</title>\n<style>\n%s</style>\n</head>\n<body>\n", html.EscapeString(book.Title), stylesheet)
+
+	// title page and table of contents
+	fmt.Fprintf(&document, "<header>\n<h1>%s</h1>\n", html.EscapeString(book.Title))
+	if len(book.Curator) > 0 {
+		fmt.Fprintf(&document, "<p class=\"byline\">Collected by %s</p>\n", html.EscapeString(book.Curator))
+	}
+	renderParagraphs(&document, book.Description)
+	document.WriteString("</header>\n<nav>\n<h2>Contents</h2>\n<ol>\n")
+	for _, chapter := range book.Chapters {
+		fmt.Fprintf(&document, "<li><a href=\"#blog-%s\">%s</a></li>\n", chapter.ID, html.EscapeString(chapter.Title))
+	}
+	document.WriteString("</ol>\n</nav>\n")
+
+	// blogs
+	for _, chapter := range book.Chapters {
+		fmt.Fprintf(&document, "<article id=\"blog-%s\">\n", chapter.ID)
+		renderChapter(&document, chapter)
+		document.WriteString("</article>\n")
+	}
+	document.WriteString("</body>\n</html>\n")
+
+	_, err := io.WriteString(w, document.String())
+	return err
+}
+
+// function to render the heading, note and text of a chapter
+func renderChapter(builder *strings.Builder, chapter Chapter) {
+	fmt.Fprintf(builder, "<h2>%s</h2>\n<p class=\"byline\">%s</p>\n", html.EscapeString(chapter.Title), html.EscapeString(byline(chapter)))
+	if len(chapter.Note) > 0 {
+		builder.WriteString("<div class=\"note\">\n")
+		renderParagraphs(builder, chapter.Note)
+		builder.WriteString("</div>\n")
+	}
+	renderParagraphs(builder, chapter.Content)
+}
@@ -0,0 +1,73 @@
+package export
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WriteMarkdown writes the book as a zip of markdown files, one per blog numbered in the order of the collection
+// along with a README listing them
+func WriteMarkdown(w io.Writer, book Book) error {
+	archive := zip.NewWriter(w)
+
+	// naming the files of the blogs
+	fileNames := make([]string, len(book.Chapters))
+	for position, chapter := range book.Chapters {
+		fileNames[position] = fmt.Sprintf("%03d-%s.md", position+1, Slug(chapter.Title, "blog"))
+	}
+
+	// README with the details of the collection
+	var readme strings.Builder
+	fmt.Fprintf(&readme, "# %s\n\n", book.Title)
+	if len(book.Curator) > 0 {
+		fmt.Fprintf(&readme, "_Collected by %s_\n\n", book.Curator)
+	}
+	if len(book.Description) > 0 {
+		fmt.Fprintf(&readme, "%s\n\n", book.Description)
+	}
+	readme.WriteString("## Contents\n\n")
+	for position, chapter := range book.Chapters {
+		fmt.Fprintf(&readme, "%d. [%s](%s)\n", position+1, chapter.Title, fileNames[position])
+	}
+	err := writeZipFile(archive, "README.md", readme.String(), book)
+	if err != nil {
+		return err
+	}
+
+	// blogs with their details as front matter
+	for position, chapter := range book.Chapters {
+		var file strings.Builder
+		file.WriteString("---\n")
+		fmt.Fprintf(&file, "title: %s\n", strconv.Quote(chapter.Title))
+		fmt.Fprintf(&file, "author: %s\n", strconv.Quote(chapter.Author))
+		fmt.Fprintf(&file, "category: %s\n", strconv.Quote(chapter.Category))
+		fmt.Fprintf(&file, "published: %s\n", chapter.PublishedAt.Format("2006-01-02"))
+		if len(chapter.Note) > 0 {
+			fmt.Fprintf(&file, "note: %s\n", strconv.Quote(chapter.Note))
+		}
+		fmt.Fprintf(&file, "---\n\n# %s\n\n%s\n", chapter.Title, strings.TrimSpace(chapter.Content))
+		err = writeZipFile(archive, fileNames[position], file.String(), book)
+		if err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+// function to add a compressed file to the archive
+func writeZipFile(archive *zip.Writer, name string, content string, book Book) error {
+	file, err := archive.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: book.Modified,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(file, content)
+	return err
+}
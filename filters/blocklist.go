@@ -0,0 +1,65 @@
+package filters
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// BlocklistFilter matches content against a list of blocked words and regular expressions
+type BlocklistFilter struct {
+	words    *regexp.Regexp
+	patterns []*regexp.Regexp
+	action   Action
+}
+
+// NewBlocklistFilter creates a blocklist filter, words are matched as whole words ignoring case
+func NewBlocklistFilter(words []string, patterns []string, action Action) (*BlocklistFilter, error) {
+	filter := &BlocklistFilter{
+		action: action,
+	}
+
+	// combining all the words into a single case insensitive expression
+	var quotedWords []string
+	for _, word := range words {
+		word = strings.TrimSpace(word)
+		if len(word) > 0 {
+			quotedWords = append(quotedWords, regexp.QuoteMeta(word))
+		}
+	}
+	if len(quotedWords) > 0 {
+		filter.words = regexp.MustCompile(`(?i)\b(` + strings.Join(quotedWords, "|") + `)\b`)
+	}
+
+	// compiling the user supplied expressions
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if len(pattern) == 0 {
+			continue
+		}
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid blocklist pattern %q: %w", pattern, err)
+		}
+		filter.patterns = append(filter.patterns, compiled)
+	}
+
+	return filter, nil
+}
+
+func (f *BlocklistFilter) Check(ctx context.Context, content Content) (Verdict, error) {
+	text := content.Title + "\n" + content.Body
+	if f.words != nil {
+		if match := f.words.FindString(text); len(match) > 0 {
+			return Verdict{Action: f.action, Reason: fmt.Sprintf("contains blocked word %q", match)}, nil
+		}
+	}
+	for _, pattern := range f.patterns {
+		if pattern.MatchString(text) {
+			return Verdict{Action: f.action, Reason: "matches a blocked pattern"}, nil
+		}
+	}
+
+	return Allowed, nil
+}
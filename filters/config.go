@@ -0,0 +1,31 @@
+package filters
+
+import "time"
+
+// Config holds the settings of the built in filters
+type Config struct {
+	BlockedWords      []string
+	BlockedPatterns   []string
+	MaxBlogLinks      int
+	MaxCommentLinks   int
+	MaxPostsPerWindow int
+	PostingWindow     time.Duration
+	DuplicateWindow   time.Duration
+}
+
+// NewDefaultFilter chains the built in filters together
+// blocked words and posting too fast are rejected, link heavy content is flagged for moderation
+// and duplicates are shadow hidden so that spam bots do not learn they were caught
+func NewDefaultFilter(cfg Config) (ContentFilter, error) {
+	blocklist, err := NewBlocklistFilter(cfg.BlockedWords, cfg.BlockedPatterns, ActionReject)
+	if err != nil {
+		return nil, err
+	}
+
+	return Chain(
+		blocklist,
+		NewVelocityFilter(cfg.MaxPostsPerWindow, cfg.PostingWindow, ActionReject),
+		NewLinkLimitFilter(cfg.MaxBlogLinks, cfg.MaxCommentLinks, ActionFlag),
+		NewDuplicateFilter(cfg.DuplicateWindow, ActionShadowHide),
+	), nil
+}
@@ -0,0 +1,108 @@
+package filters
+
+import (
+	"context"
+	"crypto/sha256"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type postedContent struct {
+	entityID uuid.UUID
+	postedAt time.Time
+}
+
+// DuplicateFilter catches users posting the same content again within a time window
+// content is compared after ignoring case and whitespace differences
+type DuplicateFilter struct {
+	window time.Duration
+	action Action
+
+	mu        sync.Mutex
+	seen      map[uuid.UUID]map[[sha256.Size]byte]postedContent
+	lastSweep time.Time
+}
+
+// NewDuplicateFilter creates a duplicate filter remembering what every user posted within window
+func NewDuplicateFilter(window time.Duration, action Action) *DuplicateFilter {
+	return &DuplicateFilter{
+		window:    window,
+		action:    action,
+		seen:      make(map[uuid.UUID]map[[sha256.Size]byte]postedContent),
+		lastSweep: time.Now(),
+	}
+}
+
+// function to hash the content after ignoring case and whitespace differences, empty content has no hash
+func contentHash(content Content) ([sha256.Size]byte, bool) {
+	normalized := strings.ToLower(strings.Join(strings.Fields(content.Body), " "))
+	if len(normalized) == 0 {
+		return [sha256.Size]byte{}, false
+	}
+	return sha256.Sum256([]byte(content.Kind + "\x00" + normalized)), true
+}
+
+func (f *DuplicateFilter) Check(ctx context.Context, content Content) (Verdict, error) {
+	hash, ok := contentHash(content)
+	if !ok {
+		return Allowed, nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// the same blog or comment being saved again is not a duplicate
+	now := time.Now()
+	f.sweep(now)
+	posted, ok := f.seen[content.AuthorID][hash]
+	if ok && now.Sub(posted.postedAt) < f.window && posted.entityID != content.EntityID {
+		return Verdict{Action: f.action, Reason: "duplicate of recently posted content"}, nil
+	}
+
+	return Allowed, nil
+}
+
+// Record remembers the saved content so that posting it again as another blog or comment is caught
+func (f *DuplicateFilter) Record(content Content, created bool) {
+	hash, ok := contentHash(content)
+	if !ok {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	f.sweep(now)
+	userContent := f.seen[content.AuthorID]
+	if userContent == nil {
+		userContent = make(map[[sha256.Size]byte]postedContent)
+		f.seen[content.AuthorID] = userContent
+	}
+	userContent[hash] = postedContent{
+		entityID: content.EntityID,
+		postedAt: now,
+	}
+}
+
+// function to forget the content which is out of the window, it runs at most once every window so that idle users do not pile up
+func (f *DuplicateFilter) sweep(now time.Time) {
+	if now.Sub(f.lastSweep) < f.window {
+		return
+	}
+	f.lastSweep = now
+
+	for authorID, userContent := range f.seen {
+		for key, posted := range userContent {
+			if now.Sub(posted.postedAt) >= f.window {
+				delete(userContent, key)
+			}
+		}
+		if len(userContent) == 0 {
+			delete(f.seen, authorID)
+		}
+	}
+}
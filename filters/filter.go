@@ -0,0 +1,112 @@
+package filters
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// kinds of content which are passed through the filters
+const (
+	KindBlog    = "blog"
+	KindComment = "comment"
+)
+
+// Action is what should happen to a piece of content after it was checked
+// actions are ordered by strictness so that the strictest one wins in a chain
+type Action int
+
+const (
+	ActionAllow Action = iota
+	ActionFlag
+	ActionShadowHide
+	ActionReject
+)
+
+func (a Action) String() string {
+	switch a {
+	case ActionFlag:
+		return "flag"
+	case ActionShadowHide:
+		return "shadow_hide"
+	case ActionReject:
+		return "reject"
+	default:
+		return "allow"
+	}
+}
+
+// Content is a blog or a comment which is about to be created or edited
+// EntityID is uuid.Nil when the content is being created
+type Content struct {
+	Kind     string
+	EntityID uuid.UUID
+	AuthorID uuid.UUID
+	Title    string
+	Body     string
+}
+
+// Verdict is the result of checking a piece of content
+type Verdict struct {
+	Action Action
+	Reason string
+}
+
+// Allowed is the verdict returned by filters which found nothing wrong
+var Allowed = Verdict{Action: ActionAllow}
+
+// ContentFilter checks a piece of content and decides what should happen to it
+type ContentFilter interface {
+	Check(ctx context.Context, content Content) (Verdict, error)
+}
+
+// Recorder is implemented by filters which remember what users posted
+// Record is called once the content was saved, so EntityID is always set and created tells if it was a new post
+type Recorder interface {
+	Record(content Content, created bool)
+}
+
+// FilterFunc allows an ordinary function to be used as a ContentFilter
+type FilterFunc func(ctx context.Context, content Content) (Verdict, error)
+
+func (f FilterFunc) Check(ctx context.Context, content Content) (Verdict, error) {
+	return f(ctx, content)
+}
+
+type chain []ContentFilter
+
+// Chain composes multiple filters into one
+// every filter is run in order and the strictest verdict is returned, a reject stops the chain early
+func Chain(filters ...ContentFilter) ContentFilter {
+	return chain(filters)
+}
+
+func (c chain) Check(ctx context.Context, content Content) (Verdict, error) {
+	verdict := Allowed
+	for _, filter := range c {
+		if filter == nil {
+			continue
+		}
+		result, err := filter.Check(ctx, content)
+		if err != nil {
+			return Verdict{}, err
+		}
+		if result.Action > verdict.Action {
+			verdict = result
+		}
+		if verdict.Action == ActionReject {
+			break
+		}
+	}
+
+	return verdict, nil
+}
+
+// Record passes the saved content to every filter in the chain which remembers posts
+func (c chain) Record(content Content, created bool) {
+	for _, filter := range c {
+		if recorder, ok := filter.(Recorder); ok {
+			recorder.Record(content, created)
+		}
+	}
+}
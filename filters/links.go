@@ -0,0 +1,40 @@
+package filters
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+var linkPattern = regexp.MustCompile(`(?i)(https?://|www\.)\S+`)
+
+// LinkLimitFilter limits the number of links a piece of content can contain
+type LinkLimitFilter struct {
+	maxLinks map[string]int
+	action   Action
+}
+
+// NewLinkLimitFilter creates a link limit filter with a separate limit for blogs and comments
+func NewLinkLimitFilter(maxBlogLinks int, maxCommentLinks int, action Action) *LinkLimitFilter {
+	return &LinkLimitFilter{
+		maxLinks: map[string]int{
+			KindBlog:    maxBlogLinks,
+			KindComment: maxCommentLinks,
+		},
+		action: action,
+	}
+}
+
+func (f *LinkLimitFilter) Check(ctx context.Context, content Content) (Verdict, error) {
+	maxLinks, ok := f.maxLinks[content.Kind]
+	if !ok {
+		return Allowed, nil
+	}
+
+	noOfLinks := len(linkPattern.FindAllString(content.Title+"\n"+content.Body, -1))
+	if noOfLinks > maxLinks {
+		return Verdict{Action: f.action, Reason: fmt.Sprintf("contains %d links, at most %d are allowed", noOfLinks, maxLinks)}, nil
+	}
+
+	return Allowed, nil
+}
@@ -0,0 +1,92 @@
+package filters
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VelocityFilter limits how many pieces of content a user can post within a time window
+// edits are not counted as new posts
+type VelocityFilter struct {
+	limit  int
+	window time.Duration
+	action Action
+
+	mu        sync.Mutex
+	posts     map[uuid.UUID][]time.Time
+	lastSweep time.Time
+}
+
+// NewVelocityFilter creates a velocity filter allowing limit posts per user within window
+func NewVelocityFilter(limit int, window time.Duration, action Action) *VelocityFilter {
+	return &VelocityFilter{
+		limit:     limit,
+		window:    window,
+		action:    action,
+		posts:     make(map[uuid.UUID][]time.Time),
+		lastSweep: time.Now(),
+	}
+}
+
+func (f *VelocityFilter) Check(ctx context.Context, content Content) (Verdict, error) {
+	if content.EntityID != uuid.Nil {
+		return Allowed, nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	f.sweep(now)
+	if len(f.recentPosts(content.AuthorID, now)) >= f.limit {
+		return Verdict{Action: f.action, Reason: fmt.Sprintf("posting too fast, at most %d posts are allowed every %s", f.limit, f.window)}, nil
+	}
+
+	return Allowed, nil
+}
+
+// Record counts a saved post against its author, edits are not counted
+func (f *VelocityFilter) Record(content Content, created bool) {
+	if !created {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	f.sweep(now)
+	f.posts[content.AuthorID] = append(f.recentPosts(content.AuthorID, now), now)
+}
+
+// function to drop the posts of the user which are out of the window and return the rest
+func (f *VelocityFilter) recentPosts(authorID uuid.UUID, now time.Time) []time.Time {
+	recentPosts := f.posts[authorID][:0]
+	for _, postedAt := range f.posts[authorID] {
+		if now.Sub(postedAt) < f.window {
+			recentPosts = append(recentPosts, postedAt)
+		}
+	}
+	if len(recentPosts) == 0 {
+		delete(f.posts, authorID)
+		return nil
+	}
+	f.posts[authorID] = recentPosts
+	return recentPosts
+}
+
+// function to forget the users who did not post within the window, it runs at most once every window
+func (f *VelocityFilter) sweep(now time.Time) {
+	if now.Sub(f.lastSweep) < f.window {
+		return
+	}
+	f.lastSweep = now
+
+	for authorID := range f.posts {
+		f.recentPosts(authorID, now)
+	}
+}
@@ -0,0 +1,262 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: blocks.sql
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const blockUser = `-- name: BlockUser :exec
+insert into user_blocks (blocker_id, blocked_id, created_at)
+values ($1, $2, NOW())
+on conflict do nothing
+`
+
+type BlockUserParams struct {
+	BlockerID uuid.UUID
+	BlockedID uuid.UUID
+}
+
+func (q *Queries) BlockUser(ctx context.Context, arg BlockUserParams) error {
+	_, err := q.db.ExecContext(ctx, blockUser, arg.BlockerID, arg.BlockedID)
+	return err
+}
+
+const deleteFollowsBetween = `-- name: DeleteFollowsBetween :exec
+delete from users_follow
+    where (follower_id = $1 and following_id = $2)
+        or (follower_id = $2 and following_id = $1)
+`
+
+type DeleteFollowsBetweenParams struct {
+	UserID      uuid.UUID
+	OtherUserID uuid.UUID
+}
+
+func (q *Queries) DeleteFollowsBetween(ctx context.Context, arg DeleteFollowsBetweenParams) error {
+	_, err := q.db.ExecContext(ctx, deleteFollowsBetween, arg.UserID, arg.OtherUserID)
+	return err
+}
+
+const getBlockedEitherWayUserIds = `-- name: GetBlockedEitherWayUserIds :many
+select user_blocks.blocked_id as user_id from user_blocks where user_blocks.blocker_id = $1
+union
+select user_blocks.blocker_id from user_blocks where user_blocks.blocked_id = $1
+`
+
+func (q *Queries) GetBlockedEitherWayUserIds(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, getBlockedEitherWayUserIds, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var user_id uuid.UUID
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBlockedUsers = `-- name: GetBlockedUsers :many
+select users.id, users.username, users.display_name, users.avatar_url, user_blocks.created_at
+    from user_blocks join users on users.id = user_blocks.blocked_id
+    where user_blocks.blocker_id = $1
+    order by user_blocks.created_at desc
+    limit $2 offset $3
+`
+
+type GetBlockedUsersParams struct {
+	BlockerID uuid.UUID
+	Limit     int32
+	Offset    int32
+}
+
+type GetBlockedUsersRow struct {
+	ID          uuid.UUID
+	Username    string
+	DisplayName string
+	AvatarUrl   string
+	CreatedAt   time.Time
+}
+
+func (q *Queries) GetBlockedUsers(ctx context.Context, arg GetBlockedUsersParams) ([]GetBlockedUsersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getBlockedUsers, arg.BlockerID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetBlockedUsersRow
+	for rows.Next() {
+		var i GetBlockedUsersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.DisplayName,
+			&i.AvatarUrl,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMutedUsers = `-- name: GetMutedUsers :many
+select users.id, users.username, users.display_name, users.avatar_url, user_mutes.created_at
+    from user_mutes join users on users.id = user_mutes.muted_id
+    where user_mutes.muter_id = $1
+    order by user_mutes.created_at desc
+    limit $2 offset $3
+`
+
+type GetMutedUsersParams struct {
+	MuterID uuid.UUID
+	Limit   int32
+	Offset  int32
+}
+
+type GetMutedUsersRow struct {
+	ID          uuid.UUID
+	Username    string
+	DisplayName string
+	AvatarUrl   string
+	CreatedAt   time.Time
+}
+
+func (q *Queries) GetMutedUsers(ctx context.Context, arg GetMutedUsersParams) ([]GetMutedUsersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getMutedUsers, arg.MuterID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMutedUsersRow
+	for rows.Next() {
+		var i GetMutedUsersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.DisplayName,
+			&i.AvatarUrl,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const isBlockedEitherWay = `-- name: IsBlockedEitherWay :one
+select exists(
+    select 1 from user_blocks
+    where (blocker_id = $1 and blocked_id = $2)
+        or (blocker_id = $2 and blocked_id = $1)
+)
+`
+
+type IsBlockedEitherWayParams struct {
+	UserID      uuid.UUID
+	OtherUserID uuid.UUID
+}
+
+func (q *Queries) IsBlockedEitherWay(ctx context.Context, arg IsBlockedEitherWayParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, isBlockedEitherWay, arg.UserID, arg.OtherUserID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const isNotificationSuppressed = `-- name: IsNotificationSuppressed :one
+select (exists(
+    select 1 from user_blocks
+    where (blocker_id = $1 and blocked_id = $2)
+        or (blocker_id = $2 and blocked_id = $1)
+) or exists(
+    select 1 from user_mutes where muter_id = $1 and muted_id = $2
+))::boolean as suppressed
+`
+
+type IsNotificationSuppressedParams struct {
+	UserID  uuid.UUID
+	ActorID uuid.UUID
+}
+
+func (q *Queries) IsNotificationSuppressed(ctx context.Context, arg IsNotificationSuppressedParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, isNotificationSuppressed, arg.UserID, arg.ActorID)
+	var suppressed bool
+	err := row.Scan(&suppressed)
+	return suppressed, err
+}
+
+const muteUser = `-- name: MuteUser :exec
+insert into user_mutes (muter_id, muted_id, created_at)
+values ($1, $2, NOW())
+on conflict do nothing
+`
+
+type MuteUserParams struct {
+	MuterID uuid.UUID
+	MutedID uuid.UUID
+}
+
+func (q *Queries) MuteUser(ctx context.Context, arg MuteUserParams) error {
+	_, err := q.db.ExecContext(ctx, muteUser, arg.MuterID, arg.MutedID)
+	return err
+}
+
+const unblockUser = `-- name: UnblockUser :exec
+delete from user_blocks where blocker_id = $1 and blocked_id = $2
+`
+
+type UnblockUserParams struct {
+	BlockerID uuid.UUID
+	BlockedID uuid.UUID
+}
+
+func (q *Queries) UnblockUser(ctx context.Context, arg UnblockUserParams) error {
+	_, err := q.db.ExecContext(ctx, unblockUser, arg.BlockerID, arg.BlockedID)
+	return err
+}
+
+const unmuteUser = `-- name: UnmuteUser :exec
+delete from user_mutes where muter_id = $1 and muted_id = $2
+`
+
+type UnmuteUserParams struct {
+	MuterID uuid.UUID
+	MutedID uuid.UUID
+}
+
+func (q *Queries) UnmuteUser(ctx context.Context, arg UnmuteUserParams) error {
+	_, err := q.db.ExecContext(ctx, unmuteUser, arg.MuterID, arg.MutedID)
+	return err
+}
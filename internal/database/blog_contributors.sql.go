@@ -0,0 +1,265 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: blog_contributors.sql
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const acceptBlogInvite = `-- name: AcceptBlogInvite :one
+update blog_contributors set status = 'accepted', updated_at = NOW() where blog_id = $1 and user_id = $2 and status = 'invited'
+returning blog_id, user_id, role, status, invited_by, created_at, updated_at
+`
+
+type AcceptBlogInviteParams struct {
+	BlogID uuid.UUID
+	UserID uuid.UUID
+}
+
+func (q *Queries) AcceptBlogInvite(ctx context.Context, arg AcceptBlogInviteParams) (BlogContributor, error) {
+	row := q.db.QueryRowContext(ctx, acceptBlogInvite, arg.BlogID, arg.UserID)
+	var i BlogContributor
+	err := row.Scan(
+		&i.BlogID,
+		&i.UserID,
+		&i.Role,
+		&i.Status,
+		&i.InvitedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getBlogAuthors = `-- name: GetBlogAuthors :many
+select authors.id, authors.username, authors.display_name, authors.avatar_url, authors.role from (
+    select users.id, users.username, users.display_name, users.avatar_url, 'owner'::text as role, blogs.created_at as since
+        from blogs join users on users.id = blogs.author_id
+        where blogs.id = $1
+    union all
+    select users.id, users.username, users.display_name, users.avatar_url, blog_contributors.role, blog_contributors.updated_at as since
+        from blog_contributors join users on users.id = blog_contributors.user_id
+        where blog_contributors.blog_id = $1 and blog_contributors.role = 'co-author' and blog_contributors.status = 'accepted'
+) as authors
+order by authors.since
+`
+
+type GetBlogAuthorsRow struct {
+	ID          uuid.UUID
+	Username    string
+	DisplayName string
+	AvatarUrl   string
+	Role        string
+}
+
+func (q *Queries) GetBlogAuthors(ctx context.Context, blogID uuid.UUID) ([]GetBlogAuthorsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getBlogAuthors, blogID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetBlogAuthorsRow
+	for rows.Next() {
+		var i GetBlogAuthorsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.DisplayName,
+			&i.AvatarUrl,
+			&i.Role,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBlogContributors = `-- name: GetBlogContributors :many
+select users.id,
+    users.username,
+    users.display_name,
+    users.avatar_url,
+    blog_contributors.role,
+    blog_contributors.status,
+    blog_contributors.created_at
+    from blog_contributors join users on users.id = blog_contributors.user_id
+    where blog_contributors.blog_id = $1
+    order by blog_contributors.created_at
+`
+
+type GetBlogContributorsRow struct {
+	ID          uuid.UUID
+	Username    string
+	DisplayName string
+	AvatarUrl   string
+	Role        string
+	Status      string
+	CreatedAt   time.Time
+}
+
+func (q *Queries) GetBlogContributors(ctx context.Context, blogID uuid.UUID) ([]GetBlogContributorsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getBlogContributors, blogID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetBlogContributorsRow
+	for rows.Next() {
+		var i GetBlogContributorsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.DisplayName,
+			&i.AvatarUrl,
+			&i.Role,
+			&i.Status,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBlogInvites = `-- name: GetBlogInvites :many
+select blogs.id,
+    blogs.title,
+    blogs.author_id,
+    users.username as author_name,
+    blog_contributors.role,
+    blog_contributors.created_at
+    from blog_contributors join blogs on blogs.id = blog_contributors.blog_id join users on users.id = blogs.author_id
+    where blog_contributors.user_id = $1 and blog_contributors.status = 'invited'
+    order by blog_contributors.created_at desc
+`
+
+type GetBlogInvitesRow struct {
+	ID         uuid.UUID
+	Title      string
+	AuthorID   uuid.UUID
+	AuthorName string
+	Role       string
+	CreatedAt  time.Time
+}
+
+func (q *Queries) GetBlogInvites(ctx context.Context, userID uuid.UUID) ([]GetBlogInvitesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getBlogInvites, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetBlogInvitesRow
+	for rows.Next() {
+		var i GetBlogInvitesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.AuthorID,
+			&i.AuthorName,
+			&i.Role,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBlogRole = `-- name: GetBlogRole :one
+select (case when blogs.author_id = $1 then 'owner'
+    else coalesce((select blog_contributors.role from blog_contributors
+        where blog_contributors.blog_id = blogs.id and blog_contributors.user_id = $1 and blog_contributors.status = 'accepted'),
+        (select 'reviewer' from blog_reviewers where blog_reviewers.blog_id = blogs.id and blog_reviewers.reviewer_id = $1),
+        (select 'editor' from publication_members where publication_members.publication_id = blogs.publication_id and publication_members.user_id = $1 and publication_members.role in ('owner', 'editor') and publication_members.status = 'accepted'), '') end)::text as role
+    from blogs where blogs.id = $2
+`
+
+type GetBlogRoleParams struct {
+	UserID uuid.UUID
+	BlogID uuid.UUID
+}
+
+func (q *Queries) GetBlogRole(ctx context.Context, arg GetBlogRoleParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getBlogRole, arg.UserID, arg.BlogID)
+	var role string
+	err := row.Scan(&role)
+	return role, err
+}
+
+const inviteBlogContributor = `-- name: InviteBlogContributor :one
+insert into blog_contributors (blog_id, user_id, role, status, invited_by, created_at, updated_at)
+values ($1, $2, $3, 'invited', $4, NOW(), NOW())
+on conflict (blog_id, user_id) do update set role = excluded.role, updated_at = NOW()
+returning blog_id, user_id, role, status, invited_by, created_at, updated_at
+`
+
+type InviteBlogContributorParams struct {
+	BlogID    uuid.UUID
+	UserID    uuid.UUID
+	Role      string
+	InvitedBy uuid.NullUUID
+}
+
+func (q *Queries) InviteBlogContributor(ctx context.Context, arg InviteBlogContributorParams) (BlogContributor, error) {
+	row := q.db.QueryRowContext(ctx, inviteBlogContributor,
+		arg.BlogID,
+		arg.UserID,
+		arg.Role,
+		arg.InvitedBy,
+	)
+	var i BlogContributor
+	err := row.Scan(
+		&i.BlogID,
+		&i.UserID,
+		&i.Role,
+		&i.Status,
+		&i.InvitedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const removeBlogContributor = `-- name: RemoveBlogContributor :execrows
+delete from blog_contributors where blog_id = $1 and user_id = $2
+`
+
+type RemoveBlogContributorParams struct {
+	BlogID uuid.UUID
+	UserID uuid.UUID
+}
+
+func (q *Queries) RemoveBlogContributor(ctx context.Context, arg RemoveBlogContributorParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, removeBlogContributor, arg.BlogID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
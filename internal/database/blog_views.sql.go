@@ -0,0 +1,149 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: blog_views.sql
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const getAuthorBlogStats = `-- name: GetAuthorBlogStats :many
+select blogs.id,
+    blogs.title,
+    blogs.created_at,
+    (select count(*) from blog_views where blog_views.blog_id = blogs.id and blog_views.viewed_at > NOW() - make_interval(days => $1::int)) as views,
+    (select count(distinct blog_views.viewer_key) from blog_views where blog_views.blog_id = blogs.id and blog_views.viewed_at > NOW() - make_interval(days => $1::int)) as unique_readers,
+    (select count(*) from likes where likes.blog_id = blogs.id and likes.created_at > NOW() - make_interval(days => $1::int)) as likes,
+    (select count(*) from comments where comments.blog_id = blogs.id and comments.status = 'approved' and comments.created_at > NOW() - make_interval(days => $1::int)) as comments
+    from blogs where blogs.author_id = $2
+    order by blogs.created_at desc
+`
+
+type GetAuthorBlogStatsParams struct {
+	Days     int32
+	AuthorID uuid.UUID
+}
+
+type GetAuthorBlogStatsRow struct {
+	ID            uuid.UUID
+	Title         string
+	CreatedAt     time.Time
+	Views         int64
+	UniqueReaders int64
+	Likes         int64
+	Comments      int64
+}
+
+func (q *Queries) GetAuthorBlogStats(ctx context.Context, arg GetAuthorBlogStatsParams) ([]GetAuthorBlogStatsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAuthorBlogStats, arg.Days, arg.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAuthorBlogStatsRow
+	for rows.Next() {
+		var i GetAuthorBlogStatsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.CreatedAt,
+			&i.Views,
+			&i.UniqueReaders,
+			&i.Likes,
+			&i.Comments,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAuthorDailyStats = `-- name: GetAuthorDailyStats :many
+select days.day::date as day,
+    (select count(*) from blog_views join blogs on blogs.id = blog_views.blog_id
+        where blogs.author_id = $1 and ($2::uuid is null or blogs.id = $2::uuid) and blog_views.viewed_at::date = days.day::date) as views,
+    (select count(distinct blog_views.viewer_key) from blog_views join blogs on blogs.id = blog_views.blog_id
+        where blogs.author_id = $1 and ($2::uuid is null or blogs.id = $2::uuid) and blog_views.viewed_at::date = days.day::date) as unique_readers,
+    (select count(*) from likes join blogs on blogs.id = likes.blog_id
+        where blogs.author_id = $1 and ($2::uuid is null or blogs.id = $2::uuid) and likes.created_at::date = days.day::date) as likes,
+    (select count(*) from comments join blogs on blogs.id = comments.blog_id
+        where blogs.author_id = $1 and ($2::uuid is null or blogs.id = $2::uuid) and comments.status = 'approved' and comments.created_at::date = days.day::date) as comments,
+    (select count(*) from users_follow where users_follow.following_id = $1 and users_follow.created_at::date = days.day::date) as new_followers
+    from generate_series((NOW() - make_interval(days => $3::int - 1))::date, NOW()::date, interval '1 day') as days(day)
+    order by days.day
+`
+
+type GetAuthorDailyStatsParams struct {
+	AuthorID uuid.UUID
+	BlogID   uuid.NullUUID
+	Days     int32
+}
+
+type GetAuthorDailyStatsRow struct {
+	Day           time.Time
+	Views         int64
+	UniqueReaders int64
+	Likes         int64
+	Comments      int64
+	NewFollowers  int64
+}
+
+func (q *Queries) GetAuthorDailyStats(ctx context.Context, arg GetAuthorDailyStatsParams) ([]GetAuthorDailyStatsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAuthorDailyStats, arg.AuthorID, arg.BlogID, arg.Days)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAuthorDailyStatsRow
+	for rows.Next() {
+		var i GetAuthorDailyStatsRow
+		if err := rows.Scan(
+			&i.Day,
+			&i.Views,
+			&i.UniqueReaders,
+			&i.Likes,
+			&i.Comments,
+			&i.NewFollowers,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordBlogViews = `-- name: RecordBlogViews :exec
+insert into blog_views (blog_id, viewer_key, view_window, viewed_at)
+select unnest($1::uuid[]), unnest($2::text[]), unnest($3::bigint[]), NOW()
+on conflict do nothing
+`
+
+type RecordBlogViewsParams struct {
+	BlogIds     []uuid.UUID
+	ViewerKeys  []string
+	ViewWindows []int64
+}
+
+func (q *Queries) RecordBlogViews(ctx context.Context, arg RecordBlogViewsParams) error {
+	_, err := q.db.ExecContext(ctx, recordBlogViews, pq.Array(arg.BlogIds), pq.Array(arg.ViewerKeys), pq.Array(arg.ViewWindows))
+	return err
+}
@@ -20,6 +20,9 @@ insert into blogs(
     thumbnail_url, 
     content, 
     category, 
+    visibility,
+    is_published,
+    publication_id,
     created_at, 
     updated_at
 )
@@ -30,18 +33,24 @@ values (
     $3,
     $4,
     $5,
+    $6,
+    $7,
+    $8,
     NOW(),
     NOW()
 )
-returning id, title, author_id, thumbnail_url, content, created_at, updated_at, category
+returning id, title, author_id, thumbnail_url, content, created_at, updated_at, category, moderation_status, visibility, is_published, review_status, publication_id
 `
 
 type CreateBlogParams struct {
-	Title        string
-	AuthorID     uuid.UUID
-	ThumbnailUrl string
-	Content      string
-	Category     uuid.UUID
+	Title         string
+	AuthorID      uuid.UUID
+	ThumbnailUrl  string
+	Content       string
+	Category      uuid.UUID
+	Visibility    string
+	IsPublished   bool
+	PublicationID uuid.NullUUID
 }
 
 func (q *Queries) CreateBlog(ctx context.Context, arg CreateBlogParams) (Blog, error) {
@@ -51,6 +60,9 @@ func (q *Queries) CreateBlog(ctx context.Context, arg CreateBlogParams) (Blog, e
 		arg.ThumbnailUrl,
 		arg.Content,
 		arg.Category,
+		arg.Visibility,
+		arg.IsPublished,
+		arg.PublicationID,
 	)
 	var i Blog
 	err := row.Scan(
@@ -62,13 +74,18 @@ func (q *Queries) CreateBlog(ctx context.Context, arg CreateBlogParams) (Blog, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Category,
+		&i.ModerationStatus,
+		&i.Visibility,
+		&i.IsPublished,
+		&i.ReviewStatus,
+		&i.PublicationID,
 	)
 	return i, err
 }
 
 const deleteBlog = `-- name: DeleteBlog :one
 delete from blogs where id = $1
-returning id, title, author_id, thumbnail_url, content, created_at, updated_at, category
+returning id, title, author_id, thumbnail_url, content, created_at, updated_at, category, moderation_status, visibility, is_published, review_status, publication_id
 `
 
 func (q *Queries) DeleteBlog(ctx context.Context, id uuid.UUID) (Blog, error) {
@@ -83,13 +100,18 @@ func (q *Queries) DeleteBlog(ctx context.Context, id uuid.UUID) (Blog, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Category,
+		&i.ModerationStatus,
+		&i.Visibility,
+		&i.IsPublished,
+		&i.ReviewStatus,
+		&i.PublicationID,
 	)
 	return i, err
 }
 
 const editBlog = `-- name: EditBlog :one
-update blogs set title = $1, thumbnail_url = $2, content = $3, category = $4, updated_at = NOW() where id = $5
-returning id, title, author_id, thumbnail_url, content, created_at, updated_at, category
+update blogs set title = $1, thumbnail_url = $2, content = $3, category = $4, visibility = $5, updated_at = NOW() where id = $6
+returning id, title, author_id, thumbnail_url, content, created_at, updated_at, category, moderation_status, visibility, is_published, review_status, publication_id
 `
 
 type EditBlogParams struct {
@@ -97,6 +119,7 @@ type EditBlogParams struct {
 	ThumbnailUrl string
 	Content      string
 	Category     uuid.UUID
+	Visibility   string
 	ID           uuid.UUID
 }
 
@@ -106,6 +129,7 @@ func (q *Queries) EditBlog(ctx context.Context, arg EditBlogParams) (Blog, error
 		arg.ThumbnailUrl,
 		arg.Content,
 		arg.Category,
+		arg.Visibility,
 		arg.ID,
 	)
 	var i Blog
@@ -118,6 +142,11 @@ func (q *Queries) EditBlog(ctx context.Context, arg EditBlogParams) (Blog, error
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Category,
+		&i.ModerationStatus,
+		&i.Visibility,
+		&i.IsPublished,
+		&i.ReviewStatus,
+		&i.PublicationID,
 	)
 	return i, err
 }
@@ -133,6 +162,17 @@ func (q *Queries) GetAuthorNameByBlogId(ctx context.Context, id uuid.UUID) (stri
 	return username, err
 }
 
+const getBlogAuthorId = `-- name: GetBlogAuthorId :one
+select author_id from blogs where id = $1
+`
+
+func (q *Queries) GetBlogAuthorId(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, getBlogAuthorId, id)
+	var author_id uuid.UUID
+	err := row.Scan(&author_id)
+	return author_id, err
+}
+
 const getBlogById = `-- name: GetBlogById :one
 select blogs.id, 
     blogs.title, 
@@ -142,21 +182,31 @@ select blogs.id,
     blogs.category,
     blogs.created_at,
     blogs.updated_at,
+    blogs.moderation_status,
+    blogs.visibility,
+    blogs.is_published,
+    blogs.review_status,
+    blogs.publication_id,
     count(likes.blog_id) as likes_count 
     from blogs left join likes on blogs.id = likes.blog_id 
     where blogs.id = $1 group by blogs.id, blogs.title
 `
 
 type GetBlogByIdRow struct {
-	ID           uuid.UUID
-	Title        string
-	AuthorID     uuid.UUID
-	ThumbnailUrl string
-	Content      string
-	Category     uuid.UUID
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
-	LikesCount   int64
+	ID               uuid.UUID
+	Title            string
+	AuthorID         uuid.UUID
+	ThumbnailUrl     string
+	Content          string
+	Category         uuid.UUID
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	ModerationStatus string
+	Visibility       string
+	IsPublished      bool
+	ReviewStatus     string
+	PublicationID    uuid.NullUUID
+	LikesCount       int64
 }
 
 func (q *Queries) GetBlogById(ctx context.Context, id uuid.UUID) (GetBlogByIdRow, error) {
@@ -171,6 +221,11 @@ func (q *Queries) GetBlogById(ctx context.Context, id uuid.UUID) (GetBlogByIdRow
 		&i.Category,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ModerationStatus,
+		&i.Visibility,
+		&i.IsPublished,
+		&i.ReviewStatus,
+		&i.PublicationID,
 		&i.LikesCount,
 	)
 	return i, err
@@ -188,7 +243,7 @@ func (q *Queries) GetBlogNameById(ctx context.Context, id uuid.UUID) (string, er
 }
 
 const getBlogsByAuthorId = `-- name: GetBlogsByAuthorId :many
-select blogs.id, blogs.title, blogs.author_id, blogs.content, blogs.thumbnail_url, blogs.category, blogs.created_at, blogs.updated_at, count(likes.blog_id) as likes_count from blogs left join likes on blogs.id = likes.blog_id where blogs.author_id = $1 group by blogs.id, blogs.title, blogs.author_id, blogs.thumbnail_url
+select blogs.id, blogs.title, blogs.author_id, blogs.content, blogs.thumbnail_url, blogs.category, blogs.visibility, blogs.is_published, blogs.review_status, blogs.created_at, blogs.updated_at, count(likes.blog_id) as likes_count from blogs left join likes on blogs.id = likes.blog_id where blogs.author_id = $1 group by blogs.id, blogs.title, blogs.author_id, blogs.thumbnail_url
 `
 
 type GetBlogsByAuthorIdRow struct {
@@ -198,6 +253,9 @@ type GetBlogsByAuthorIdRow struct {
 	Content      string
 	ThumbnailUrl string
 	Category     uuid.UUID
+	Visibility   string
+	IsPublished  bool
+	ReviewStatus string
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 	LikesCount   int64
@@ -219,6 +277,9 @@ func (q *Queries) GetBlogsByAuthorId(ctx context.Context, authorID uuid.UUID) ([
 			&i.Content,
 			&i.ThumbnailUrl,
 			&i.Category,
+			&i.Visibility,
+			&i.IsPublished,
+			&i.ReviewStatus,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.LikesCount,
@@ -237,9 +298,23 @@ func (q *Queries) GetBlogsByAuthorId(ctx context.Context, authorID uuid.UUID) ([
 }
 
 const getBlogsByCategory = `-- name: GetBlogsByCategory :many
-select blogs.id, blogs.title, blogs.author_id, blogs.thumbnail_url, count(likes.blog_id) as likes_count from blogs left join likes on blogs.id = likes.blog_id where blogs.category = $1 group by blogs.id, blogs.title, blogs.author_id, blogs.thumbnail_url
+select blogs.id, blogs.title, blogs.author_id, blogs.thumbnail_url, count(likes.blog_id) as likes_count from blogs left join likes on blogs.id = likes.blog_id where blogs.category = $1 and blogs.is_published and blogs.moderation_status in ('visible', 'flagged')
+    and blogs.author_id not in (select user_blocks.blocked_id from user_blocks where user_blocks.blocker_id = $2)
+    and blogs.author_id not in (select user_blocks.blocker_id from user_blocks where user_blocks.blocked_id = $2)
+    and (blogs.author_id = $2
+        or blogs.author_id not in (select users.id from users where users.is_private)
+        or blogs.author_id in (select users_follow.following_id from users_follow where users_follow.follower_id = $2))
+    and (blogs.visibility = 'public'
+        or (blogs.author_id = $2 and blogs.visibility <> 'unlisted')
+        or (blogs.visibility = 'followers' and blogs.author_id in (select users_follow.following_id from users_follow where users_follow.follower_id = $2)))
+    group by blogs.id, blogs.title, blogs.author_id, blogs.thumbnail_url
 `
 
+type GetBlogsByCategoryParams struct {
+	Category uuid.UUID
+	ViewerID uuid.UUID
+}
+
 type GetBlogsByCategoryRow struct {
 	ID           uuid.UUID
 	Title        string
@@ -248,8 +323,8 @@ type GetBlogsByCategoryRow struct {
 	LikesCount   int64
 }
 
-func (q *Queries) GetBlogsByCategory(ctx context.Context, category uuid.UUID) ([]GetBlogsByCategoryRow, error) {
-	rows, err := q.db.QueryContext(ctx, getBlogsByCategory, category)
+func (q *Queries) GetBlogsByCategory(ctx context.Context, arg GetBlogsByCategoryParams) ([]GetBlogsByCategoryRow, error) {
+	rows, err := q.db.QueryContext(ctx, getBlogsByCategory, arg.Category, arg.ViewerID)
 	if err != nil {
 		return nil, err
 	}
@@ -278,9 +353,22 @@ func (q *Queries) GetBlogsByCategory(ctx context.Context, category uuid.UUID) ([
 }
 
 const getBlogsByTitle = `-- name: GetBlogsByTitle :many
-select id, title, author_id, thumbnail_url from blogs where title = $1
+select blogs.id, blogs.title, blogs.author_id, blogs.thumbnail_url from blogs where blogs.title = $1 and blogs.is_published and blogs.moderation_status in ('visible', 'flagged')
+    and blogs.author_id not in (select user_blocks.blocked_id from user_blocks where user_blocks.blocker_id = $2)
+    and blogs.author_id not in (select user_blocks.blocker_id from user_blocks where user_blocks.blocked_id = $2)
+    and (blogs.author_id = $2
+        or blogs.author_id not in (select users.id from users where users.is_private)
+        or blogs.author_id in (select users_follow.following_id from users_follow where users_follow.follower_id = $2))
+    and (blogs.visibility = 'public'
+        or (blogs.author_id = $2 and blogs.visibility <> 'unlisted')
+        or (blogs.visibility = 'followers' and blogs.author_id in (select users_follow.following_id from users_follow where users_follow.follower_id = $2)))
 `
 
+type GetBlogsByTitleParams struct {
+	Title    string
+	ViewerID uuid.UUID
+}
+
 type GetBlogsByTitleRow struct {
 	ID           uuid.UUID
 	Title        string
@@ -288,8 +376,8 @@ type GetBlogsByTitleRow struct {
 	ThumbnailUrl string
 }
 
-func (q *Queries) GetBlogsByTitle(ctx context.Context, title string) ([]GetBlogsByTitleRow, error) {
-	rows, err := q.db.QueryContext(ctx, getBlogsByTitle, title)
+func (q *Queries) GetBlogsByTitle(ctx context.Context, arg GetBlogsByTitleParams) ([]GetBlogsByTitleRow, error) {
+	rows, err := q.db.QueryContext(ctx, getBlogsByTitle, arg.Title, arg.ViewerID)
 	if err != nil {
 		return nil, err
 	}
@@ -363,6 +451,20 @@ func (q *Queries) LikeBlog(ctx context.Context, arg LikeBlogParams) error {
 	return err
 }
 
+const setBlogModerationStatus = `-- name: SetBlogModerationStatus :exec
+update blogs set moderation_status = $1 where id = $2
+`
+
+type SetBlogModerationStatusParams struct {
+	ModerationStatus string
+	ID               uuid.UUID
+}
+
+func (q *Queries) SetBlogModerationStatus(ctx context.Context, arg SetBlogModerationStatusParams) error {
+	_, err := q.db.ExecContext(ctx, setBlogModerationStatus, arg.ModerationStatus, arg.ID)
+	return err
+}
+
 const unlikeBlog = `-- name: UnlikeBlog :exec
 delete from likes where user_id = $1 and blog_id = $2
 `
@@ -12,31 +12,39 @@ import (
 )
 
 const createCategory = `-- name: CreateCategory :one
-insert into categories (id, category_name, created_at, updated_at)
+insert into categories (id, category_name, publication_id, created_at, updated_at)
 values (
     gen_random_uuid(),
     $1,
+    $2,
     NOW(),
     NOW()
 )
-returning id, category_name, created_at, updated_at
+returning id, category_name, created_at, updated_at, requires_review, publication_id
 `
 
-func (q *Queries) CreateCategory(ctx context.Context, categoryName string) (Category, error) {
-	row := q.db.QueryRowContext(ctx, createCategory, categoryName)
+type CreateCategoryParams struct {
+	CategoryName  string
+	PublicationID uuid.NullUUID
+}
+
+func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error) {
+	row := q.db.QueryRowContext(ctx, createCategory, arg.CategoryName, arg.PublicationID)
 	var i Category
 	err := row.Scan(
 		&i.ID,
 		&i.CategoryName,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RequiresReview,
+		&i.PublicationID,
 	)
 	return i, err
 }
 
 const deleteCategory = `-- name: DeleteCategory :one
 delete from categories where id = $1
-returning id, category_name, created_at, updated_at
+returning id, category_name, created_at, updated_at, requires_review, publication_id
 `
 
 func (q *Queries) DeleteCategory(ctx context.Context, id uuid.UUID) (Category, error) {
@@ -47,13 +55,15 @@ func (q *Queries) DeleteCategory(ctx context.Context, id uuid.UUID) (Category, e
 		&i.CategoryName,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RequiresReview,
+		&i.PublicationID,
 	)
 	return i, err
 }
 
 const editCategory = `-- name: EditCategory :one
 update categories set category_name = $1, updated_at = NOW() where id = $2
-returning id, category_name, created_at, updated_at
+returning id, category_name, created_at, updated_at, requires_review, publication_id
 `
 
 type EditCategoryParams struct {
@@ -69,16 +79,41 @@ func (q *Queries) EditCategory(ctx context.Context, arg EditCategoryParams) (Cat
 		&i.CategoryName,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RequiresReview,
+		&i.PublicationID,
+	)
+	return i, err
+}
+
+const getCategoryById = `-- name: GetCategoryById :one
+select id, category_name, created_at, updated_at, requires_review, publication_id from categories where id = $1
+`
+
+func (q *Queries) GetCategoryById(ctx context.Context, id uuid.UUID) (Category, error) {
+	row := q.db.QueryRowContext(ctx, getCategoryById, id)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.CategoryName,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RequiresReview,
+		&i.PublicationID,
 	)
 	return i, err
 }
 
 const getCategoryIdByName = `-- name: GetCategoryIdByName :one
-select id from categories where category_name = $1
+select id from categories where category_name = $1 and publication_id is not distinct from $2::uuid
 `
 
-func (q *Queries) GetCategoryIdByName(ctx context.Context, categoryName string) (uuid.UUID, error) {
-	row := q.db.QueryRowContext(ctx, getCategoryIdByName, categoryName)
+type GetCategoryIdByNameParams struct {
+	CategoryName  string
+	PublicationID uuid.NullUUID
+}
+
+func (q *Queries) GetCategoryIdByName(ctx context.Context, arg GetCategoryIdByNameParams) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, getCategoryIdByName, arg.CategoryName, arg.PublicationID)
 	var id uuid.UUID
 	err := row.Scan(&id)
 	return id, err
@@ -94,3 +129,27 @@ func (q *Queries) GetCategoryNameById(ctx context.Context, id uuid.UUID) (string
 	err := row.Scan(&category_name)
 	return category_name, err
 }
+
+const setCategoryRequiresReview = `-- name: SetCategoryRequiresReview :one
+update categories set requires_review = $1, updated_at = NOW() where id = $2
+returning id, category_name, created_at, updated_at, requires_review, publication_id
+`
+
+type SetCategoryRequiresReviewParams struct {
+	RequiresReview bool
+	ID             uuid.UUID
+}
+
+func (q *Queries) SetCategoryRequiresReview(ctx context.Context, arg SetCategoryRequiresReviewParams) (Category, error) {
+	row := q.db.QueryRowContext(ctx, setCategoryRequiresReview, arg.RequiresReview, arg.ID)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.CategoryName,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RequiresReview,
+		&i.PublicationID,
+	)
+	return i, err
+}
@@ -12,36 +12,83 @@ import (
 )
 
 const addBlogToCollection = `-- name: AddBlogToCollection :one
-insert into collection_blog (collection_id, blog_id, created_at, updated_at)
+insert into collection_blog (collection_id, blog_id, added_by, note, position, created_at, updated_at)
 values (
     $1,
     $2,
+    $3,
+    $4,
+    coalesce((select max(collection_blog.position) from collection_blog where collection_blog.collection_id = $1), 0) + $5::bigint,
     NOW(),
     NOW()
 )
-returning collection_id, blog_id, created_at, updated_at
+returning collection_id, blog_id, created_at, updated_at, added_by, position, note
 `
 
 type AddBlogToCollectionParams struct {
 	CollectionID uuid.UUID
 	BlogID       uuid.UUID
+	AddedBy      uuid.NullUUID
+	Note         string
+	PositionGap  int64
 }
 
 func (q *Queries) AddBlogToCollection(ctx context.Context, arg AddBlogToCollectionParams) (CollectionBlog, error) {
-	row := q.db.QueryRowContext(ctx, addBlogToCollection, arg.CollectionID, arg.BlogID)
+	row := q.db.QueryRowContext(ctx, addBlogToCollection,
+		arg.CollectionID,
+		arg.BlogID,
+		arg.AddedBy,
+		arg.Note,
+		arg.PositionGap,
+	)
 	var i CollectionBlog
 	err := row.Scan(
 		&i.CollectionID,
 		&i.BlogID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AddedBy,
+		&i.Position,
+		&i.Note,
 	)
 	return i, err
 }
 
+const getCollectionItemPositions = `-- name: GetCollectionItemPositions :many
+select blog_id, position from collection_blog where collection_id = $1 order by position
+`
+
+type GetCollectionItemPositionsRow struct {
+	BlogID   uuid.UUID
+	Position int64
+}
+
+func (q *Queries) GetCollectionItemPositions(ctx context.Context, collectionID uuid.UUID) ([]GetCollectionItemPositionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCollectionItemPositions, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCollectionItemPositionsRow
+	for rows.Next() {
+		var i GetCollectionItemPositionsRow
+		if err := rows.Scan(&i.BlogID, &i.Position); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const removeBlogFromCollection = `-- name: RemoveBlogFromCollection :one
 delete from collection_blog where collection_id = $1 and blog_id = $2
-returning collection_id, blog_id, created_at, updated_at
+returning collection_id, blog_id, created_at, updated_at, added_by, position, note
 `
 
 type RemoveBlogFromCollectionParams struct {
@@ -57,6 +104,66 @@ func (q *Queries) RemoveBlogFromCollection(ctx context.Context, arg RemoveBlogFr
 		&i.BlogID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AddedBy,
+		&i.Position,
+		&i.Note,
+	)
+	return i, err
+}
+
+const renumberCollectionItems = `-- name: RenumberCollectionItems :exec
+update collection_blog set position = ranked.rank * $1::bigint
+    from (select collection_blog.blog_id, row_number() over (order by collection_blog.position) as rank from collection_blog where collection_blog.collection_id = $2) as ranked
+    where collection_blog.collection_id = $2 and collection_blog.blog_id = ranked.blog_id
+`
+
+type RenumberCollectionItemsParams struct {
+	PositionGap  int64
+	CollectionID uuid.UUID
+}
+
+func (q *Queries) RenumberCollectionItems(ctx context.Context, arg RenumberCollectionItemsParams) error {
+	_, err := q.db.ExecContext(ctx, renumberCollectionItems, arg.PositionGap, arg.CollectionID)
+	return err
+}
+
+const setCollectionItemNote = `-- name: SetCollectionItemNote :one
+update collection_blog set note = $1, updated_at = NOW() where collection_id = $2 and blog_id = $3
+returning collection_id, blog_id, created_at, updated_at, added_by, position, note
+`
+
+type SetCollectionItemNoteParams struct {
+	Note         string
+	CollectionID uuid.UUID
+	BlogID       uuid.UUID
+}
+
+func (q *Queries) SetCollectionItemNote(ctx context.Context, arg SetCollectionItemNoteParams) (CollectionBlog, error) {
+	row := q.db.QueryRowContext(ctx, setCollectionItemNote, arg.Note, arg.CollectionID, arg.BlogID)
+	var i CollectionBlog
+	err := row.Scan(
+		&i.CollectionID,
+		&i.BlogID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.AddedBy,
+		&i.Position,
+		&i.Note,
 	)
 	return i, err
 }
+
+const setCollectionItemPosition = `-- name: SetCollectionItemPosition :exec
+update collection_blog set position = $1, updated_at = NOW() where collection_id = $2 and blog_id = $3
+`
+
+type SetCollectionItemPositionParams struct {
+	Position     int64
+	CollectionID uuid.UUID
+	BlogID       uuid.UUID
+}
+
+func (q *Queries) SetCollectionItemPosition(ctx context.Context, arg SetCollectionItemPositionParams) error {
+	_, err := q.db.ExecContext(ctx, setCollectionItemPosition, arg.Position, arg.CollectionID, arg.BlogID)
+	return err
+}
@@ -0,0 +1,291 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: collection_members.sql
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const acceptCollectionInvite = `-- name: AcceptCollectionInvite :one
+update collection_members set status = 'accepted', updated_at = NOW() where collection_id = $1 and user_id = $2 and status = 'invited'
+returning collection_id, user_id, role, status, invited_by, created_at, updated_at
+`
+
+type AcceptCollectionInviteParams struct {
+	CollectionID uuid.UUID
+	UserID       uuid.UUID
+}
+
+func (q *Queries) AcceptCollectionInvite(ctx context.Context, arg AcceptCollectionInviteParams) (CollectionMember, error) {
+	row := q.db.QueryRowContext(ctx, acceptCollectionInvite, arg.CollectionID, arg.UserID)
+	var i CollectionMember
+	err := row.Scan(
+		&i.CollectionID,
+		&i.UserID,
+		&i.Role,
+		&i.Status,
+		&i.InvitedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getCollectionInvites = `-- name: GetCollectionInvites :many
+select collections.id,
+    collections.name,
+    collections.user_id,
+    users.username as owner_name,
+    collection_members.role,
+    collection_members.created_at
+    from collection_members join collections on collections.id = collection_members.collection_id join users on users.id = collections.user_id
+    where collection_members.user_id = $1 and collection_members.status = 'invited'
+    order by collection_members.created_at desc
+`
+
+type GetCollectionInvitesRow struct {
+	ID        uuid.UUID
+	Name      string
+	UserID    uuid.UUID
+	OwnerName string
+	Role      string
+	CreatedAt time.Time
+}
+
+func (q *Queries) GetCollectionInvites(ctx context.Context, userID uuid.UUID) ([]GetCollectionInvitesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCollectionInvites, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCollectionInvitesRow
+	for rows.Next() {
+		var i GetCollectionInvitesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.UserID,
+			&i.OwnerName,
+			&i.Role,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCollectionMembers = `-- name: GetCollectionMembers :many
+select users.id,
+    users.username,
+    users.display_name,
+    users.avatar_url,
+    collection_members.role,
+    collection_members.status,
+    collection_members.created_at
+    from collection_members join users on users.id = collection_members.user_id
+    where collection_members.collection_id = $1
+    order by collection_members.created_at
+`
+
+type GetCollectionMembersRow struct {
+	ID          uuid.UUID
+	Username    string
+	DisplayName string
+	AvatarUrl   string
+	Role        string
+	Status      string
+	CreatedAt   time.Time
+}
+
+func (q *Queries) GetCollectionMembers(ctx context.Context, collectionID uuid.UUID) ([]GetCollectionMembersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCollectionMembers, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCollectionMembersRow
+	for rows.Next() {
+		var i GetCollectionMembersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.DisplayName,
+			&i.AvatarUrl,
+			&i.Role,
+			&i.Status,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCollectionRole = `-- name: GetCollectionRole :one
+select (case when collections.user_id = $1 then 'owner'
+    else coalesce((select collection_members.role from collection_members
+        where collection_members.collection_id = collections.id and collection_members.user_id = $1 and collection_members.status = 'accepted'), '') end)::text as role
+    from collections where collections.id = $2
+`
+
+type GetCollectionRoleParams struct {
+	UserID       uuid.UUID
+	CollectionID uuid.UUID
+}
+
+func (q *Queries) GetCollectionRole(ctx context.Context, arg GetCollectionRoleParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getCollectionRole, arg.UserID, arg.CollectionID)
+	var role string
+	err := row.Scan(&role)
+	return role, err
+}
+
+const getSharedCollectionsByUserId = `-- name: GetSharedCollectionsByUserId :many
+select collections.id, collections.name, collections.user_id, collections.created_at, collections.updated_at, collections.visibility, collections.share_token, collections.description, collections.cover_url
+    from collections join collection_members on collection_members.collection_id = collections.id
+    where collection_members.user_id = $1 and collection_members.status = 'accepted'
+    order by collections.updated_at desc
+`
+
+func (q *Queries) GetSharedCollectionsByUserId(ctx context.Context, userID uuid.UUID) ([]Collection, error) {
+	rows, err := q.db.QueryContext(ctx, getSharedCollectionsByUserId, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Collection
+	for rows.Next() {
+		var i Collection
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Visibility,
+			&i.ShareToken,
+			&i.Description,
+			&i.CoverUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const inviteCollectionMember = `-- name: InviteCollectionMember :one
+insert into collection_members (collection_id, user_id, role, status, invited_by, created_at, updated_at)
+values ($1, $2, $3, 'invited', $4, NOW(), NOW())
+on conflict (collection_id, user_id) do update set role = excluded.role, updated_at = NOW()
+returning collection_id, user_id, role, status, invited_by, created_at, updated_at
+`
+
+type InviteCollectionMemberParams struct {
+	CollectionID uuid.UUID
+	UserID       uuid.UUID
+	Role         string
+	InvitedBy    uuid.NullUUID
+}
+
+func (q *Queries) InviteCollectionMember(ctx context.Context, arg InviteCollectionMemberParams) (CollectionMember, error) {
+	row := q.db.QueryRowContext(ctx, inviteCollectionMember,
+		arg.CollectionID,
+		arg.UserID,
+		arg.Role,
+		arg.InvitedBy,
+	)
+	var i CollectionMember
+	err := row.Scan(
+		&i.CollectionID,
+		&i.UserID,
+		&i.Role,
+		&i.Status,
+		&i.InvitedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const removeCollectionMember = `-- name: RemoveCollectionMember :execrows
+delete from collection_members where collection_id = $1 and user_id = $2
+`
+
+type RemoveCollectionMemberParams struct {
+	CollectionID uuid.UUID
+	UserID       uuid.UUID
+}
+
+func (q *Queries) RemoveCollectionMember(ctx context.Context, arg RemoveCollectionMemberParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, removeCollectionMember, arg.CollectionID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const transferCollectionOwnership = `-- name: TransferCollectionOwnership :one
+with new_owner as (
+    delete from collection_members
+    where collection_members.collection_id = $2 and collection_members.user_id = $1 and collection_members.status = 'accepted'
+    returning collection_members.collection_id
+), previous_owner as (
+    insert into collection_members (collection_id, user_id, role, status, invited_by, created_at, updated_at)
+    select new_owner.collection_id, $3, 'editor', 'accepted', $1, NOW(), NOW() from new_owner
+    returning collection_members.collection_id
+)
+update collections set user_id = $1, updated_at = NOW()
+where collections.id in (select previous_owner.collection_id from previous_owner)
+returning id, name, user_id, created_at, updated_at, visibility, share_token, description, cover_url
+`
+
+type TransferCollectionOwnershipParams struct {
+	NewOwnerID   uuid.UUID
+	CollectionID uuid.UUID
+	OwnerID      uuid.UUID
+}
+
+func (q *Queries) TransferCollectionOwnership(ctx context.Context, arg TransferCollectionOwnershipParams) (Collection, error) {
+	row := q.db.QueryRowContext(ctx, transferCollectionOwnership, arg.NewOwnerID, arg.CollectionID, arg.OwnerID)
+	var i Collection
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Visibility,
+		&i.ShareToken,
+		&i.Description,
+		&i.CoverUrl,
+	)
+	return i, err
+}
@@ -7,30 +7,57 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+const countCollectionFollowers = `-- name: CountCollectionFollowers :one
+select count(*) from collection_follows where collection_id = $1
+`
+
+func (q *Queries) CountCollectionFollowers(ctx context.Context, collectionID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countCollectionFollowers, collectionID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createCollection = `-- name: CreateCollection :one
-insert into collections (id, name, user_id, created_at, updated_at)
+insert into collections (id, name, user_id, visibility, share_token, description, cover_url, created_at, updated_at)
 values (
     gen_random_uuid(),
     $1,
     $2,
+    $3,
+    $4,
+    $5,
+    $6,
     NOW(),
     NOW()
 )
-returning id, name, user_id, created_at, updated_at
+returning id, name, user_id, created_at, updated_at, visibility, share_token, description, cover_url
 `
 
 type CreateCollectionParams struct {
-	Name   string
-	UserID uuid.UUID
+	Name        string
+	UserID      uuid.UUID
+	Visibility  string
+	ShareToken  sql.NullString
+	Description string
+	CoverUrl    string
 }
 
 func (q *Queries) CreateCollection(ctx context.Context, arg CreateCollectionParams) (Collection, error) {
-	row := q.db.QueryRowContext(ctx, createCollection, arg.Name, arg.UserID)
+	row := q.db.QueryRowContext(ctx, createCollection,
+		arg.Name,
+		arg.UserID,
+		arg.Visibility,
+		arg.ShareToken,
+		arg.Description,
+		arg.CoverUrl,
+	)
 	var i Collection
 	err := row.Scan(
 		&i.ID,
@@ -38,13 +65,17 @@ func (q *Queries) CreateCollection(ctx context.Context, arg CreateCollectionPara
 		&i.UserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Visibility,
+		&i.ShareToken,
+		&i.Description,
+		&i.CoverUrl,
 	)
 	return i, err
 }
 
 const deleteCollection = `-- name: DeleteCollection :one
 delete from collections where id = $1
-returning id, name, user_id, created_at, updated_at
+returning id, name, user_id, created_at, updated_at, visibility, share_token, description, cover_url
 `
 
 func (q *Queries) DeleteCollection(ctx context.Context, id uuid.UUID) (Collection, error) {
@@ -56,22 +87,33 @@ func (q *Queries) DeleteCollection(ctx context.Context, id uuid.UUID) (Collectio
 		&i.UserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Visibility,
+		&i.ShareToken,
+		&i.Description,
+		&i.CoverUrl,
 	)
 	return i, err
 }
 
 const editCollection = `-- name: EditCollection :one
-update collections set name = $1, updated_at = NOW() where id = $2
-returning id, name, user_id, created_at, updated_at
+update collections set name = $1, description = $2, cover_url = $3, updated_at = NOW() where id = $4
+returning id, name, user_id, created_at, updated_at, visibility, share_token, description, cover_url
 `
 
 type EditCollectionParams struct {
-	Name string
-	ID   uuid.UUID
+	Name        string
+	Description string
+	CoverUrl    string
+	ID          uuid.UUID
 }
 
 func (q *Queries) EditCollection(ctx context.Context, arg EditCollectionParams) (Collection, error) {
-	row := q.db.QueryRowContext(ctx, editCollection, arg.Name, arg.ID)
+	row := q.db.QueryRowContext(ctx, editCollection,
+		arg.Name,
+		arg.Description,
+		arg.CoverUrl,
+		arg.ID,
+	)
 	var i Collection
 	err := row.Scan(
 		&i.ID,
@@ -79,14 +121,47 @@ func (q *Queries) EditCollection(ctx context.Context, arg EditCollectionParams)
 		&i.UserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Visibility,
+		&i.ShareToken,
+		&i.Description,
+		&i.CoverUrl,
 	)
 	return i, err
 }
 
+const followCollection = `-- name: FollowCollection :exec
+insert into collection_follows (user_id, collection_id, created_at)
+values ($1, $2, NOW())
+`
+
+type FollowCollectionParams struct {
+	UserID       uuid.UUID
+	CollectionID uuid.UUID
+}
+
+func (q *Queries) FollowCollection(ctx context.Context, arg FollowCollectionParams) error {
+	_, err := q.db.ExecContext(ctx, followCollection, arg.UserID, arg.CollectionID)
+	return err
+}
+
 const getAllBlogsByCollectionId = `-- name: GetAllBlogsByCollectionId :many
-select blogs.id, blogs.title, blogs.author_id, users.username as author_name, blogs.thumbnail_url, blogs.content, blogs.category, categories.category_name, blogs.created_at, blogs.updated_at from collections join collection_blog on collections.id = collection_blog.collection_id join blogs on collection_blog.blog_id = blogs.id join users on blogs.author_id = users.id join categories on blogs.category = categories.id where collections.id = $1
+select blogs.id, blogs.title, blogs.author_id, users.username as author_name, blogs.thumbnail_url, blogs.content, blogs.category, categories.category_name, blogs.created_at, blogs.updated_at, collection_blog.added_by, collection_blog.note, collection_blog.position from collections join collection_blog on collections.id = collection_blog.collection_id join blogs on collection_blog.blog_id = blogs.id join users on blogs.author_id = users.id join categories on blogs.category = categories.id where collections.id = $1 and blogs.is_published and blogs.moderation_status in ('visible', 'flagged')
+    and blogs.author_id not in (select user_blocks.blocked_id from user_blocks where user_blocks.blocker_id = $2)
+    and blogs.author_id not in (select user_blocks.blocker_id from user_blocks where user_blocks.blocked_id = $2)
+    and (blogs.author_id = $2
+        or blogs.author_id not in (select users.id from users where users.is_private)
+        or blogs.author_id in (select users_follow.following_id from users_follow where users_follow.follower_id = $2))
+    and (blogs.visibility in ('public', 'unlisted')
+        or blogs.author_id = $2
+        or (blogs.visibility = 'followers' and blogs.author_id in (select users_follow.following_id from users_follow where users_follow.follower_id = $2)))
+    order by collection_blog.position
 `
 
+type GetAllBlogsByCollectionIdParams struct {
+	ID       uuid.UUID
+	ViewerID uuid.UUID
+}
+
 type GetAllBlogsByCollectionIdRow struct {
 	ID           uuid.UUID
 	Title        string
@@ -98,10 +173,13 @@ type GetAllBlogsByCollectionIdRow struct {
 	CategoryName string
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
+	AddedBy      uuid.NullUUID
+	Note         string
+	Position     int64
 }
 
-func (q *Queries) GetAllBlogsByCollectionId(ctx context.Context, id uuid.UUID) ([]GetAllBlogsByCollectionIdRow, error) {
-	rows, err := q.db.QueryContext(ctx, getAllBlogsByCollectionId, id)
+func (q *Queries) GetAllBlogsByCollectionId(ctx context.Context, arg GetAllBlogsByCollectionIdParams) ([]GetAllBlogsByCollectionIdRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAllBlogsByCollectionId, arg.ID, arg.ViewerID)
 	if err != nil {
 		return nil, err
 	}
@@ -120,6 +198,9 @@ func (q *Queries) GetAllBlogsByCollectionId(ctx context.Context, id uuid.UUID) (
 			&i.CategoryName,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.AddedBy,
+			&i.Note,
+			&i.Position,
 		); err != nil {
 			return nil, err
 		}
@@ -135,7 +216,7 @@ func (q *Queries) GetAllBlogsByCollectionId(ctx context.Context, id uuid.UUID) (
 }
 
 const getAllCollectionsByUserId = `-- name: GetAllCollectionsByUserId :many
-select id, name, user_id, created_at, updated_at from collections where user_id = $1
+select id, name, user_id, created_at, updated_at, visibility, share_token, description, cover_url from collections where user_id = $1
 `
 
 func (q *Queries) GetAllCollectionsByUserId(ctx context.Context, userID uuid.UUID) ([]Collection, error) {
@@ -153,6 +234,10 @@ func (q *Queries) GetAllCollectionsByUserId(ctx context.Context, userID uuid.UUI
 			&i.UserID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Visibility,
+			&i.ShareToken,
+			&i.Description,
+			&i.CoverUrl,
 		); err != nil {
 			return nil, err
 		}
@@ -167,6 +252,27 @@ func (q *Queries) GetAllCollectionsByUserId(ctx context.Context, userID uuid.UUI
 	return items, nil
 }
 
+const getCollectionById = `-- name: GetCollectionById :one
+select id, name, user_id, created_at, updated_at, visibility, share_token, description, cover_url from collections where id = $1
+`
+
+func (q *Queries) GetCollectionById(ctx context.Context, id uuid.UUID) (Collection, error) {
+	row := q.db.QueryRowContext(ctx, getCollectionById, id)
+	var i Collection
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Visibility,
+		&i.ShareToken,
+		&i.Description,
+		&i.CoverUrl,
+	)
+	return i, err
+}
+
 const getCollectionNameById = `-- name: GetCollectionNameById :one
 select name from collections where id = $1
 `
@@ -188,3 +294,133 @@ func (q *Queries) GetOwnerId(ctx context.Context, id uuid.UUID) (uuid.UUID, erro
 	err := row.Scan(&user_id)
 	return user_id, err
 }
+
+const getPublicCollectionsByUserId = `-- name: GetPublicCollectionsByUserId :many
+select collections.id,
+    collections.name,
+    collections.user_id,
+    collections.visibility,
+    collections.description,
+    collections.cover_url,
+    collections.created_at,
+    collections.updated_at,
+    (select count(*) from collection_blog where collection_blog.collection_id = collections.id) as blogs_count,
+    (select count(*) from collection_follows where collection_follows.collection_id = collections.id) as followers_count
+    from collections where collections.user_id = $1 and collections.visibility = 'public'
+    order by collections.updated_at desc
+    limit $3 offset $2
+`
+
+type GetPublicCollectionsByUserIdParams struct {
+	UserID uuid.UUID
+	Offset int32
+	Limit  int32
+}
+
+type GetPublicCollectionsByUserIdRow struct {
+	ID             uuid.UUID
+	Name           string
+	UserID         uuid.UUID
+	Visibility     string
+	Description    string
+	CoverUrl       string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	BlogsCount     int64
+	FollowersCount int64
+}
+
+func (q *Queries) GetPublicCollectionsByUserId(ctx context.Context, arg GetPublicCollectionsByUserIdParams) ([]GetPublicCollectionsByUserIdRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPublicCollectionsByUserId, arg.UserID, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPublicCollectionsByUserIdRow
+	for rows.Next() {
+		var i GetPublicCollectionsByUserIdRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.UserID,
+			&i.Visibility,
+			&i.Description,
+			&i.CoverUrl,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.BlogsCount,
+			&i.FollowersCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const isCollectionFollowed = `-- name: IsCollectionFollowed :one
+select exists(select 1 from collection_follows where user_id = $1 and collection_id = $2)
+`
+
+type IsCollectionFollowedParams struct {
+	UserID       uuid.UUID
+	CollectionID uuid.UUID
+}
+
+func (q *Queries) IsCollectionFollowed(ctx context.Context, arg IsCollectionFollowedParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, isCollectionFollowed, arg.UserID, arg.CollectionID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const setCollectionVisibility = `-- name: SetCollectionVisibility :one
+update collections set visibility = $1, share_token = $2, updated_at = NOW() where id = $3
+returning id, name, user_id, created_at, updated_at, visibility, share_token, description, cover_url
+`
+
+type SetCollectionVisibilityParams struct {
+	Visibility string
+	ShareToken sql.NullString
+	ID         uuid.UUID
+}
+
+func (q *Queries) SetCollectionVisibility(ctx context.Context, arg SetCollectionVisibilityParams) (Collection, error) {
+	row := q.db.QueryRowContext(ctx, setCollectionVisibility, arg.Visibility, arg.ShareToken, arg.ID)
+	var i Collection
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Visibility,
+		&i.ShareToken,
+		&i.Description,
+		&i.CoverUrl,
+	)
+	return i, err
+}
+
+const unfollowCollection = `-- name: UnfollowCollection :execrows
+delete from collection_follows where user_id = $1 and collection_id = $2
+`
+
+type UnfollowCollectionParams struct {
+	UserID       uuid.UUID
+	CollectionID uuid.UUID
+}
+
+func (q *Queries) UnfollowCollection(ctx context.Context, arg UnfollowCollectionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, unfollowCollection, arg.UserID, arg.CollectionID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,61 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: comment_settings.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const getCommentSettings = `-- name: GetCommentSettings :one
+select blog_id, comment_policy, require_approval, created_at, updated_at from blog_comment_settings where blog_id = $1
+`
+
+func (q *Queries) GetCommentSettings(ctx context.Context, blogID uuid.UUID) (BlogCommentSetting, error) {
+	row := q.db.QueryRowContext(ctx, getCommentSettings, blogID)
+	var i BlogCommentSetting
+	err := row.Scan(
+		&i.BlogID,
+		&i.CommentPolicy,
+		&i.RequireApproval,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertCommentSettings = `-- name: UpsertCommentSettings :one
+insert into blog_comment_settings (blog_id, comment_policy, require_approval, created_at, updated_at)
+values (
+    $1,
+    $2,
+    $3,
+    NOW(),
+    NOW()
+)
+on conflict (blog_id) do update set comment_policy = excluded.comment_policy, require_approval = excluded.require_approval, updated_at = NOW()
+returning blog_id, comment_policy, require_approval, created_at, updated_at
+`
+
+type UpsertCommentSettingsParams struct {
+	BlogID          uuid.UUID
+	CommentPolicy   string
+	RequireApproval bool
+}
+
+func (q *Queries) UpsertCommentSettings(ctx context.Context, arg UpsertCommentSettingsParams) (BlogCommentSetting, error) {
+	row := q.db.QueryRowContext(ctx, upsertCommentSettings, arg.BlogID, arg.CommentPolicy, arg.RequireApproval)
+	var i BlogCommentSetting
+	err := row.Scan(
+		&i.BlogID,
+		&i.CommentPolicy,
+		&i.RequireApproval,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
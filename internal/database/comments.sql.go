@@ -13,26 +13,36 @@ import (
 )
 
 const createComment = `-- name: CreateComment :one
-insert into comments (id, description, blog_id, user_id, created_at, updated_at)
+insert into comments (id, description, blog_id, user_id, status, parent_id, created_at, updated_at)
 values (
     gen_random_uuid(),
     $1,
     $2,
     $3,
+    $4,
+    $5,
     NOW(),
     NOW()
 )
-returning id, description, blog_id, user_id, created_at, updated_at
+returning id, description, blog_id, user_id, created_at, updated_at, status, is_pinned, moderated_by, moderated_at, parent_id
 `
 
 type CreateCommentParams struct {
 	Description string
 	BlogID      uuid.UUID
 	UserID      uuid.UUID
+	Status      string
+	ParentID    uuid.NullUUID
 }
 
 func (q *Queries) CreateComment(ctx context.Context, arg CreateCommentParams) (Comment, error) {
-	row := q.db.QueryRowContext(ctx, createComment, arg.Description, arg.BlogID, arg.UserID)
+	row := q.db.QueryRowContext(ctx, createComment,
+		arg.Description,
+		arg.BlogID,
+		arg.UserID,
+		arg.Status,
+		arg.ParentID,
+	)
 	var i Comment
 	err := row.Scan(
 		&i.ID,
@@ -41,13 +51,18 @@ func (q *Queries) CreateComment(ctx context.Context, arg CreateCommentParams) (C
 		&i.UserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.IsPinned,
+		&i.ModeratedBy,
+		&i.ModeratedAt,
+		&i.ParentID,
 	)
 	return i, err
 }
 
 const deleteComment = `-- name: DeleteComment :one
 delete from comments where id = $1
-returning id, description, blog_id, user_id, created_at, updated_at
+returning id, description, blog_id, user_id, created_at, updated_at, status, is_pinned, moderated_by, moderated_at, parent_id
 `
 
 func (q *Queries) DeleteComment(ctx context.Context, id uuid.UUID) (Comment, error) {
@@ -60,13 +75,18 @@ func (q *Queries) DeleteComment(ctx context.Context, id uuid.UUID) (Comment, err
 		&i.UserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.IsPinned,
+		&i.ModeratedBy,
+		&i.ModeratedAt,
+		&i.ParentID,
 	)
 	return i, err
 }
 
 const editComment = `-- name: EditComment :one
 update comments set description = $1, updated_at = NOW() where id = $2 and blog_id = $3
-returning id, description, blog_id, user_id, created_at, updated_at
+returning id, description, blog_id, user_id, created_at, updated_at, status, is_pinned, moderated_by, moderated_at, parent_id
 `
 
 type EditCommentParams struct {
@@ -85,6 +105,11 @@ func (q *Queries) EditComment(ctx context.Context, arg EditCommentParams) (Comme
 		&i.UserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.IsPinned,
+		&i.ModeratedBy,
+		&i.ModeratedAt,
+		&i.ParentID,
 	)
 	return i, err
 }
@@ -94,25 +119,41 @@ select comments.id,
     comments.description, 
     comments.blog_id, 
     comments.user_id, 
+    comments.status,
+    comments.is_pinned,
+    comments.parent_id,
     comments.created_at, 
     comments.updated_at, 
-    count(comment_likes.comment_id) as likes_count 
-    from comments left join comment_likes on comments.id = comment_likes.comment_id where comments.blog_id = $1
+    count(comment_likes.comment_id) as likes_count,
+    exists(select 1 from comment_likes as my_likes where my_likes.comment_id = comments.id and my_likes.user_id = $2)::boolean as liked_by_me
+    from comments left join comment_likes on comments.id = comment_likes.comment_id where comments.blog_id = $1 and (comments.status = 'approved' or (comments.status = 'shadow_hidden' and comments.user_id = $2))
+        and comments.user_id not in (select user_blocks.blocked_id from user_blocks where user_blocks.blocker_id = $2)
+        and comments.user_id not in (select user_blocks.blocker_id from user_blocks where user_blocks.blocked_id = $2)
     group by comments.id
+    order by comments.is_pinned desc, comments.created_at
 `
 
+type GetAllCommentsByBlogIdParams struct {
+	BlogID uuid.UUID
+	UserID uuid.UUID
+}
+
 type GetAllCommentsByBlogIdRow struct {
 	ID          uuid.UUID
 	Description string
 	BlogID      uuid.UUID
 	UserID      uuid.UUID
+	Status      string
+	IsPinned    bool
+	ParentID    uuid.NullUUID
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	LikesCount  int64
+	LikedByMe   bool
 }
 
-func (q *Queries) GetAllCommentsByBlogId(ctx context.Context, blogID uuid.UUID) ([]GetAllCommentsByBlogIdRow, error) {
-	rows, err := q.db.QueryContext(ctx, getAllCommentsByBlogId, blogID)
+func (q *Queries) GetAllCommentsByBlogId(ctx context.Context, arg GetAllCommentsByBlogIdParams) ([]GetAllCommentsByBlogIdRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAllCommentsByBlogId, arg.BlogID, arg.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -125,9 +166,13 @@ func (q *Queries) GetAllCommentsByBlogId(ctx context.Context, blogID uuid.UUID)
 			&i.Description,
 			&i.BlogID,
 			&i.UserID,
+			&i.Status,
+			&i.IsPinned,
+			&i.ParentID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.LikesCount,
+			&i.LikedByMe,
 		); err != nil {
 			return nil, err
 		}
@@ -143,7 +188,7 @@ func (q *Queries) GetAllCommentsByBlogId(ctx context.Context, blogID uuid.UUID)
 }
 
 const getCommentById = `-- name: GetCommentById :one
-select id, description, blog_id, user_id, created_at, updated_at from comments where id = $1
+select id, description, blog_id, user_id, created_at, updated_at, status, is_pinned, moderated_by, moderated_at, parent_id from comments where id = $1
 `
 
 func (q *Queries) GetCommentById(ctx context.Context, id uuid.UUID) (Comment, error) {
@@ -156,10 +201,65 @@ func (q *Queries) GetCommentById(ctx context.Context, id uuid.UUID) (Comment, er
 		&i.UserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.IsPinned,
+		&i.ModeratedBy,
+		&i.ModeratedAt,
+		&i.ParentID,
 	)
 	return i, err
 }
 
+const getNoOfCommentLikes = `-- name: GetNoOfCommentLikes :one
+select count(*) from comment_likes where comment_id = $1
+`
+
+func (q *Queries) GetNoOfCommentLikes(ctx context.Context, commentID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getNoOfCommentLikes, commentID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getPendingCommentsByBlogId = `-- name: GetPendingCommentsByBlogId :many
+select id, description, blog_id, user_id, created_at, updated_at, status, is_pinned, moderated_by, moderated_at, parent_id from comments where blog_id = $1 and status = 'pending' order by created_at
+`
+
+func (q *Queries) GetPendingCommentsByBlogId(ctx context.Context, blogID uuid.UUID) ([]Comment, error) {
+	rows, err := q.db.QueryContext(ctx, getPendingCommentsByBlogId, blogID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Comment
+	for rows.Next() {
+		var i Comment
+		if err := rows.Scan(
+			&i.ID,
+			&i.Description,
+			&i.BlogID,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.IsPinned,
+			&i.ModeratedBy,
+			&i.ModeratedAt,
+			&i.ParentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const isCommentLiked = `-- name: IsCommentLiked :one
 select user_id, comment_id from comment_likes where user_id = $1 and comment_id = $2
 `
@@ -196,6 +296,64 @@ func (q *Queries) LikeComment(ctx context.Context, arg LikeCommentParams) error
 	return err
 }
 
+const setCommentPinned = `-- name: SetCommentPinned :one
+update comments set is_pinned = $1 where id = $2
+returning id, description, blog_id, user_id, created_at, updated_at, status, is_pinned, moderated_by, moderated_at, parent_id
+`
+
+type SetCommentPinnedParams struct {
+	IsPinned bool
+	ID       uuid.UUID
+}
+
+func (q *Queries) SetCommentPinned(ctx context.Context, arg SetCommentPinnedParams) (Comment, error) {
+	row := q.db.QueryRowContext(ctx, setCommentPinned, arg.IsPinned, arg.ID)
+	var i Comment
+	err := row.Scan(
+		&i.ID,
+		&i.Description,
+		&i.BlogID,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.IsPinned,
+		&i.ModeratedBy,
+		&i.ModeratedAt,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const setCommentStatus = `-- name: SetCommentStatus :one
+update comments set status = $1 where id = $2
+returning id, description, blog_id, user_id, created_at, updated_at, status, is_pinned, moderated_by, moderated_at, parent_id
+`
+
+type SetCommentStatusParams struct {
+	Status string
+	ID     uuid.UUID
+}
+
+func (q *Queries) SetCommentStatus(ctx context.Context, arg SetCommentStatusParams) (Comment, error) {
+	row := q.db.QueryRowContext(ctx, setCommentStatus, arg.Status, arg.ID)
+	var i Comment
+	err := row.Scan(
+		&i.ID,
+		&i.Description,
+		&i.BlogID,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.IsPinned,
+		&i.ModeratedBy,
+		&i.ModeratedAt,
+		&i.ParentID,
+	)
+	return i, err
+}
+
 const unlikeComment = `-- name: UnlikeComment :exec
 delete from comment_likes where user_id = $1 and comment_id = $2
 `
@@ -209,3 +367,42 @@ func (q *Queries) UnlikeComment(ctx context.Context, arg UnlikeCommentParams) er
 	_, err := q.db.ExecContext(ctx, unlikeComment, arg.UserID, arg.CommentID)
 	return err
 }
+
+const unpinCommentsByBlogId = `-- name: UnpinCommentsByBlogId :exec
+update comments set is_pinned = false where blog_id = $1 and is_pinned = true
+`
+
+func (q *Queries) UnpinCommentsByBlogId(ctx context.Context, blogID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, unpinCommentsByBlogId, blogID)
+	return err
+}
+
+const updateCommentStatus = `-- name: UpdateCommentStatus :one
+update comments set status = $1, moderated_by = $2, moderated_at = NOW() where id = $3
+returning id, description, blog_id, user_id, created_at, updated_at, status, is_pinned, moderated_by, moderated_at, parent_id
+`
+
+type UpdateCommentStatusParams struct {
+	Status      string
+	ModeratedBy uuid.NullUUID
+	ID          uuid.UUID
+}
+
+func (q *Queries) UpdateCommentStatus(ctx context.Context, arg UpdateCommentStatusParams) (Comment, error) {
+	row := q.db.QueryRowContext(ctx, updateCommentStatus, arg.Status, arg.ModeratedBy, arg.ID)
+	var i Comment
+	err := row.Scan(
+		&i.ID,
+		&i.Description,
+		&i.BlogID,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.IsPinned,
+		&i.ModeratedBy,
+		&i.ModeratedAt,
+		&i.ParentID,
+	)
+	return i, err
+}
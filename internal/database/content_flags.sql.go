@@ -0,0 +1,130 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: content_flags.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const createContentFlag = `-- name: CreateContentFlag :one
+insert into content_flags (id, entity_type, entity_id, user_id, action, reason, created_at, updated_at)
+values (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    NOW(),
+    NOW()
+)
+returning id, entity_type, entity_id, user_id, action, reason, reviewed_by, reviewed_at, created_at, updated_at
+`
+
+type CreateContentFlagParams struct {
+	EntityType string
+	EntityID   uuid.UUID
+	UserID     uuid.UUID
+	Action     string
+	Reason     string
+}
+
+func (q *Queries) CreateContentFlag(ctx context.Context, arg CreateContentFlagParams) (ContentFlag, error) {
+	row := q.db.QueryRowContext(ctx, createContentFlag,
+		arg.EntityType,
+		arg.EntityID,
+		arg.UserID,
+		arg.Action,
+		arg.Reason,
+	)
+	var i ContentFlag
+	err := row.Scan(
+		&i.ID,
+		&i.EntityType,
+		&i.EntityID,
+		&i.UserID,
+		&i.Action,
+		&i.Reason,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getUnreviewedContentFlags = `-- name: GetUnreviewedContentFlags :many
+select id, entity_type, entity_id, user_id, action, reason, reviewed_by, reviewed_at, created_at, updated_at from content_flags where reviewed_at is null order by created_at limit $1 offset $2
+`
+
+type GetUnreviewedContentFlagsParams struct {
+	Limit  int32
+	Offset int32
+}
+
+func (q *Queries) GetUnreviewedContentFlags(ctx context.Context, arg GetUnreviewedContentFlagsParams) ([]ContentFlag, error) {
+	rows, err := q.db.QueryContext(ctx, getUnreviewedContentFlags, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ContentFlag
+	for rows.Next() {
+		var i ContentFlag
+		if err := rows.Scan(
+			&i.ID,
+			&i.EntityType,
+			&i.EntityID,
+			&i.UserID,
+			&i.Action,
+			&i.Reason,
+			&i.ReviewedBy,
+			&i.ReviewedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markContentFlagReviewed = `-- name: MarkContentFlagReviewed :one
+update content_flags set reviewed_by = $1, reviewed_at = NOW(), updated_at = NOW() where id = $2
+returning id, entity_type, entity_id, user_id, action, reason, reviewed_by, reviewed_at, created_at, updated_at
+`
+
+type MarkContentFlagReviewedParams struct {
+	ReviewedBy uuid.NullUUID
+	ID         uuid.UUID
+}
+
+func (q *Queries) MarkContentFlagReviewed(ctx context.Context, arg MarkContentFlagReviewedParams) (ContentFlag, error) {
+	row := q.db.QueryRowContext(ctx, markContentFlagReviewed, arg.ReviewedBy, arg.ID)
+	var i ContentFlag
+	err := row.Scan(
+		&i.ID,
+		&i.EntityType,
+		&i.EntityID,
+		&i.UserID,
+		&i.Action,
+		&i.Reason,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,183 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: follow_requests.sql
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const approveAllFollowRequests = `-- name: ApproveAllFollowRequests :many
+with approved as (
+    delete from follow_requests where target_id = $1
+    returning requester_id, target_id
+), followed as (
+    insert into users_follow (follower_id, following_id, created_at, updated_at)
+    select requester_id, target_id, NOW(), NOW() from approved
+    on conflict do nothing
+)
+select requester_id from approved
+`
+
+func (q *Queries) ApproveAllFollowRequests(ctx context.Context, targetID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, approveAllFollowRequests, targetID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var requester_id uuid.UUID
+		if err := rows.Scan(&requester_id); err != nil {
+			return nil, err
+		}
+		items = append(items, requester_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const canViewAuthorContent = `-- name: CanViewAuthorContent :one
+select (users.id = $1
+    or not users.is_private
+    or exists(select 1 from users_follow where users_follow.follower_id = $1 and users_follow.following_id = users.id))::boolean as allowed
+    from users where users.id = $2
+`
+
+type CanViewAuthorContentParams struct {
+	ViewerID uuid.UUID
+	AuthorID uuid.UUID
+}
+
+func (q *Queries) CanViewAuthorContent(ctx context.Context, arg CanViewAuthorContentParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, canViewAuthorContent, arg.ViewerID, arg.AuthorID)
+	var allowed bool
+	err := row.Scan(&allowed)
+	return allowed, err
+}
+
+const createFollowRequest = `-- name: CreateFollowRequest :exec
+insert into follow_requests (requester_id, target_id, created_at)
+values ($1, $2, NOW())
+on conflict do nothing
+`
+
+type CreateFollowRequestParams struct {
+	RequesterID uuid.UUID
+	TargetID    uuid.UUID
+}
+
+func (q *Queries) CreateFollowRequest(ctx context.Context, arg CreateFollowRequestParams) error {
+	_, err := q.db.ExecContext(ctx, createFollowRequest, arg.RequesterID, arg.TargetID)
+	return err
+}
+
+const deleteFollowRequest = `-- name: DeleteFollowRequest :execrows
+delete from follow_requests where requester_id = $1 and target_id = $2
+`
+
+type DeleteFollowRequestParams struct {
+	RequesterID uuid.UUID
+	TargetID    uuid.UUID
+}
+
+func (q *Queries) DeleteFollowRequest(ctx context.Context, arg DeleteFollowRequestParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteFollowRequest, arg.RequesterID, arg.TargetID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteFollowRequestsBetween = `-- name: DeleteFollowRequestsBetween :exec
+delete from follow_requests
+    where (requester_id = $1 and target_id = $2)
+        or (requester_id = $2 and target_id = $1)
+`
+
+type DeleteFollowRequestsBetweenParams struct {
+	UserID      uuid.UUID
+	OtherUserID uuid.UUID
+}
+
+func (q *Queries) DeleteFollowRequestsBetween(ctx context.Context, arg DeleteFollowRequestsBetweenParams) error {
+	_, err := q.db.ExecContext(ctx, deleteFollowRequestsBetween, arg.UserID, arg.OtherUserID)
+	return err
+}
+
+const getIncomingFollowRequests = `-- name: GetIncomingFollowRequests :many
+select users.id, users.username, users.display_name, users.avatar_url, follow_requests.created_at
+    from follow_requests join users on users.id = follow_requests.requester_id
+    where follow_requests.target_id = $1
+    order by follow_requests.created_at desc
+    limit $2 offset $3
+`
+
+type GetIncomingFollowRequestsParams struct {
+	TargetID uuid.UUID
+	Limit    int32
+	Offset   int32
+}
+
+type GetIncomingFollowRequestsRow struct {
+	ID          uuid.UUID
+	Username    string
+	DisplayName string
+	AvatarUrl   string
+	CreatedAt   time.Time
+}
+
+func (q *Queries) GetIncomingFollowRequests(ctx context.Context, arg GetIncomingFollowRequestsParams) ([]GetIncomingFollowRequestsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getIncomingFollowRequests, arg.TargetID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetIncomingFollowRequestsRow
+	for rows.Next() {
+		var i GetIncomingFollowRequestsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.DisplayName,
+			&i.AvatarUrl,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const isFollowRequested = `-- name: IsFollowRequested :one
+select exists(select 1 from follow_requests where requester_id = $1 and target_id = $2)
+`
+
+type IsFollowRequestedParams struct {
+	RequesterID uuid.UUID
+	TargetID    uuid.UUID
+}
+
+func (q *Queries) IsFollowRequested(ctx context.Context, arg IsFollowRequestedParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, isFollowRequested, arg.RequesterID, arg.TargetID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
@@ -0,0 +1,165 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: mentions.sql
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const createMention = `-- name: CreateMention :exec
+insert into mentions (id, source_type, source_id, mentioned_user_id, mentioner_id, created_at)
+values (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    NOW()
+)
+on conflict (source_type, source_id, mentioned_user_id) do nothing
+`
+
+type CreateMentionParams struct {
+	SourceType      string
+	SourceID        uuid.UUID
+	MentionedUserID uuid.UUID
+	MentionerID     uuid.UUID
+}
+
+func (q *Queries) CreateMention(ctx context.Context, arg CreateMentionParams) error {
+	_, err := q.db.ExecContext(ctx, createMention,
+		arg.SourceType,
+		arg.SourceID,
+		arg.MentionedUserID,
+		arg.MentionerID,
+	)
+	return err
+}
+
+const deleteCommentMentionsByBlogId = `-- name: DeleteCommentMentionsByBlogId :exec
+delete from mentions where source_type = 'comment' and source_id in (select id from comments where blog_id = $1)
+`
+
+func (q *Queries) DeleteCommentMentionsByBlogId(ctx context.Context, blogID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteCommentMentionsByBlogId, blogID)
+	return err
+}
+
+const deleteMention = `-- name: DeleteMention :exec
+delete from mentions where source_type = $1 and source_id = $2 and mentioned_user_id = $3
+`
+
+type DeleteMentionParams struct {
+	SourceType      string
+	SourceID        uuid.UUID
+	MentionedUserID uuid.UUID
+}
+
+func (q *Queries) DeleteMention(ctx context.Context, arg DeleteMentionParams) error {
+	_, err := q.db.ExecContext(ctx, deleteMention, arg.SourceType, arg.SourceID, arg.MentionedUserID)
+	return err
+}
+
+const deleteMentionsBySource = `-- name: DeleteMentionsBySource :exec
+delete from mentions where source_type = $1 and source_id = $2
+`
+
+type DeleteMentionsBySourceParams struct {
+	SourceType string
+	SourceID   uuid.UUID
+}
+
+func (q *Queries) DeleteMentionsBySource(ctx context.Context, arg DeleteMentionsBySourceParams) error {
+	_, err := q.db.ExecContext(ctx, deleteMentionsBySource, arg.SourceType, arg.SourceID)
+	return err
+}
+
+const getMentionsBySource = `-- name: GetMentionsBySource :many
+select mentions.mentioned_user_id, users.username from mentions join users on mentions.mentioned_user_id = users.id where mentions.source_type = $1 and mentions.source_id = $2
+`
+
+type GetMentionsBySourceParams struct {
+	SourceType string
+	SourceID   uuid.UUID
+}
+
+type GetMentionsBySourceRow struct {
+	MentionedUserID uuid.UUID
+	Username        string
+}
+
+func (q *Queries) GetMentionsBySource(ctx context.Context, arg GetMentionsBySourceParams) ([]GetMentionsBySourceRow, error) {
+	rows, err := q.db.QueryContext(ctx, getMentionsBySource, arg.SourceType, arg.SourceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMentionsBySourceRow
+	for rows.Next() {
+		var i GetMentionsBySourceRow
+		if err := rows.Scan(&i.MentionedUserID, &i.Username); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMentionsOfUser = `-- name: GetMentionsOfUser :many
+select mentions.source_type, mentions.source_id, mentions.mentioner_id, users.username as mentioner_name, mentions.created_at from mentions join users on mentions.mentioner_id = users.id where mentions.mentioned_user_id = $1 order by mentions.created_at desc limit $2 offset $3
+`
+
+type GetMentionsOfUserParams struct {
+	MentionedUserID uuid.UUID
+	Limit           int32
+	Offset          int32
+}
+
+type GetMentionsOfUserRow struct {
+	SourceType    string
+	SourceID      uuid.UUID
+	MentionerID   uuid.UUID
+	MentionerName string
+	CreatedAt     time.Time
+}
+
+func (q *Queries) GetMentionsOfUser(ctx context.Context, arg GetMentionsOfUserParams) ([]GetMentionsOfUserRow, error) {
+	rows, err := q.db.QueryContext(ctx, getMentionsOfUser, arg.MentionedUserID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMentionsOfUserRow
+	for rows.Next() {
+		var i GetMentionsOfUserRow
+		if err := rows.Scan(
+			&i.SourceType,
+			&i.SourceID,
+			&i.MentionerID,
+			&i.MentionerName,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
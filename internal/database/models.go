@@ -12,36 +12,108 @@ import (
 )
 
 type Blog struct {
-	ID           uuid.UUID
-	Title        string
-	AuthorID     uuid.UUID
-	ThumbnailUrl string
-	Content      string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
-	Category     uuid.UUID
+	ID               uuid.UUID
+	Title            string
+	AuthorID         uuid.UUID
+	ThumbnailUrl     string
+	Content          string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	Category         uuid.UUID
+	ModerationStatus string
+	Visibility       string
+	IsPublished      bool
+	ReviewStatus     string
+	PublicationID    uuid.NullUUID
 }
 
-type Category struct {
-	ID           uuid.UUID
-	CategoryName string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+type BlogCommentSetting struct {
+	BlogID          uuid.UUID
+	CommentPolicy   string
+	RequireApproval bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
 
-type Collection struct {
-	ID        uuid.UUID
-	Name      string
+type BlogContributor struct {
+	BlogID    uuid.UUID
 	UserID    uuid.UUID
+	Role      string
+	Status    string
+	InvitedBy uuid.NullUUID
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
+type BlogRanking struct {
+	Ranking    string
+	BlogID     uuid.UUID
+	Score      float64
+	ComputedAt time.Time
+}
+
+type BlogReviewer struct {
+	BlogID     uuid.UUID
+	ReviewerID uuid.UUID
+	AssignedBy uuid.NullUUID
+	Decision   sql.NullString
+	DecidedAt  sql.NullTime
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type BlogView struct {
+	BlogID     uuid.UUID
+	ViewerKey  string
+	ViewWindow int64
+	ViewedAt   time.Time
+}
+
+type Category struct {
+	ID             uuid.UUID
+	CategoryName   string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	RequiresReview bool
+	PublicationID  uuid.NullUUID
+}
+
+type Collection struct {
+	ID          uuid.UUID
+	Name        string
+	UserID      uuid.UUID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Visibility  string
+	ShareToken  sql.NullString
+	Description string
+	CoverUrl    string
+}
+
 type CollectionBlog struct {
 	CollectionID uuid.UUID
 	BlogID       uuid.UUID
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
+	AddedBy      uuid.NullUUID
+	Position     int64
+	Note         string
+}
+
+type CollectionFollow struct {
+	UserID       uuid.UUID
+	CollectionID uuid.UUID
+	CreatedAt    time.Time
+}
+
+type CollectionMember struct {
+	CollectionID uuid.UUID
+	UserID       uuid.UUID
+	Role         string
+	Status       string
+	InvitedBy    uuid.NullUUID
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
 
 type Comment struct {
@@ -51,6 +123,11 @@ type Comment struct {
 	UserID      uuid.UUID
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	Status      string
+	IsPinned    bool
+	ModeratedBy uuid.NullUUID
+	ModeratedAt sql.NullTime
+	ParentID    uuid.NullUUID
 }
 
 type CommentLike struct {
@@ -60,6 +137,25 @@ type CommentLike struct {
 	UpdatedAt time.Time
 }
 
+type ContentFlag struct {
+	ID         uuid.UUID
+	EntityType string
+	EntityID   uuid.UUID
+	UserID     uuid.UUID
+	Action     string
+	Reason     string
+	ReviewedBy uuid.NullUUID
+	ReviewedAt sql.NullTime
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type FollowRequest struct {
+	RequesterID uuid.UUID
+	TargetID    uuid.UUID
+	CreatedAt   time.Time
+}
+
 type Like struct {
 	UserID    uuid.UUID
 	BlogID    uuid.UUID
@@ -67,6 +163,53 @@ type Like struct {
 	UpdatedAt time.Time
 }
 
+type Mention struct {
+	ID              uuid.UUID
+	SourceType      string
+	SourceID        uuid.UUID
+	MentionedUserID uuid.UUID
+	MentionerID     uuid.UUID
+	CreatedAt       time.Time
+}
+
+type Notification struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	ActorID    uuid.UUID
+	Type       string
+	EntityType string
+	EntityID   uuid.UUID
+	ReadAt     sql.NullTime
+	CreatedAt  time.Time
+}
+
+type NotificationPreference struct {
+	UserID    uuid.UUID
+	Type      string
+	Enabled   bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Publication struct {
+	ID          uuid.UUID
+	Slug        string
+	Name        string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type PublicationMember struct {
+	PublicationID uuid.UUID
+	UserID        uuid.UUID
+	Role          string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	Status        string
+	InvitedBy     uuid.NullUUID
+}
+
 type RefreshToken struct {
 	Token     string
 	UserID    uuid.UUID
@@ -76,15 +219,120 @@ type RefreshToken struct {
 	UpdatedAt time.Time
 }
 
-type User struct {
+type RelatedBlog struct {
+	BlogID     uuid.UUID
+	RelatedID  uuid.UUID
+	Score      float64
+	ComputedAt time.Time
+}
+
+type Report struct {
 	ID             uuid.UUID
-	Username       string
-	Email          string
-	HashedPassword string
+	ReporterID     uuid.UUID
+	EntityType     string
+	EntityID       uuid.UUID
+	ReasonCode     string
+	Details        string
+	Status         string
+	AssigneeID     uuid.NullUUID
+	ActionTaken    string
+	ResolutionNote string
+	ResolvedBy     uuid.NullUUID
+	ResolvedAt     sql.NullTime
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 }
 
+type ReviewComment struct {
+	ID          uuid.UUID
+	BlogID      uuid.UUID
+	UserID      uuid.UUID
+	Body        string
+	AnchorStart int32
+	AnchorEnd   int32
+	QuotedText  string
+	Resolved    bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type Series struct {
+	ID          uuid.UUID
+	AuthorID    uuid.UUID
+	Title       string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type SeriesBlog struct {
+	SeriesID  uuid.UUID
+	BlogID    uuid.UUID
+	Position  int32
+	CreatedAt time.Time
+}
+
+type User struct {
+	ID                uuid.UUID
+	Username          string
+	Email             string
+	HashedPassword    string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	Role              string
+	SuspendedUntil    sql.NullTime
+	DisplayName       string
+	Bio               string
+	AvatarUrl         string
+	Website           string
+	Location          string
+	UsernameChangedAt sql.NullTime
+	IsPrivate         bool
+}
+
+type UserBlock struct {
+	BlockerID uuid.UUID
+	BlockedID uuid.UUID
+	CreatedAt time.Time
+}
+
+type UserMute struct {
+	MuterID   uuid.UUID
+	MutedID   uuid.UUID
+	CreatedAt time.Time
+}
+
+type UserRecommendation struct {
+	UserID        uuid.UUID
+	RecommendedID uuid.UUID
+	Score         float64
+	Reason        string
+	ComputedAt    time.Time
+}
+
+type UserSocialLink struct {
+	UserID    uuid.UUID
+	Platform  string
+	Url       string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type UserWarning struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	ModeratorID uuid.NullUUID
+	ReportID    uuid.NullUUID
+	Message     string
+	CreatedAt   time.Time
+}
+
+type UsernameHistory struct {
+	OldUsername string
+	UserID      uuid.UUID
+	ChangedAt   time.Time
+}
+
 type UsersFollow struct {
 	FollowerID  uuid.UUID
 	FollowingID uuid.UUID
@@ -0,0 +1,238 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: notifications.sql
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const countUnreadNotificationGroups = `-- name: CountUnreadNotificationGroups :one
+select count(*) from (
+    select notifications.type from notifications where notifications.user_id = $1 and notifications.read_at is null
+    group by notifications.type, notifications.entity_type, notifications.entity_id
+) as unread_groups
+`
+
+func (q *Queries) CountUnreadNotificationGroups(ctx context.Context, userID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUnreadNotificationGroups, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createNotification = `-- name: CreateNotification :exec
+insert into notifications (id, user_id, actor_id, type, entity_type, entity_id, created_at)
+values (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    NOW()
+)
+`
+
+type CreateNotificationParams struct {
+	UserID     uuid.UUID
+	ActorID    uuid.UUID
+	Type       string
+	EntityType string
+	EntityID   uuid.UUID
+}
+
+func (q *Queries) CreateNotification(ctx context.Context, arg CreateNotificationParams) error {
+	_, err := q.db.ExecContext(ctx, createNotification,
+		arg.UserID,
+		arg.ActorID,
+		arg.Type,
+		arg.EntityType,
+		arg.EntityID,
+	)
+	return err
+}
+
+const deleteNotification = `-- name: DeleteNotification :exec
+delete from notifications where user_id = $1 and actor_id = $2 and type = $3 and entity_id = $4
+`
+
+type DeleteNotificationParams struct {
+	UserID   uuid.UUID
+	ActorID  uuid.UUID
+	Type     string
+	EntityID uuid.UUID
+}
+
+func (q *Queries) DeleteNotification(ctx context.Context, arg DeleteNotificationParams) error {
+	_, err := q.db.ExecContext(ctx, deleteNotification,
+		arg.UserID,
+		arg.ActorID,
+		arg.Type,
+		arg.EntityID,
+	)
+	return err
+}
+
+const getNotificationGroups = `-- name: GetNotificationGroups :many
+select notifications.type,
+    notifications.entity_type,
+    notifications.entity_id,
+    count(distinct notifications.actor_id) as actors_count,
+    max(notifications.created_at)::timestamp as latest_at,
+    bool_or(notifications.read_at is null) as unread,
+    (array_agg(users.username order by notifications.created_at desc))[1]::text as latest_actor_name
+    from notifications join users on notifications.actor_id = users.id
+    where notifications.user_id = $1
+    group by notifications.type, notifications.entity_type, notifications.entity_id
+    order by latest_at desc
+    limit $2 offset $3
+`
+
+type GetNotificationGroupsParams struct {
+	UserID uuid.UUID
+	Limit  int32
+	Offset int32
+}
+
+type GetNotificationGroupsRow struct {
+	Type            string
+	EntityType      string
+	EntityID        uuid.UUID
+	ActorsCount     int64
+	LatestAt        time.Time
+	Unread          bool
+	LatestActorName string
+}
+
+func (q *Queries) GetNotificationGroups(ctx context.Context, arg GetNotificationGroupsParams) ([]GetNotificationGroupsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getNotificationGroups, arg.UserID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetNotificationGroupsRow
+	for rows.Next() {
+		var i GetNotificationGroupsRow
+		if err := rows.Scan(
+			&i.Type,
+			&i.EntityType,
+			&i.EntityID,
+			&i.ActorsCount,
+			&i.LatestAt,
+			&i.Unread,
+			&i.LatestActorName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNotificationPreference = `-- name: GetNotificationPreference :one
+select enabled from notification_preferences where user_id = $1 and type = $2
+`
+
+type GetNotificationPreferenceParams struct {
+	UserID uuid.UUID
+	Type   string
+}
+
+func (q *Queries) GetNotificationPreference(ctx context.Context, arg GetNotificationPreferenceParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, getNotificationPreference, arg.UserID, arg.Type)
+	var enabled bool
+	err := row.Scan(&enabled)
+	return enabled, err
+}
+
+const getNotificationPreferences = `-- name: GetNotificationPreferences :many
+select type, enabled from notification_preferences where user_id = $1
+`
+
+type GetNotificationPreferencesRow struct {
+	Type    string
+	Enabled bool
+}
+
+func (q *Queries) GetNotificationPreferences(ctx context.Context, userID uuid.UUID) ([]GetNotificationPreferencesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getNotificationPreferences, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetNotificationPreferencesRow
+	for rows.Next() {
+		var i GetNotificationPreferencesRow
+		if err := rows.Scan(&i.Type, &i.Enabled); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markAllNotificationsRead = `-- name: MarkAllNotificationsRead :exec
+update notifications set read_at = NOW() where user_id = $1 and read_at is null
+`
+
+func (q *Queries) MarkAllNotificationsRead(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, markAllNotificationsRead, userID)
+	return err
+}
+
+const markNotificationGroupRead = `-- name: MarkNotificationGroupRead :exec
+update notifications set read_at = NOW() where user_id = $1 and type = $2 and entity_type = $3 and entity_id = $4 and read_at is null
+`
+
+type MarkNotificationGroupReadParams struct {
+	UserID     uuid.UUID
+	Type       string
+	EntityType string
+	EntityID   uuid.UUID
+}
+
+func (q *Queries) MarkNotificationGroupRead(ctx context.Context, arg MarkNotificationGroupReadParams) error {
+	_, err := q.db.ExecContext(ctx, markNotificationGroupRead,
+		arg.UserID,
+		arg.Type,
+		arg.EntityType,
+		arg.EntityID,
+	)
+	return err
+}
+
+const upsertNotificationPreferences = `-- name: UpsertNotificationPreferences :exec
+insert into notification_preferences (user_id, type, enabled, created_at, updated_at)
+select $1, unnest($2::text[]), unnest($3::boolean[]), NOW(), NOW()
+on conflict (user_id, type) do update set enabled = excluded.enabled, updated_at = NOW()
+`
+
+type UpsertNotificationPreferencesParams struct {
+	UserID  uuid.UUID
+	Types   []string
+	Enabled []bool
+}
+
+func (q *Queries) UpsertNotificationPreferences(ctx context.Context, arg UpsertNotificationPreferencesParams) error {
+	_, err := q.db.ExecContext(ctx, upsertNotificationPreferences, arg.UserID, pq.Array(arg.Types), pq.Array(arg.Enabled))
+	return err
+}
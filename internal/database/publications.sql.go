@@ -0,0 +1,477 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: publications.sql
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const acceptPublicationInvite = `-- name: AcceptPublicationInvite :one
+update publication_members set status = 'accepted', updated_at = NOW() where publication_id = $1 and user_id = $2 and status = 'invited'
+returning publication_id, user_id, role, created_at, updated_at, status, invited_by
+`
+
+type AcceptPublicationInviteParams struct {
+	PublicationID uuid.UUID
+	UserID        uuid.UUID
+}
+
+func (q *Queries) AcceptPublicationInvite(ctx context.Context, arg AcceptPublicationInviteParams) (PublicationMember, error) {
+	row := q.db.QueryRowContext(ctx, acceptPublicationInvite, arg.PublicationID, arg.UserID)
+	var i PublicationMember
+	err := row.Scan(
+		&i.PublicationID,
+		&i.UserID,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.InvitedBy,
+	)
+	return i, err
+}
+
+const createPublication = `-- name: CreatePublication :one
+with new_publication as (
+    insert into publications (id, slug, name, description, created_at, updated_at)
+    values (
+        gen_random_uuid(),
+        $1,
+        $2,
+        $3,
+        NOW(),
+        NOW()
+    )
+    returning id, slug, name, description, created_at, updated_at
+), owner as (
+    insert into publication_members (publication_id, user_id, role, created_at, updated_at)
+    select new_publication.id, $4, 'owner', NOW(), NOW() from new_publication
+)
+select new_publication.id,
+    new_publication.slug,
+    new_publication.name,
+    new_publication.description,
+    new_publication.created_at,
+    new_publication.updated_at
+    from new_publication
+`
+
+type CreatePublicationParams struct {
+	Slug        string
+	Name        string
+	Description string
+	OwnerID     uuid.UUID
+}
+
+type CreatePublicationRow struct {
+	ID          uuid.UUID
+	Slug        string
+	Name        string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (q *Queries) CreatePublication(ctx context.Context, arg CreatePublicationParams) (CreatePublicationRow, error) {
+	row := q.db.QueryRowContext(ctx, createPublication,
+		arg.Slug,
+		arg.Name,
+		arg.Description,
+		arg.OwnerID,
+	)
+	var i CreatePublicationRow
+	err := row.Scan(
+		&i.ID,
+		&i.Slug,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const editPublication = `-- name: EditPublication :one
+update publications set name = $1, description = $2, updated_at = NOW() where id = $3
+returning id, slug, name, description, created_at, updated_at
+`
+
+type EditPublicationParams struct {
+	Name        string
+	Description string
+	ID          uuid.UUID
+}
+
+func (q *Queries) EditPublication(ctx context.Context, arg EditPublicationParams) (Publication, error) {
+	row := q.db.QueryRowContext(ctx, editPublication, arg.Name, arg.Description, arg.ID)
+	var i Publication
+	err := row.Scan(
+		&i.ID,
+		&i.Slug,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getPublicationBlogs = `-- name: GetPublicationBlogs :many
+select blogs.id,
+    blogs.title,
+    blogs.author_id,
+    users.username as author_name,
+    blogs.thumbnail_url,
+    categories.category_name,
+    blogs.created_at,
+    (select count(*) from likes where likes.blog_id = blogs.id) as likes_count
+    from blogs join users on users.id = blogs.author_id join categories on categories.id = blogs.category
+    where blogs.publication_id = $1
+    and ($2::uuid is null or blogs.category = $2::uuid)
+    and blogs.is_published and blogs.moderation_status in ('visible', 'flagged')
+    and blogs.author_id not in (select user_blocks.blocked_id from user_blocks where user_blocks.blocker_id = $3)
+    and blogs.author_id not in (select user_blocks.blocker_id from user_blocks where user_blocks.blocked_id = $3)
+    and (blogs.author_id = $3
+        or blogs.author_id not in (select users.id from users where users.is_private)
+        or blogs.author_id in (select users_follow.following_id from users_follow where users_follow.follower_id = $3))
+    and (blogs.visibility = 'public'
+        or (blogs.author_id = $3 and blogs.visibility <> 'unlisted')
+        or (blogs.visibility = 'followers' and blogs.author_id in (select users_follow.following_id from users_follow where users_follow.follower_id = $3)))
+    order by blogs.created_at desc
+    limit $5 offset $4
+`
+
+type GetPublicationBlogsParams struct {
+	PublicationID uuid.NullUUID
+	Category      uuid.NullUUID
+	ViewerID      uuid.UUID
+	Offset        int32
+	Limit         int32
+}
+
+type GetPublicationBlogsRow struct {
+	ID           uuid.UUID
+	Title        string
+	AuthorID     uuid.UUID
+	AuthorName   string
+	ThumbnailUrl string
+	CategoryName string
+	CreatedAt    time.Time
+	LikesCount   int64
+}
+
+func (q *Queries) GetPublicationBlogs(ctx context.Context, arg GetPublicationBlogsParams) ([]GetPublicationBlogsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPublicationBlogs,
+		arg.PublicationID,
+		arg.Category,
+		arg.ViewerID,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPublicationBlogsRow
+	for rows.Next() {
+		var i GetPublicationBlogsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.AuthorID,
+			&i.AuthorName,
+			&i.ThumbnailUrl,
+			&i.CategoryName,
+			&i.CreatedAt,
+			&i.LikesCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPublicationBySlug = `-- name: GetPublicationBySlug :one
+select id, slug, name, description, created_at, updated_at from publications where slug = $1
+`
+
+func (q *Queries) GetPublicationBySlug(ctx context.Context, slug string) (Publication, error) {
+	row := q.db.QueryRowContext(ctx, getPublicationBySlug, slug)
+	var i Publication
+	err := row.Scan(
+		&i.ID,
+		&i.Slug,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getPublicationCategories = `-- name: GetPublicationCategories :many
+select id, category_name, created_at, updated_at, requires_review, publication_id from categories where publication_id = $1 order by category_name
+`
+
+func (q *Queries) GetPublicationCategories(ctx context.Context, publicationID uuid.NullUUID) ([]Category, error) {
+	rows, err := q.db.QueryContext(ctx, getPublicationCategories, publicationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Category
+	for rows.Next() {
+		var i Category
+		if err := rows.Scan(
+			&i.ID,
+			&i.CategoryName,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.RequiresReview,
+			&i.PublicationID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPublicationInvites = `-- name: GetPublicationInvites :many
+select publications.id,
+    publications.slug,
+    publications.name,
+    publication_members.role,
+    publication_members.invited_by,
+    publication_members.created_at
+    from publication_members join publications on publications.id = publication_members.publication_id
+    where publication_members.user_id = $1 and publication_members.status = 'invited'
+    order by publication_members.created_at desc
+`
+
+type GetPublicationInvitesRow struct {
+	ID        uuid.UUID
+	Slug      string
+	Name      string
+	Role      string
+	InvitedBy uuid.NullUUID
+	CreatedAt time.Time
+}
+
+func (q *Queries) GetPublicationInvites(ctx context.Context, userID uuid.UUID) ([]GetPublicationInvitesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPublicationInvites, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPublicationInvitesRow
+	for rows.Next() {
+		var i GetPublicationInvitesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Slug,
+			&i.Name,
+			&i.Role,
+			&i.InvitedBy,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPublicationMembers = `-- name: GetPublicationMembers :many
+select users.id,
+    users.username,
+    users.display_name,
+    users.avatar_url,
+    publication_members.role,
+    publication_members.status,
+    publication_members.created_at
+    from publication_members join users on users.id = publication_members.user_id
+    where publication_members.publication_id = $1
+    order by publication_members.created_at
+`
+
+type GetPublicationMembersRow struct {
+	ID          uuid.UUID
+	Username    string
+	DisplayName string
+	AvatarUrl   string
+	Role        string
+	Status      string
+	CreatedAt   time.Time
+}
+
+func (q *Queries) GetPublicationMembers(ctx context.Context, publicationID uuid.UUID) ([]GetPublicationMembersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPublicationMembers, publicationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPublicationMembersRow
+	for rows.Next() {
+		var i GetPublicationMembersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.DisplayName,
+			&i.AvatarUrl,
+			&i.Role,
+			&i.Status,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPublicationRole = `-- name: GetPublicationRole :one
+select role from publication_members where publication_id = $1 and user_id = $2 and status = 'accepted'
+`
+
+type GetPublicationRoleParams struct {
+	PublicationID uuid.UUID
+	UserID        uuid.UUID
+}
+
+func (q *Queries) GetPublicationRole(ctx context.Context, arg GetPublicationRoleParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getPublicationRole, arg.PublicationID, arg.UserID)
+	var role string
+	err := row.Scan(&role)
+	return role, err
+}
+
+const getPublicationsByUserId = `-- name: GetPublicationsByUserId :many
+select publications.id, publications.slug, publications.name, publications.description, publications.created_at, publications.updated_at, publication_members.role
+    from publication_members join publications on publications.id = publication_members.publication_id
+    where publication_members.user_id = $1 and publication_members.status = 'accepted'
+    order by publications.name
+`
+
+type GetPublicationsByUserIdRow struct {
+	ID          uuid.UUID
+	Slug        string
+	Name        string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Role        string
+}
+
+func (q *Queries) GetPublicationsByUserId(ctx context.Context, userID uuid.UUID) ([]GetPublicationsByUserIdRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPublicationsByUserId, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPublicationsByUserIdRow
+	for rows.Next() {
+		var i GetPublicationsByUserIdRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Slug,
+			&i.Name,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Role,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const invitePublicationMember = `-- name: InvitePublicationMember :one
+insert into publication_members (publication_id, user_id, role, status, invited_by, created_at, updated_at)
+values ($1, $2, $3, 'invited', $4, NOW(), NOW())
+on conflict (publication_id, user_id) do update set role = excluded.role, updated_at = NOW()
+returning publication_id, user_id, role, created_at, updated_at, status, invited_by
+`
+
+type InvitePublicationMemberParams struct {
+	PublicationID uuid.UUID
+	UserID        uuid.UUID
+	Role          string
+	InvitedBy     uuid.NullUUID
+}
+
+func (q *Queries) InvitePublicationMember(ctx context.Context, arg InvitePublicationMemberParams) (PublicationMember, error) {
+	row := q.db.QueryRowContext(ctx, invitePublicationMember,
+		arg.PublicationID,
+		arg.UserID,
+		arg.Role,
+		arg.InvitedBy,
+	)
+	var i PublicationMember
+	err := row.Scan(
+		&i.PublicationID,
+		&i.UserID,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.InvitedBy,
+	)
+	return i, err
+}
+
+const removePublicationMember = `-- name: RemovePublicationMember :execrows
+delete from publication_members where publication_id = $1 and user_id = $2 and role <> 'owner'
+`
+
+type RemovePublicationMemberParams struct {
+	PublicationID uuid.UUID
+	UserID        uuid.UUID
+}
+
+func (q *Queries) RemovePublicationMember(ctx context.Context, arg RemovePublicationMemberParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, removePublicationMember, arg.PublicationID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,166 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: rankings.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const computePopularRanking = `-- name: ComputePopularRanking :exec
+insert into blog_rankings (ranking, blog_id, score, computed_at)
+select $1::text, interactions.blog_id, sum(interactions.weight)::float8, NOW()
+    from (
+        select likes.blog_id, $2::float8 as weight from likes where likes.created_at > NOW() - make_interval(hours => $3::int)
+        union all
+        select comments.blog_id, $4::float8 from comments where comments.status = 'approved' and comments.created_at > NOW() - make_interval(hours => $3::int)
+        union all
+        select blog_views.blog_id, $5::float8 from blog_views where blog_views.viewed_at > NOW() - make_interval(hours => $3::int)
+    ) as interactions
+    group by interactions.blog_id
+`
+
+type ComputePopularRankingParams struct {
+	Ranking       string
+	LikeWeight    float64
+	WindowHours   int32
+	CommentWeight float64
+	ViewWeight    float64
+}
+
+func (q *Queries) ComputePopularRanking(ctx context.Context, arg ComputePopularRankingParams) error {
+	_, err := q.db.ExecContext(ctx, computePopularRanking,
+		arg.Ranking,
+		arg.LikeWeight,
+		arg.WindowHours,
+		arg.CommentWeight,
+		arg.ViewWeight,
+	)
+	return err
+}
+
+const computeTrendingRanking = `-- name: ComputeTrendingRanking :exec
+insert into blog_rankings (ranking, blog_id, score, computed_at)
+select $1::text,
+    interactions.blog_id,
+    sum(interactions.weight / power(extract(epoch from NOW() - interactions.created_at) / 3600 + 2, $2::float8))::float8,
+    NOW()
+    from (
+        select likes.blog_id, likes.created_at, $3::float8 as weight from likes where likes.created_at > NOW() - make_interval(hours => $4::int)
+        union all
+        select comments.blog_id, comments.created_at, $5::float8 from comments where comments.status = 'approved' and comments.created_at > NOW() - make_interval(hours => $4::int)
+        union all
+        select blog_views.blog_id, blog_views.viewed_at, $6::float8 from blog_views where blog_views.viewed_at > NOW() - make_interval(hours => $4::int)
+    ) as interactions
+    group by interactions.blog_id
+`
+
+type ComputeTrendingRankingParams struct {
+	Ranking       string
+	Gravity       float64
+	LikeWeight    float64
+	WindowHours   int32
+	CommentWeight float64
+	ViewWeight    float64
+}
+
+func (q *Queries) ComputeTrendingRanking(ctx context.Context, arg ComputeTrendingRankingParams) error {
+	_, err := q.db.ExecContext(ctx, computeTrendingRanking,
+		arg.Ranking,
+		arg.Gravity,
+		arg.LikeWeight,
+		arg.WindowHours,
+		arg.CommentWeight,
+		arg.ViewWeight,
+	)
+	return err
+}
+
+const deleteBlogRankings = `-- name: DeleteBlogRankings :exec
+delete from blog_rankings
+`
+
+func (q *Queries) DeleteBlogRankings(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, deleteBlogRankings)
+	return err
+}
+
+const getRankedBlogs = `-- name: GetRankedBlogs :many
+select blogs.id,
+    blogs.title,
+    blogs.author_id,
+    users.username as author_name,
+    blogs.thumbnail_url,
+    categories.category_name,
+    blog_rankings.score
+    from blog_rankings join blogs on blogs.id = blog_rankings.blog_id join users on users.id = blogs.author_id join categories on categories.id = blogs.category
+    where blog_rankings.ranking = $1
+        and ($2::uuid is null or blogs.category = $2::uuid)
+        and blogs.visibility = 'public' and blogs.is_published and blogs.moderation_status in ('visible', 'flagged')
+        and blogs.author_id not in (select user_blocks.blocked_id from user_blocks where user_blocks.blocker_id = $3)
+        and blogs.author_id not in (select user_blocks.blocker_id from user_blocks where user_blocks.blocked_id = $3)
+        and (blogs.author_id = $3
+            or not users.is_private
+            or blogs.author_id in (select users_follow.following_id from users_follow where users_follow.follower_id = $3))
+    order by blog_rankings.score desc
+    limit $5 offset $4
+`
+
+type GetRankedBlogsParams struct {
+	Ranking  string
+	Category uuid.NullUUID
+	ViewerID uuid.UUID
+	Offset   int32
+	Limit    int32
+}
+
+type GetRankedBlogsRow struct {
+	ID           uuid.UUID
+	Title        string
+	AuthorID     uuid.UUID
+	AuthorName   string
+	ThumbnailUrl string
+	CategoryName string
+	Score        float64
+}
+
+func (q *Queries) GetRankedBlogs(ctx context.Context, arg GetRankedBlogsParams) ([]GetRankedBlogsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getRankedBlogs,
+		arg.Ranking,
+		arg.Category,
+		arg.ViewerID,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetRankedBlogsRow
+	for rows.Next() {
+		var i GetRankedBlogsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.AuthorID,
+			&i.AuthorName,
+			&i.ThumbnailUrl,
+			&i.CategoryName,
+			&i.Score,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
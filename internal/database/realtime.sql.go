@@ -0,0 +1,24 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: realtime.sql
+
+package database
+
+import (
+	"context"
+)
+
+const publishRealtimeEvent = `-- name: PublishRealtimeEvent :exec
+select pg_notify($1::text, $2::text)
+`
+
+type PublishRealtimeEventParams struct {
+	Channel string
+	Payload string
+}
+
+func (q *Queries) PublishRealtimeEvent(ctx context.Context, arg PublishRealtimeEventParams) error {
+	_, err := q.db.ExecContext(ctx, publishRealtimeEvent, arg.Channel, arg.Payload)
+	return err
+}
@@ -0,0 +1,241 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: recommendations.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const computeRelatedBlogs = `-- name: ComputeRelatedBlogs :exec
+with recent_likes as (
+    select ranked_likes.user_id, ranked_likes.blog_id from (
+        select likes.user_id, likes.blog_id, row_number() over (partition by likes.user_id order by likes.created_at desc) as position
+            from likes
+    ) as ranked_likes
+    where ranked_likes.position <= $3::bigint
+)
+insert into related_blogs (blog_id, related_id, score, computed_at)
+select ranked.blog_id, ranked.related_id, ranked.score, NOW() from (
+    select candidates.blog_id,
+        candidates.related_id,
+        sum(candidates.score)::float8 as score,
+        row_number() over (partition by candidates.blog_id order by sum(candidates.score) desc) as rank
+    from (
+        select blogs.id as blog_id, same_category.id as related_id, 0.2::float8 as score
+            from blogs join (
+                select recent.id, recent.category from (
+                    select blogs.id, blogs.category, row_number() over (partition by blogs.category order by blogs.created_at desc) as position
+                        from blogs where blogs.visibility = 'public' and blogs.is_published and blogs.moderation_status in ('visible', 'flagged')
+                ) as recent
+                where recent.position <= $1::bigint
+            ) as same_category on same_category.category = blogs.category and same_category.id <> blogs.id
+        union all
+        select user_likes.blog_id, co_likes.blog_id, count(*)::float8 / sqrt(blog_likes.likes_count * related_likes.likes_count)
+            from recent_likes as user_likes join recent_likes as co_likes on co_likes.user_id = user_likes.user_id and co_likes.blog_id <> user_likes.blog_id
+            join (select likes.blog_id, count(*)::float8 as likes_count from likes group by likes.blog_id) as blog_likes on blog_likes.blog_id = user_likes.blog_id
+            join (select likes.blog_id, count(*)::float8 as likes_count from likes group by likes.blog_id) as related_likes on related_likes.blog_id = co_likes.blog_id
+            group by user_likes.blog_id, co_likes.blog_id, blog_likes.likes_count, related_likes.likes_count
+    ) as candidates
+    join blogs as related on related.id = candidates.related_id
+    where related.visibility = 'public' and related.is_published and related.moderation_status in ('visible', 'flagged')
+    group by candidates.blog_id, candidates.related_id
+) as ranked
+where ranked.rank <= $2::bigint
+`
+
+type ComputeRelatedBlogsParams struct {
+	CandidatesPerCategory int64
+	MaxPerBlog            int64
+	LikesPerUser          int64
+}
+
+func (q *Queries) ComputeRelatedBlogs(ctx context.Context, arg ComputeRelatedBlogsParams) error {
+	_, err := q.db.ExecContext(ctx, computeRelatedBlogs, arg.CandidatesPerCategory, arg.MaxPerBlog, arg.LikesPerUser)
+	return err
+}
+
+const computeUserRecommendations = `-- name: ComputeUserRecommendations :exec
+insert into user_recommendations (user_id, recommended_id, score, reason, computed_at)
+select ranked.user_id, ranked.recommended_id, ranked.score, ranked.reason, NOW() from (
+    select candidates.user_id,
+        candidates.recommended_id,
+        sum(candidates.score)::float8 as score,
+        (array_agg(candidates.reason order by candidates.score desc))[1]::text as reason,
+        row_number() over (partition by candidates.user_id order by sum(candidates.score) desc) as rank
+    from (
+        select follows.follower_id as user_id, friends.following_id as recommended_id, 1.0::float8 as score, 'followed_by_people_you_follow'::text as reason
+            from users_follow as follows join users_follow as friends on friends.follower_id = follows.following_id
+        union all
+        select liked_categories.user_id, category_authors.author_id, 0.5 * liked_categories.likes_count, 'writes_in_categories_you_like'
+            from (select likes.user_id, blogs.category, count(*)::float8 as likes_count from likes join blogs on blogs.id = likes.blog_id group by likes.user_id, blogs.category) as liked_categories
+            join (select distinct blogs.category, blogs.author_id from blogs where blogs.visibility = 'public' and blogs.is_published and blogs.moderation_status in ('visible', 'flagged')) as category_authors
+            on category_authors.category = liked_categories.category
+        union all
+        select read_categories.user_id, category_authors.author_id, 0.2 * read_categories.views_count, 'writes_in_categories_you_read'
+            from (select substring(blog_views.viewer_key from 6)::uuid as user_id, blogs.category, count(*)::float8 as views_count from blog_views join blogs on blogs.id = blog_views.blog_id
+                where blog_views.viewer_key like 'user:%' group by blog_views.viewer_key, blogs.category) as read_categories
+            join (select distinct blogs.category, blogs.author_id from blogs where blogs.visibility = 'public' and blogs.is_published and blogs.moderation_status in ('visible', 'flagged')) as category_authors
+            on category_authors.category = read_categories.category
+    ) as candidates
+    where candidates.user_id <> candidates.recommended_id
+        and not exists (select 1 from users_follow where users_follow.follower_id = candidates.user_id and users_follow.following_id = candidates.recommended_id)
+        and not exists (select 1 from user_blocks where (user_blocks.blocker_id = candidates.user_id and user_blocks.blocked_id = candidates.recommended_id)
+            or (user_blocks.blocker_id = candidates.recommended_id and user_blocks.blocked_id = candidates.user_id))
+        and not exists (select 1 from user_mutes where user_mutes.muter_id = candidates.user_id and user_mutes.muted_id = candidates.recommended_id)
+    group by candidates.user_id, candidates.recommended_id
+) as ranked
+where ranked.rank <= $1::bigint
+`
+
+func (q *Queries) ComputeUserRecommendations(ctx context.Context, maxPerUser int64) error {
+	_, err := q.db.ExecContext(ctx, computeUserRecommendations, maxPerUser)
+	return err
+}
+
+const deleteRelatedBlogs = `-- name: DeleteRelatedBlogs :exec
+delete from related_blogs
+`
+
+func (q *Queries) DeleteRelatedBlogs(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, deleteRelatedBlogs)
+	return err
+}
+
+const deleteUserRecommendations = `-- name: DeleteUserRecommendations :exec
+delete from user_recommendations
+`
+
+func (q *Queries) DeleteUserRecommendations(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, deleteUserRecommendations)
+	return err
+}
+
+const getRelatedBlogs = `-- name: GetRelatedBlogs :many
+select blogs.id,
+    blogs.title,
+    blogs.author_id,
+    users.username as author_name,
+    blogs.thumbnail_url,
+    related_blogs.score
+    from related_blogs join blogs on blogs.id = related_blogs.related_id join users on users.id = blogs.author_id
+    where related_blogs.blog_id = $1
+        and blogs.visibility = 'public' and blogs.is_published and blogs.moderation_status in ('visible', 'flagged')
+        and blogs.author_id not in (select user_blocks.blocked_id from user_blocks where user_blocks.blocker_id = $2)
+        and blogs.author_id not in (select user_blocks.blocker_id from user_blocks where user_blocks.blocked_id = $2)
+        and (blogs.author_id = $2
+            or not users.is_private
+            or blogs.author_id in (select users_follow.following_id from users_follow where users_follow.follower_id = $2))
+    order by related_blogs.score desc
+    limit $3
+`
+
+type GetRelatedBlogsParams struct {
+	BlogID   uuid.UUID
+	ViewerID uuid.UUID
+	Limit    int32
+}
+
+type GetRelatedBlogsRow struct {
+	ID           uuid.UUID
+	Title        string
+	AuthorID     uuid.UUID
+	AuthorName   string
+	ThumbnailUrl string
+	Score        float64
+}
+
+func (q *Queries) GetRelatedBlogs(ctx context.Context, arg GetRelatedBlogsParams) ([]GetRelatedBlogsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getRelatedBlogs, arg.BlogID, arg.ViewerID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetRelatedBlogsRow
+	for rows.Next() {
+		var i GetRelatedBlogsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.AuthorID,
+			&i.AuthorName,
+			&i.ThumbnailUrl,
+			&i.Score,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserRecommendations = `-- name: GetUserRecommendations :many
+select users.id,
+    users.username,
+    users.display_name,
+    users.avatar_url,
+    user_recommendations.reason,
+    user_recommendations.score
+    from user_recommendations join users on users.id = user_recommendations.recommended_id
+    where user_recommendations.user_id = $1
+        and not exists (select 1 from users_follow where users_follow.follower_id = $1 and users_follow.following_id = users.id)
+        and not exists (select 1 from user_blocks where (user_blocks.blocker_id = $1 and user_blocks.blocked_id = users.id)
+            or (user_blocks.blocker_id = users.id and user_blocks.blocked_id = $1))
+    order by user_recommendations.score desc
+    limit $3 offset $2
+`
+
+type GetUserRecommendationsParams struct {
+	UserID uuid.UUID
+	Offset int32
+	Limit  int32
+}
+
+type GetUserRecommendationsRow struct {
+	ID          uuid.UUID
+	Username    string
+	DisplayName string
+	AvatarUrl   string
+	Reason      string
+	Score       float64
+}
+
+func (q *Queries) GetUserRecommendations(ctx context.Context, arg GetUserRecommendationsParams) ([]GetUserRecommendationsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getUserRecommendations, arg.UserID, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserRecommendationsRow
+	for rows.Next() {
+		var i GetUserRecommendationsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.DisplayName,
+			&i.AvatarUrl,
+			&i.Reason,
+			&i.Score,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
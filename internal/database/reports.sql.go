@@ -0,0 +1,331 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: reports.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const assignReport = `-- name: AssignReport :one
+update reports set assignee_id = $1, status = 'assigned', updated_at = NOW() where id = $2 and status in ('open', 'assigned')
+returning id, reporter_id, entity_type, entity_id, reason_code, details, status, assignee_id, action_taken, resolution_note, resolved_by, resolved_at, created_at, updated_at
+`
+
+type AssignReportParams struct {
+	AssigneeID uuid.NullUUID
+	ID         uuid.UUID
+}
+
+func (q *Queries) AssignReport(ctx context.Context, arg AssignReportParams) (Report, error) {
+	row := q.db.QueryRowContext(ctx, assignReport, arg.AssigneeID, arg.ID)
+	var i Report
+	err := row.Scan(
+		&i.ID,
+		&i.ReporterID,
+		&i.EntityType,
+		&i.EntityID,
+		&i.ReasonCode,
+		&i.Details,
+		&i.Status,
+		&i.AssigneeID,
+		&i.ActionTaken,
+		&i.ResolutionNote,
+		&i.ResolvedBy,
+		&i.ResolvedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const closeReport = `-- name: CloseReport :one
+update reports set status = $1, action_taken = $2, resolution_note = $3, resolved_by = $4, resolved_at = NOW(), updated_at = NOW() where id = $5 and status in ('open', 'assigned')
+returning id, reporter_id, entity_type, entity_id, reason_code, details, status, assignee_id, action_taken, resolution_note, resolved_by, resolved_at, created_at, updated_at
+`
+
+type CloseReportParams struct {
+	Status         string
+	ActionTaken    string
+	ResolutionNote string
+	ResolvedBy     uuid.NullUUID
+	ID             uuid.UUID
+}
+
+func (q *Queries) CloseReport(ctx context.Context, arg CloseReportParams) (Report, error) {
+	row := q.db.QueryRowContext(ctx, closeReport,
+		arg.Status,
+		arg.ActionTaken,
+		arg.ResolutionNote,
+		arg.ResolvedBy,
+		arg.ID,
+	)
+	var i Report
+	err := row.Scan(
+		&i.ID,
+		&i.ReporterID,
+		&i.EntityType,
+		&i.EntityID,
+		&i.ReasonCode,
+		&i.Details,
+		&i.Status,
+		&i.AssigneeID,
+		&i.ActionTaken,
+		&i.ResolutionNote,
+		&i.ResolvedBy,
+		&i.ResolvedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createReport = `-- name: CreateReport :one
+insert into reports (id, reporter_id, entity_type, entity_id, reason_code, details, created_at, updated_at)
+values (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    NOW(),
+    NOW()
+)
+returning id, reporter_id, entity_type, entity_id, reason_code, details, status, assignee_id, action_taken, resolution_note, resolved_by, resolved_at, created_at, updated_at
+`
+
+type CreateReportParams struct {
+	ReporterID uuid.UUID
+	EntityType string
+	EntityID   uuid.UUID
+	ReasonCode string
+	Details    string
+}
+
+func (q *Queries) CreateReport(ctx context.Context, arg CreateReportParams) (Report, error) {
+	row := q.db.QueryRowContext(ctx, createReport,
+		arg.ReporterID,
+		arg.EntityType,
+		arg.EntityID,
+		arg.ReasonCode,
+		arg.Details,
+	)
+	var i Report
+	err := row.Scan(
+		&i.ID,
+		&i.ReporterID,
+		&i.EntityType,
+		&i.EntityID,
+		&i.ReasonCode,
+		&i.Details,
+		&i.Status,
+		&i.AssigneeID,
+		&i.ActionTaken,
+		&i.ResolutionNote,
+		&i.ResolvedBy,
+		&i.ResolvedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createUserWarning = `-- name: CreateUserWarning :one
+insert into user_warnings (id, user_id, moderator_id, report_id, message, created_at)
+values (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    NOW()
+)
+returning id, user_id, moderator_id, report_id, message, created_at
+`
+
+type CreateUserWarningParams struct {
+	UserID      uuid.UUID
+	ModeratorID uuid.NullUUID
+	ReportID    uuid.NullUUID
+	Message     string
+}
+
+func (q *Queries) CreateUserWarning(ctx context.Context, arg CreateUserWarningParams) (UserWarning, error) {
+	row := q.db.QueryRowContext(ctx, createUserWarning,
+		arg.UserID,
+		arg.ModeratorID,
+		arg.ReportID,
+		arg.Message,
+	)
+	var i UserWarning
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ModeratorID,
+		&i.ReportID,
+		&i.Message,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getReportById = `-- name: GetReportById :one
+select id, reporter_id, entity_type, entity_id, reason_code, details, status, assignee_id, action_taken, resolution_note, resolved_by, resolved_at, created_at, updated_at from reports where id = $1
+`
+
+func (q *Queries) GetReportById(ctx context.Context, id uuid.UUID) (Report, error) {
+	row := q.db.QueryRowContext(ctx, getReportById, id)
+	var i Report
+	err := row.Scan(
+		&i.ID,
+		&i.ReporterID,
+		&i.EntityType,
+		&i.EntityID,
+		&i.ReasonCode,
+		&i.Details,
+		&i.Status,
+		&i.AssigneeID,
+		&i.ActionTaken,
+		&i.ResolutionNote,
+		&i.ResolvedBy,
+		&i.ResolvedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getReportsByAssignee = `-- name: GetReportsByAssignee :many
+select id, reporter_id, entity_type, entity_id, reason_code, details, status, assignee_id, action_taken, resolution_note, resolved_by, resolved_at, created_at, updated_at from reports where assignee_id = $1 and status = 'assigned' order by created_at limit $2 offset $3
+`
+
+type GetReportsByAssigneeParams struct {
+	AssigneeID uuid.NullUUID
+	Limit      int32
+	Offset     int32
+}
+
+func (q *Queries) GetReportsByAssignee(ctx context.Context, arg GetReportsByAssigneeParams) ([]Report, error) {
+	rows, err := q.db.QueryContext(ctx, getReportsByAssignee, arg.AssigneeID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Report
+	for rows.Next() {
+		var i Report
+		if err := rows.Scan(
+			&i.ID,
+			&i.ReporterID,
+			&i.EntityType,
+			&i.EntityID,
+			&i.ReasonCode,
+			&i.Details,
+			&i.Status,
+			&i.AssigneeID,
+			&i.ActionTaken,
+			&i.ResolutionNote,
+			&i.ResolvedBy,
+			&i.ResolvedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReportsByStatus = `-- name: GetReportsByStatus :many
+select id, reporter_id, entity_type, entity_id, reason_code, details, status, assignee_id, action_taken, resolution_note, resolved_by, resolved_at, created_at, updated_at from reports where status = $1 order by created_at limit $2 offset $3
+`
+
+type GetReportsByStatusParams struct {
+	Status string
+	Limit  int32
+	Offset int32
+}
+
+func (q *Queries) GetReportsByStatus(ctx context.Context, arg GetReportsByStatusParams) ([]Report, error) {
+	rows, err := q.db.QueryContext(ctx, getReportsByStatus, arg.Status, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Report
+	for rows.Next() {
+		var i Report
+		if err := rows.Scan(
+			&i.ID,
+			&i.ReporterID,
+			&i.EntityType,
+			&i.EntityID,
+			&i.ReasonCode,
+			&i.Details,
+			&i.Status,
+			&i.AssigneeID,
+			&i.ActionTaken,
+			&i.ResolutionNote,
+			&i.ResolvedBy,
+			&i.ResolvedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserWarnings = `-- name: GetUserWarnings :many
+select id, user_id, moderator_id, report_id, message, created_at from user_warnings where user_id = $1 order by created_at desc
+`
+
+func (q *Queries) GetUserWarnings(ctx context.Context, userID uuid.UUID) ([]UserWarning, error) {
+	rows, err := q.db.QueryContext(ctx, getUserWarnings, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UserWarning
+	for rows.Next() {
+		var i UserWarning
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ModeratorID,
+			&i.ReportID,
+			&i.Message,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
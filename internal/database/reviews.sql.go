@@ -0,0 +1,505 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: reviews.sql
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const assignBlogReviewer = `-- name: AssignBlogReviewer :one
+insert into blog_reviewers (blog_id, reviewer_id, assigned_by, created_at, updated_at)
+values ($1, $2, $3, NOW(), NOW())
+on conflict (blog_id, reviewer_id) do update set updated_at = NOW()
+returning blog_id, reviewer_id, assigned_by, decision, decided_at, created_at, updated_at
+`
+
+type AssignBlogReviewerParams struct {
+	BlogID     uuid.UUID
+	ReviewerID uuid.UUID
+	AssignedBy uuid.NullUUID
+}
+
+func (q *Queries) AssignBlogReviewer(ctx context.Context, arg AssignBlogReviewerParams) (BlogReviewer, error) {
+	row := q.db.QueryRowContext(ctx, assignBlogReviewer, arg.BlogID, arg.ReviewerID, arg.AssignedBy)
+	var i BlogReviewer
+	err := row.Scan(
+		&i.BlogID,
+		&i.ReviewerID,
+		&i.AssignedBy,
+		&i.Decision,
+		&i.DecidedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const clearReviewDecisions = `-- name: ClearReviewDecisions :exec
+update blog_reviewers set decision = null, decided_at = null, updated_at = NOW() where blog_id = $1
+`
+
+func (q *Queries) ClearReviewDecisions(ctx context.Context, blogID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, clearReviewDecisions, blogID)
+	return err
+}
+
+const createReviewComment = `-- name: CreateReviewComment :one
+insert into review_comments (id, blog_id, user_id, body, anchor_start, anchor_end, quoted_text, created_at, updated_at)
+values (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    NOW(),
+    NOW()
+)
+returning id, blog_id, user_id, body, anchor_start, anchor_end, quoted_text, resolved, created_at, updated_at
+`
+
+type CreateReviewCommentParams struct {
+	BlogID      uuid.UUID
+	UserID      uuid.UUID
+	Body        string
+	AnchorStart int32
+	AnchorEnd   int32
+	QuotedText  string
+}
+
+func (q *Queries) CreateReviewComment(ctx context.Context, arg CreateReviewCommentParams) (ReviewComment, error) {
+	row := q.db.QueryRowContext(ctx, createReviewComment,
+		arg.BlogID,
+		arg.UserID,
+		arg.Body,
+		arg.AnchorStart,
+		arg.AnchorEnd,
+		arg.QuotedText,
+	)
+	var i ReviewComment
+	err := row.Scan(
+		&i.ID,
+		&i.BlogID,
+		&i.UserID,
+		&i.Body,
+		&i.AnchorStart,
+		&i.AnchorEnd,
+		&i.QuotedText,
+		&i.Resolved,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const decideBlogReview = `-- name: DecideBlogReview :one
+update blog_reviewers set decision = $1, decided_at = NOW(), updated_at = NOW() where blog_id = $2 and reviewer_id = $3
+returning blog_id, reviewer_id, assigned_by, decision, decided_at, created_at, updated_at
+`
+
+type DecideBlogReviewParams struct {
+	Decision   sql.NullString
+	BlogID     uuid.UUID
+	ReviewerID uuid.UUID
+}
+
+func (q *Queries) DecideBlogReview(ctx context.Context, arg DecideBlogReviewParams) (BlogReviewer, error) {
+	row := q.db.QueryRowContext(ctx, decideBlogReview, arg.Decision, arg.BlogID, arg.ReviewerID)
+	var i BlogReviewer
+	err := row.Scan(
+		&i.BlogID,
+		&i.ReviewerID,
+		&i.AssignedBy,
+		&i.Decision,
+		&i.DecidedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getBlogReviewers = `-- name: GetBlogReviewers :many
+select users.id,
+    users.username,
+    users.display_name,
+    users.avatar_url,
+    blog_reviewers.decision,
+    blog_reviewers.decided_at,
+    blog_reviewers.created_at
+    from blog_reviewers join users on users.id = blog_reviewers.reviewer_id
+    where blog_reviewers.blog_id = $1
+    order by blog_reviewers.created_at
+`
+
+type GetBlogReviewersRow struct {
+	ID          uuid.UUID
+	Username    string
+	DisplayName string
+	AvatarUrl   string
+	Decision    sql.NullString
+	DecidedAt   sql.NullTime
+	CreatedAt   time.Time
+}
+
+func (q *Queries) GetBlogReviewers(ctx context.Context, blogID uuid.UUID) ([]GetBlogReviewersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getBlogReviewers, blogID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetBlogReviewersRow
+	for rows.Next() {
+		var i GetBlogReviewersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.DisplayName,
+			&i.AvatarUrl,
+			&i.Decision,
+			&i.DecidedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPublishedCategoryBlogs = `-- name: GetPublishedCategoryBlogs :many
+select id, is_published, review_status from blogs where category = $1 and is_published
+`
+
+type GetPublishedCategoryBlogsRow struct {
+	ID           uuid.UUID
+	IsPublished  bool
+	ReviewStatus string
+}
+
+func (q *Queries) GetPublishedCategoryBlogs(ctx context.Context, category uuid.UUID) ([]GetPublishedCategoryBlogsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPublishedCategoryBlogs, category)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPublishedCategoryBlogsRow
+	for rows.Next() {
+		var i GetPublishedCategoryBlogsRow
+		if err := rows.Scan(&i.ID, &i.IsPublished, &i.ReviewStatus); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReviewCommentById = `-- name: GetReviewCommentById :one
+select id, blog_id, user_id, body, anchor_start, anchor_end, quoted_text, resolved, created_at, updated_at from review_comments where id = $1
+`
+
+func (q *Queries) GetReviewCommentById(ctx context.Context, id uuid.UUID) (ReviewComment, error) {
+	row := q.db.QueryRowContext(ctx, getReviewCommentById, id)
+	var i ReviewComment
+	err := row.Scan(
+		&i.ID,
+		&i.BlogID,
+		&i.UserID,
+		&i.Body,
+		&i.AnchorStart,
+		&i.AnchorEnd,
+		&i.QuotedText,
+		&i.Resolved,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getReviewComments = `-- name: GetReviewComments :many
+select review_comments.id, review_comments.blog_id, review_comments.user_id, review_comments.body, review_comments.anchor_start, review_comments.anchor_end, review_comments.quoted_text, review_comments.resolved, review_comments.created_at, review_comments.updated_at, users.username
+    from review_comments join users on users.id = review_comments.user_id
+    where review_comments.blog_id = $1
+    order by review_comments.anchor_start, review_comments.created_at
+`
+
+type GetReviewCommentsRow struct {
+	ID          uuid.UUID
+	BlogID      uuid.UUID
+	UserID      uuid.UUID
+	Body        string
+	AnchorStart int32
+	AnchorEnd   int32
+	QuotedText  string
+	Resolved    bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Username    string
+}
+
+func (q *Queries) GetReviewComments(ctx context.Context, blogID uuid.UUID) ([]GetReviewCommentsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getReviewComments, blogID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetReviewCommentsRow
+	for rows.Next() {
+		var i GetReviewCommentsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.BlogID,
+			&i.UserID,
+			&i.Body,
+			&i.AnchorStart,
+			&i.AnchorEnd,
+			&i.QuotedText,
+			&i.Resolved,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Username,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReviewQueue = `-- name: GetReviewQueue :many
+select blogs.id,
+    blogs.title,
+    blogs.author_id,
+    users.username as author_name,
+    blogs.review_status,
+    blogs.updated_at,
+    blog_reviewers.decision
+    from blog_reviewers join blogs on blogs.id = blog_reviewers.blog_id join users on users.id = blogs.author_id
+    where blog_reviewers.reviewer_id = $1 and blogs.review_status = 'pending'
+    order by blogs.updated_at
+    limit $3 offset $2
+`
+
+type GetReviewQueueParams struct {
+	ReviewerID uuid.UUID
+	Offset     int32
+	Limit      int32
+}
+
+type GetReviewQueueRow struct {
+	ID           uuid.UUID
+	Title        string
+	AuthorID     uuid.UUID
+	AuthorName   string
+	ReviewStatus string
+	UpdatedAt    time.Time
+	Decision     sql.NullString
+}
+
+func (q *Queries) GetReviewQueue(ctx context.Context, arg GetReviewQueueParams) ([]GetReviewQueueRow, error) {
+	rows, err := q.db.QueryContext(ctx, getReviewQueue, arg.ReviewerID, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetReviewQueueRow
+	for rows.Next() {
+		var i GetReviewQueueRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.AuthorID,
+			&i.AuthorName,
+			&i.ReviewStatus,
+			&i.UpdatedAt,
+			&i.Decision,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const publishBlog = `-- name: PublishBlog :one
+update blogs set is_published = true, updated_at = NOW() where id = $1
+returning id, title, author_id, thumbnail_url, content, created_at, updated_at, category, moderation_status, visibility, is_published, review_status, publication_id
+`
+
+func (q *Queries) PublishBlog(ctx context.Context, id uuid.UUID) (Blog, error) {
+	row := q.db.QueryRowContext(ctx, publishBlog, id)
+	var i Blog
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.AuthorID,
+		&i.ThumbnailUrl,
+		&i.Content,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Category,
+		&i.ModerationStatus,
+		&i.Visibility,
+		&i.IsPublished,
+		&i.ReviewStatus,
+		&i.PublicationID,
+	)
+	return i, err
+}
+
+const resetBlogReview = `-- name: ResetBlogReview :execrows
+update blogs set review_status = 'pending' where id = $1 and review_status = 'approved' and not is_published
+`
+
+func (q *Queries) ResetBlogReview(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.ExecContext(ctx, resetBlogReview, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setReviewCommentResolved = `-- name: SetReviewCommentResolved :one
+update review_comments set resolved = $1, updated_at = NOW() where id = $2
+returning id, blog_id, user_id, body, anchor_start, anchor_end, quoted_text, resolved, created_at, updated_at
+`
+
+type SetReviewCommentResolvedParams struct {
+	Resolved bool
+	ID       uuid.UUID
+}
+
+func (q *Queries) SetReviewCommentResolved(ctx context.Context, arg SetReviewCommentResolvedParams) (ReviewComment, error) {
+	row := q.db.QueryRowContext(ctx, setReviewCommentResolved, arg.Resolved, arg.ID)
+	var i ReviewComment
+	err := row.Scan(
+		&i.ID,
+		&i.BlogID,
+		&i.UserID,
+		&i.Body,
+		&i.AnchorStart,
+		&i.AnchorEnd,
+		&i.QuotedText,
+		&i.Resolved,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const submitBlogForReview = `-- name: SubmitBlogForReview :one
+update blogs set review_status = 'pending', updated_at = NOW() where id = $1
+returning id, title, author_id, thumbnail_url, content, created_at, updated_at, category, moderation_status, visibility, is_published, review_status, publication_id
+`
+
+func (q *Queries) SubmitBlogForReview(ctx context.Context, id uuid.UUID) (Blog, error) {
+	row := q.db.QueryRowContext(ctx, submitBlogForReview, id)
+	var i Blog
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.AuthorID,
+		&i.ThumbnailUrl,
+		&i.Content,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Category,
+		&i.ModerationStatus,
+		&i.Visibility,
+		&i.IsPublished,
+		&i.ReviewStatus,
+		&i.PublicationID,
+	)
+	return i, err
+}
+
+const unassignBlogReviewer = `-- name: UnassignBlogReviewer :execrows
+delete from blog_reviewers where blog_id = $1 and reviewer_id = $2
+`
+
+type UnassignBlogReviewerParams struct {
+	BlogID     uuid.UUID
+	ReviewerID uuid.UUID
+}
+
+func (q *Queries) UnassignBlogReviewer(ctx context.Context, arg UnassignBlogReviewerParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, unassignBlogReviewer, arg.BlogID, arg.ReviewerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const unpublishBlogForReview = `-- name: UnpublishBlogForReview :one
+update blogs set is_published = false, review_status = 'pending', updated_at = NOW() where id = $1
+returning id, title, author_id, thumbnail_url, content, created_at, updated_at, category, moderation_status, visibility, is_published, review_status, publication_id
+`
+
+func (q *Queries) UnpublishBlogForReview(ctx context.Context, id uuid.UUID) (Blog, error) {
+	row := q.db.QueryRowContext(ctx, unpublishBlogForReview, id)
+	var i Blog
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.AuthorID,
+		&i.ThumbnailUrl,
+		&i.Content,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Category,
+		&i.ModerationStatus,
+		&i.Visibility,
+		&i.IsPublished,
+		&i.ReviewStatus,
+		&i.PublicationID,
+	)
+	return i, err
+}
+
+const updateBlogReviewStatus = `-- name: UpdateBlogReviewStatus :one
+update blogs set review_status = (case
+        when exists (select 1 from blog_reviewers where blog_reviewers.blog_id = blogs.id and blog_reviewers.decision = 'changes_requested') then 'changes_requested'
+        when exists (select 1 from blog_reviewers where blog_reviewers.blog_id = blogs.id and blog_reviewers.decision = 'approved') then 'approved'
+        else 'pending' end),
+    updated_at = NOW()
+    where blogs.id = $1
+returning review_status
+`
+
+func (q *Queries) UpdateBlogReviewStatus(ctx context.Context, id uuid.UUID) (string, error) {
+	row := q.db.QueryRowContext(ctx, updateBlogReviewStatus, id)
+	var review_status string
+	err := row.Scan(&review_status)
+	return review_status, err
+}
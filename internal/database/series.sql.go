@@ -0,0 +1,292 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: series.sql
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const addBlogToSeries = `-- name: AddBlogToSeries :one
+insert into series_blogs (series_id, blog_id, position, created_at)
+values (
+    $1,
+    $2,
+    coalesce((select max(series_blogs.position) from series_blogs where series_blogs.series_id = $1), 0) + 1,
+    NOW()
+)
+returning series_id, blog_id, position, created_at
+`
+
+type AddBlogToSeriesParams struct {
+	SeriesID uuid.UUID
+	BlogID   uuid.UUID
+}
+
+func (q *Queries) AddBlogToSeries(ctx context.Context, arg AddBlogToSeriesParams) (SeriesBlog, error) {
+	row := q.db.QueryRowContext(ctx, addBlogToSeries, arg.SeriesID, arg.BlogID)
+	var i SeriesBlog
+	err := row.Scan(
+		&i.SeriesID,
+		&i.BlogID,
+		&i.Position,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createSeries = `-- name: CreateSeries :one
+insert into series (id, author_id, title, description, created_at, updated_at)
+values (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    NOW(),
+    NOW()
+)
+returning id, author_id, title, description, created_at, updated_at
+`
+
+type CreateSeriesParams struct {
+	AuthorID    uuid.UUID
+	Title       string
+	Description string
+}
+
+func (q *Queries) CreateSeries(ctx context.Context, arg CreateSeriesParams) (Series, error) {
+	row := q.db.QueryRowContext(ctx, createSeries, arg.AuthorID, arg.Title, arg.Description)
+	var i Series
+	err := row.Scan(
+		&i.ID,
+		&i.AuthorID,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteSeries = `-- name: DeleteSeries :exec
+delete from series where id = $1
+`
+
+func (q *Queries) DeleteSeries(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteSeries, id)
+	return err
+}
+
+const editSeries = `-- name: EditSeries :one
+update series set title = $1, description = $2, updated_at = NOW() where id = $3
+returning id, author_id, title, description, created_at, updated_at
+`
+
+type EditSeriesParams struct {
+	Title       string
+	Description string
+	ID          uuid.UUID
+}
+
+func (q *Queries) EditSeries(ctx context.Context, arg EditSeriesParams) (Series, error) {
+	row := q.db.QueryRowContext(ctx, editSeries, arg.Title, arg.Description, arg.ID)
+	var i Series
+	err := row.Scan(
+		&i.ID,
+		&i.AuthorID,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getSeriesByAuthorId = `-- name: GetSeriesByAuthorId :many
+select series.id, series.author_id, series.title, series.description, series.created_at, series.updated_at,
+    (select count(*) from series_blogs where series_blogs.series_id = series.id) as parts_count
+    from series where series.author_id = $1
+    order by series.updated_at desc
+    limit $3 offset $2
+`
+
+type GetSeriesByAuthorIdParams struct {
+	AuthorID uuid.UUID
+	Offset   int32
+	Limit    int32
+}
+
+type GetSeriesByAuthorIdRow struct {
+	ID          uuid.UUID
+	AuthorID    uuid.UUID
+	Title       string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	PartsCount  int64
+}
+
+func (q *Queries) GetSeriesByAuthorId(ctx context.Context, arg GetSeriesByAuthorIdParams) ([]GetSeriesByAuthorIdRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSeriesByAuthorId, arg.AuthorID, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSeriesByAuthorIdRow
+	for rows.Next() {
+		var i GetSeriesByAuthorIdRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AuthorID,
+			&i.Title,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PartsCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSeriesByBlogId = `-- name: GetSeriesByBlogId :one
+select series.id, series.author_id, series.title, series.description, series.created_at, series.updated_at from series join series_blogs on series_blogs.series_id = series.id where series_blogs.blog_id = $1
+`
+
+func (q *Queries) GetSeriesByBlogId(ctx context.Context, blogID uuid.UUID) (Series, error) {
+	row := q.db.QueryRowContext(ctx, getSeriesByBlogId, blogID)
+	var i Series
+	err := row.Scan(
+		&i.ID,
+		&i.AuthorID,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getSeriesById = `-- name: GetSeriesById :one
+select id, author_id, title, description, created_at, updated_at from series where id = $1
+`
+
+func (q *Queries) GetSeriesById(ctx context.Context, id uuid.UUID) (Series, error) {
+	row := q.db.QueryRowContext(ctx, getSeriesById, id)
+	var i Series
+	err := row.Scan(
+		&i.ID,
+		&i.AuthorID,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getSeriesParts = `-- name: GetSeriesParts :many
+select blogs.id, blogs.title, blogs.thumbnail_url, blogs.moderation_status, blogs.visibility, blogs.is_published, blogs.created_at, series_blogs.position
+    from series_blogs join blogs on blogs.id = series_blogs.blog_id
+    where series_blogs.series_id = $1
+    order by series_blogs.position
+`
+
+type GetSeriesPartsRow struct {
+	ID               uuid.UUID
+	Title            string
+	ThumbnailUrl     string
+	ModerationStatus string
+	Visibility       string
+	IsPublished      bool
+	CreatedAt        time.Time
+	Position         int32
+}
+
+func (q *Queries) GetSeriesParts(ctx context.Context, seriesID uuid.UUID) ([]GetSeriesPartsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSeriesParts, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSeriesPartsRow
+	for rows.Next() {
+		var i GetSeriesPartsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.ThumbnailUrl,
+			&i.ModerationStatus,
+			&i.Visibility,
+			&i.IsPublished,
+			&i.CreatedAt,
+			&i.Position,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const removeBlogFromSeries = `-- name: RemoveBlogFromSeries :execrows
+delete from series_blogs where series_id = $1 and blog_id = $2
+`
+
+type RemoveBlogFromSeriesParams struct {
+	SeriesID uuid.UUID
+	BlogID   uuid.UUID
+}
+
+func (q *Queries) RemoveBlogFromSeries(ctx context.Context, arg RemoveBlogFromSeriesParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, removeBlogFromSeries, arg.SeriesID, arg.BlogID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const reorderSeries = `-- name: ReorderSeries :exec
+update series_blogs set position = ordered.position
+    from unnest($2::uuid[]) with ordinality as ordered(blog_id, position)
+    where series_blogs.series_id = $1 and series_blogs.blog_id = ordered.blog_id
+`
+
+type ReorderSeriesParams struct {
+	SeriesID uuid.UUID
+	BlogIds  []uuid.UUID
+}
+
+func (q *Queries) ReorderSeries(ctx context.Context, arg ReorderSeriesParams) error {
+	_, err := q.db.ExecContext(ctx, reorderSeries, arg.SeriesID, pq.Array(arg.BlogIds))
+	return err
+}
+
+const touchSeries = `-- name: TouchSeries :exec
+update series set updated_at = NOW() where id = $1
+`
+
+func (q *Queries) TouchSeries(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, touchSeries, id)
+	return err
+}
@@ -0,0 +1,75 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: social_links.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const deleteSocialLink = `-- name: DeleteSocialLink :exec
+delete from user_social_links where user_id = $1 and platform = $2
+`
+
+type DeleteSocialLinkParams struct {
+	UserID   uuid.UUID
+	Platform string
+}
+
+func (q *Queries) DeleteSocialLink(ctx context.Context, arg DeleteSocialLinkParams) error {
+	_, err := q.db.ExecContext(ctx, deleteSocialLink, arg.UserID, arg.Platform)
+	return err
+}
+
+const getSocialLinks = `-- name: GetSocialLinks :many
+select platform, url from user_social_links where user_id = $1 order by platform
+`
+
+type GetSocialLinksRow struct {
+	Platform string
+	Url      string
+}
+
+func (q *Queries) GetSocialLinks(ctx context.Context, userID uuid.UUID) ([]GetSocialLinksRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSocialLinks, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSocialLinksRow
+	for rows.Next() {
+		var i GetSocialLinksRow
+		if err := rows.Scan(&i.Platform, &i.Url); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertSocialLink = `-- name: UpsertSocialLink :exec
+insert into user_social_links (user_id, platform, url, created_at, updated_at)
+values ($1, $2, $3, NOW(), NOW())
+on conflict (user_id, platform) do update set url = excluded.url, updated_at = NOW()
+`
+
+type UpsertSocialLinkParams struct {
+	UserID   uuid.UUID
+	Platform string
+	Url      string
+}
+
+func (q *Queries) UpsertSocialLink(ctx context.Context, arg UpsertSocialLinkParams) error {
+	_, err := q.db.ExecContext(ctx, upsertSocialLink, arg.UserID, arg.Platform, arg.Url)
+	return err
+}
@@ -0,0 +1,66 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: username_history.sql
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const getCurrentUsernameByOldUsername = `-- name: GetCurrentUsernameByOldUsername :one
+select users.username from username_history join users on users.id = username_history.user_id
+    where lower(username_history.old_username) = lower($1)
+`
+
+func (q *Queries) GetCurrentUsernameByOldUsername(ctx context.Context, username string) (string, error) {
+	row := q.db.QueryRowContext(ctx, getCurrentUsernameByOldUsername, username)
+	err := row.Scan(&username)
+	return username, err
+}
+
+const isUsernameHeld = `-- name: IsUsernameHeld :one
+select exists(select 1 from username_history where lower(old_username) = lower($1) and user_id <> $2 and changed_at > $3)
+`
+
+type IsUsernameHeldParams struct {
+	Username  string
+	UserID    uuid.UUID
+	HeldSince time.Time
+}
+
+func (q *Queries) IsUsernameHeld(ctx context.Context, arg IsUsernameHeldParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, isUsernameHeld, arg.Username, arg.UserID, arg.HeldSince)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const recordUsernameChange = `-- name: RecordUsernameChange :exec
+insert into username_history (old_username, user_id, changed_at)
+values ($1, $2, NOW())
+on conflict (lower(old_username)) do update set old_username = excluded.old_username, user_id = excluded.user_id, changed_at = NOW()
+`
+
+type RecordUsernameChangeParams struct {
+	OldUsername string
+	UserID      uuid.UUID
+}
+
+func (q *Queries) RecordUsernameChange(ctx context.Context, arg RecordUsernameChangeParams) error {
+	_, err := q.db.ExecContext(ctx, recordUsernameChange, arg.OldUsername, arg.UserID)
+	return err
+}
+
+const releaseUsername = `-- name: ReleaseUsername :exec
+delete from username_history where lower(old_username) = lower($1)
+`
+
+func (q *Queries) ReleaseUsername(ctx context.Context, username string) error {
+	_, err := q.db.ExecContext(ctx, releaseUsername, username)
+	return err
+}
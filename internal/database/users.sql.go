@@ -7,11 +7,26 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+const changeUsername = `-- name: ChangeUsername :exec
+update users set username = $1, username_changed_at = NOW(), updated_at = NOW() where id = $2
+`
+
+type ChangeUsernameParams struct {
+	Username string
+	ID       uuid.UUID
+}
+
+func (q *Queries) ChangeUsername(ctx context.Context, arg ChangeUsernameParams) error {
+	_, err := q.db.ExecContext(ctx, changeUsername, arg.Username, arg.ID)
+	return err
+}
+
 const createUser = `-- name: CreateUser :one
 insert into users(id, username, email, hashed_password, created_at, updated_at)
 values(
@@ -54,7 +69,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (CreateU
 
 const deleteUser = `-- name: DeleteUser :one
 delete from users where id = $1
-returning id, username, email, hashed_password, created_at, updated_at
+returning id, username, email, hashed_password, created_at, updated_at, role, suspended_until, display_name, bio, avatar_url, website, location, username_changed_at, is_private
 `
 
 func (q *Queries) DeleteUser(ctx context.Context, id uuid.UUID) (User, error) {
@@ -67,12 +82,72 @@ func (q *Queries) DeleteUser(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.HashedPassword,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Role,
+		&i.SuspendedUntil,
+		&i.DisplayName,
+		&i.Bio,
+		&i.AvatarUrl,
+		&i.Website,
+		&i.Location,
+		&i.UsernameChangedAt,
+		&i.IsPrivate,
+	)
+	return i, err
+}
+
+const getPublicProfileByUsername = `-- name: GetPublicProfileByUsername :one
+select users.id,
+    users.username,
+    users.display_name,
+    users.bio,
+    users.avatar_url,
+    users.website,
+    users.location,
+    users.is_private,
+    users.created_at,
+    (select count(*) from users_follow where users_follow.following_id = users.id) as followers_count,
+    (select count(*) from users_follow where users_follow.follower_id = users.id) as following_count,
+    (select count(*) from blogs where blogs.author_id = users.id and blogs.is_published and blogs.moderation_status in ('visible', 'flagged') and blogs.visibility in ('public', 'followers')) as posts_count
+    from users where lower(users.username) = lower($1)
+`
+
+type GetPublicProfileByUsernameRow struct {
+	ID             uuid.UUID
+	Username       string
+	DisplayName    string
+	Bio            string
+	AvatarUrl      string
+	Website        string
+	Location       string
+	IsPrivate      bool
+	CreatedAt      time.Time
+	FollowersCount int64
+	FollowingCount int64
+	PostsCount     int64
+}
+
+func (q *Queries) GetPublicProfileByUsername(ctx context.Context, username string) (GetPublicProfileByUsernameRow, error) {
+	row := q.db.QueryRowContext(ctx, getPublicProfileByUsername, username)
+	var i GetPublicProfileByUsernameRow
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.DisplayName,
+		&i.Bio,
+		&i.AvatarUrl,
+		&i.Website,
+		&i.Location,
+		&i.IsPrivate,
+		&i.CreatedAt,
+		&i.FollowersCount,
+		&i.FollowingCount,
+		&i.PostsCount,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-select id, username, email, hashed_password, created_at, updated_at from users where email = $1
+select id, username, email, hashed_password, created_at, updated_at, role, suspended_until, display_name, bio, avatar_url, website, location, username_changed_at, is_private from users where email = $1
 `
 
 func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
@@ -85,12 +160,21 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.HashedPassword,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Role,
+		&i.SuspendedUntil,
+		&i.DisplayName,
+		&i.Bio,
+		&i.AvatarUrl,
+		&i.Website,
+		&i.Location,
+		&i.UsernameChangedAt,
+		&i.IsPrivate,
 	)
 	return i, err
 }
 
 const getUserById = `-- name: GetUserById :one
-select id, username, email, hashed_password, created_at, updated_at from users where id = $1
+select id, username, email, hashed_password, created_at, updated_at, role, suspended_until, display_name, bio, avatar_url, website, location, username_changed_at, is_private from users where id = $1
 `
 
 func (q *Queries) GetUserById(ctx context.Context, id uuid.UUID) (User, error) {
@@ -103,12 +187,34 @@ func (q *Queries) GetUserById(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.HashedPassword,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Role,
+		&i.SuspendedUntil,
+		&i.DisplayName,
+		&i.Bio,
+		&i.AvatarUrl,
+		&i.Website,
+		&i.Location,
+		&i.UsernameChangedAt,
+		&i.IsPrivate,
 	)
 	return i, err
 }
 
 const getUserFeed = `-- name: GetUserFeed :many
-select id, title, author_id, thumbnail_url, content, created_at, updated_at, category from blogs where author_id = (select following_id from users_follow where follower_id = $1) order by created_at
+select id, title, author_id, thumbnail_url, content, created_at, updated_at, category, moderation_status, visibility, is_published, review_status, publication_id from blogs where (blogs.author_id in (select users_follow.following_id from users_follow where users_follow.follower_id = $1)
+        or blogs.id in (select collection_blog.blog_id from collection_blog
+            join collection_follows on collection_follows.collection_id = collection_blog.collection_id
+            join collections on collections.id = collection_blog.collection_id
+            where collection_follows.user_id = $1 and collections.visibility <> 'private' and collection_blog.created_at >= collection_follows.created_at))
+    and blogs.is_published and blogs.moderation_status in ('visible', 'flagged')
+    and (blogs.visibility = 'public'
+        or (blogs.visibility = 'followers' and blogs.author_id in (select users_follow.following_id from users_follow where users_follow.follower_id = $1)))
+    and (blogs.author_id not in (select users.id from users where users.is_private)
+        or blogs.author_id in (select users_follow.following_id from users_follow where users_follow.follower_id = $1))
+    and blogs.author_id not in (select user_mutes.muted_id from user_mutes where user_mutes.muter_id = $1)
+    and blogs.author_id not in (select user_blocks.blocked_id from user_blocks where user_blocks.blocker_id = $1)
+    and blogs.author_id not in (select user_blocks.blocker_id from user_blocks where user_blocks.blocked_id = $1)
+    order by blogs.created_at
 `
 
 func (q *Queries) GetUserFeed(ctx context.Context, followerID uuid.UUID) ([]Blog, error) {
@@ -129,6 +235,11 @@ func (q *Queries) GetUserFeed(ctx context.Context, followerID uuid.UUID) ([]Blog
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.Category,
+			&i.ModerationStatus,
+			&i.Visibility,
+			&i.IsPublished,
+			&i.ReviewStatus,
+			&i.PublicationID,
 		); err != nil {
 			return nil, err
 		}
@@ -170,8 +281,19 @@ func (q *Queries) GetUserFollowingList(ctx context.Context, followerID uuid.UUID
 	return items, nil
 }
 
+const getUserIdByUsername = `-- name: GetUserIdByUsername :one
+select id from users where lower(username) = lower($1)
+`
+
+func (q *Queries) GetUserIdByUsername(ctx context.Context, username string) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, getUserIdByUsername, username)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
 const getUsersByUsername = `-- name: GetUsersByUsername :many
-select id, username from users where username = $1
+select id, username from users where lower(username) = lower($1)
 `
 
 type GetUsersByUsernameRow struct {
@@ -202,6 +324,76 @@ func (q *Queries) GetUsersByUsername(ctx context.Context, username string) ([]Ge
 	return items, nil
 }
 
+const searchUsersByUsername = `-- name: SearchUsersByUsername :many
+select id, username from users where lower(username) = lower($1)
+    and not exists(
+        select 1 from user_blocks
+        where (blocker_id = $2 and blocked_id = users.id)
+            or (blocker_id = users.id and blocked_id = $2)
+    )
+`
+
+type SearchUsersByUsernameParams struct {
+	Username string
+	ViewerID uuid.UUID
+}
+
+type SearchUsersByUsernameRow struct {
+	ID       uuid.UUID
+	Username string
+}
+
+func (q *Queries) SearchUsersByUsername(ctx context.Context, arg SearchUsersByUsernameParams) ([]SearchUsersByUsernameRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchUsersByUsername, arg.Username, arg.ViewerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchUsersByUsernameRow
+	for rows.Next() {
+		var i SearchUsersByUsernameRow
+		if err := rows.Scan(&i.ID, &i.Username); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setUserPrivacy = `-- name: SetUserPrivacy :exec
+update users set is_private = $1, updated_at = NOW() where id = $2
+`
+
+type SetUserPrivacyParams struct {
+	IsPrivate bool
+	ID        uuid.UUID
+}
+
+func (q *Queries) SetUserPrivacy(ctx context.Context, arg SetUserPrivacyParams) error {
+	_, err := q.db.ExecContext(ctx, setUserPrivacy, arg.IsPrivate, arg.ID)
+	return err
+}
+
+const suspendUser = `-- name: SuspendUser :exec
+update users set suspended_until = $1, updated_at = NOW() where id = $2
+`
+
+type SuspendUserParams struct {
+	SuspendedUntil sql.NullTime
+	ID             uuid.UUID
+}
+
+func (q *Queries) SuspendUser(ctx context.Context, arg SuspendUserParams) error {
+	_, err := q.db.ExecContext(ctx, suspendUser, arg.SuspendedUntil, arg.ID)
+	return err
+}
+
 const updateUserEmailOrUsername = `-- name: UpdateUserEmailOrUsername :one
 update users set email = $1, username = $2, updated_at = NOW() where id = $3
 returning id, username, email, created_at, updated_at
@@ -233,3 +425,55 @@ func (q *Queries) UpdateUserEmailOrUsername(ctx context.Context, arg UpdateUserE
 	)
 	return i, err
 }
+
+const updateUserProfile = `-- name: UpdateUserProfile :one
+update users set display_name = $1, bio = $2, avatar_url = $3, website = $4, location = $5, updated_at = NOW() where id = $6
+returning id, username, email, display_name, bio, avatar_url, website, location, created_at, updated_at
+`
+
+type UpdateUserProfileParams struct {
+	DisplayName string
+	Bio         string
+	AvatarUrl   string
+	Website     string
+	Location    string
+	ID          uuid.UUID
+}
+
+type UpdateUserProfileRow struct {
+	ID          uuid.UUID
+	Username    string
+	Email       string
+	DisplayName string
+	Bio         string
+	AvatarUrl   string
+	Website     string
+	Location    string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (q *Queries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (UpdateUserProfileRow, error) {
+	row := q.db.QueryRowContext(ctx, updateUserProfile,
+		arg.DisplayName,
+		arg.Bio,
+		arg.AvatarUrl,
+		arg.Website,
+		arg.Location,
+		arg.ID,
+	)
+	var i UpdateUserProfileRow
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.DisplayName,
+		&i.Bio,
+		&i.AvatarUrl,
+		&i.Website,
+		&i.Location,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
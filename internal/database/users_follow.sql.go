@@ -7,6 +7,7 @@ package database
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -26,6 +27,144 @@ func (q *Queries) FollowUser(ctx context.Context, arg FollowUserParams) error {
 	return err
 }
 
+const getFollowers = `-- name: GetFollowers :many
+select users.id,
+    users.username,
+    users.display_name,
+    users.avatar_url,
+    users_follow.created_at as followed_at,
+    exists(select 1 from users_follow as viewer_follow where viewer_follow.follower_id = $1 and viewer_follow.following_id = users.id) as followed_by_viewer,
+    exists(select 1 from users_follow as viewer_follow where viewer_follow.follower_id = users.id and viewer_follow.following_id = $1) as follows_viewer
+    from users_follow join users on users.id = users_follow.follower_id
+    where users_follow.following_id = $2
+    and users.id not in (select user_blocks.blocked_id from user_blocks where user_blocks.blocker_id = $1)
+    and users.id not in (select user_blocks.blocker_id from user_blocks where user_blocks.blocked_id = $1)
+    order by users_follow.created_at desc
+    limit $4 offset $3
+`
+
+type GetFollowersParams struct {
+	ViewerID uuid.UUID
+	UserID   uuid.UUID
+	Offset   int32
+	Limit    int32
+}
+
+type GetFollowersRow struct {
+	ID               uuid.UUID
+	Username         string
+	DisplayName      string
+	AvatarUrl        string
+	FollowedAt       time.Time
+	FollowedByViewer bool
+	FollowsViewer    bool
+}
+
+func (q *Queries) GetFollowers(ctx context.Context, arg GetFollowersParams) ([]GetFollowersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getFollowers,
+		arg.ViewerID,
+		arg.UserID,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetFollowersRow
+	for rows.Next() {
+		var i GetFollowersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.DisplayName,
+			&i.AvatarUrl,
+			&i.FollowedAt,
+			&i.FollowedByViewer,
+			&i.FollowsViewer,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFollowing = `-- name: GetFollowing :many
+select users.id,
+    users.username,
+    users.display_name,
+    users.avatar_url,
+    users_follow.created_at as followed_at,
+    exists(select 1 from users_follow as viewer_follow where viewer_follow.follower_id = $1 and viewer_follow.following_id = users.id) as followed_by_viewer,
+    exists(select 1 from users_follow as viewer_follow where viewer_follow.follower_id = users.id and viewer_follow.following_id = $1) as follows_viewer
+    from users_follow join users on users.id = users_follow.following_id
+    where users_follow.follower_id = $2
+    and users.id not in (select user_blocks.blocked_id from user_blocks where user_blocks.blocker_id = $1)
+    and users.id not in (select user_blocks.blocker_id from user_blocks where user_blocks.blocked_id = $1)
+    order by users_follow.created_at desc
+    limit $4 offset $3
+`
+
+type GetFollowingParams struct {
+	ViewerID uuid.UUID
+	UserID   uuid.UUID
+	Offset   int32
+	Limit    int32
+}
+
+type GetFollowingRow struct {
+	ID               uuid.UUID
+	Username         string
+	DisplayName      string
+	AvatarUrl        string
+	FollowedAt       time.Time
+	FollowedByViewer bool
+	FollowsViewer    bool
+}
+
+func (q *Queries) GetFollowing(ctx context.Context, arg GetFollowingParams) ([]GetFollowingRow, error) {
+	rows, err := q.db.QueryContext(ctx, getFollowing,
+		arg.ViewerID,
+		arg.UserID,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetFollowingRow
+	for rows.Next() {
+		var i GetFollowingRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.DisplayName,
+			&i.AvatarUrl,
+			&i.FollowedAt,
+			&i.FollowedByViewer,
+			&i.FollowsViewer,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getPair = `-- name: GetPair :one
 select follower_id, following_id, created_at, updated_at from users_follow where follower_id = $1 and following_id = $2
 `
@@ -47,6 +186,28 @@ func (q *Queries) GetPair(ctx context.Context, arg GetPairParams) (UsersFollow,
 	return i, err
 }
 
+const getRelationship = `-- name: GetRelationship :one
+select exists(select 1 from users_follow where users_follow.follower_id = $1 and users_follow.following_id = $2) as following,
+    exists(select 1 from users_follow where users_follow.follower_id = $2 and users_follow.following_id = $1) as followed_by
+`
+
+type GetRelationshipParams struct {
+	ViewerID uuid.UUID
+	UserID   uuid.UUID
+}
+
+type GetRelationshipRow struct {
+	Following  bool
+	FollowedBy bool
+}
+
+func (q *Queries) GetRelationship(ctx context.Context, arg GetRelationshipParams) (GetRelationshipRow, error) {
+	row := q.db.QueryRowContext(ctx, getRelationship, arg.ViewerID, arg.UserID)
+	var i GetRelationshipRow
+	err := row.Scan(&i.Following, &i.FollowedBy)
+	return i, err
+}
+
 const unfollowUser = `-- name: UnfollowUser :exec
 delete from users_follow where follower_id = $1 and following_id = $2
 `
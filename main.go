@@ -1,14 +1,25 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/harshvardha/blogs/analytics"
 	"github.com/harshvardha/blogs/controllers"
+	"github.com/harshvardha/blogs/filters"
 	"github.com/harshvardha/blogs/internal/database"
 	"github.com/harshvardha/blogs/middlewares"
+	"github.com/harshvardha/blogs/notifications"
+	"github.com/harshvardha/blogs/rankings"
+	"github.com/harshvardha/blogs/realtime"
+	"github.com/harshvardha/blogs/recommendations"
+	"github.com/harshvardha/blogs/utility"
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
 )
@@ -35,6 +46,21 @@ func main() {
 		log.Fatal("port env varaible not set")
 	}
 
+	// content filter settings, every setting falls back to a sensible default when not set
+	contentFilterConfig := filters.Config{
+		BlockedWords:      splitEnv("CONTENT_BLOCKED_WORDS", ","),
+		BlockedPatterns:   splitEnv("CONTENT_BLOCKED_PATTERNS", ";"),
+		MaxBlogLinks:      intEnv("CONTENT_MAX_BLOG_LINKS", 20),
+		MaxCommentLinks:   intEnv("CONTENT_MAX_COMMENT_LINKS", 2),
+		MaxPostsPerWindow: intEnv("CONTENT_MAX_POSTS_PER_MINUTE", 5),
+		PostingWindow:     time.Minute,
+		DuplicateWindow:   time.Hour,
+	}
+	contentFilter, err := filters.NewDefaultFilter(contentFilterConfig)
+	if err != nil {
+		log.Fatal("Invalid content filter settings: ", err)
+	}
+
 	// creating database connection
 	dbConnection, err := sql.Open("postgres", dbURL)
 	if err != nil {
@@ -42,12 +68,43 @@ func main() {
 	}
 	db := database.New(dbConnection)
 
+	// realtime event broker, setting REALTIME_BACKEND=postgres shares the events between multiple servers
+	var broker realtime.Broker = realtime.NewHub()
+	if os.Getenv("REALTIME_BACKEND") == "postgres" {
+		postgresBroker, err := realtime.NewPostgresBroker(dbURL, db)
+		if err != nil {
+			log.Fatal("Error starting realtime listener: ", err)
+		}
+		defer postgresBroker.Close()
+		broker = postgresBroker
+	}
+
+	// blog views are written in batches every few seconds, a reader is counted once per blog every VIEW_DEDUP_MINUTES
+	views, err := analytics.NewViewRecorder(db, time.Duration(intEnv("VIEW_DEDUP_MINUTES", 30))*time.Minute)
+	if err != nil {
+		log.Fatal("Invalid view settings: ", err)
+	}
+	go views.Run(context.Background(), 5*time.Second)
+
 	// setting the variables in apiConfig struct to be used by different controller functions
 	apiCfg := controllers.ApiConfig{
-		DB:        db,
-		JwtSecret: jwtSecret,
+		DB:            db,
+		JwtSecret:     jwtSecret,
+		ContentFilter: contentFilter,
+		Notifier:      notifications.NewService(db, broker),
+		Broker:        broker,
+		Presence:      realtime.NewPresence(),
+		Views:         views,
 	}
 
+	// refreshing the recommendations in the background, every RECOMMENDATIONS_REFRESH_MINUTES
+	recommender := recommendations.NewService(dbConnection, db)
+	go recommender.Run(context.Background(), time.Duration(intEnv("RECOMMENDATIONS_REFRESH_MINUTES", 60))*time.Minute)
+
+	// refreshing the trending and popular blog rankings in the background, every RANKINGS_REFRESH_MINUTES
+	ranker := rankings.NewService(dbConnection, db)
+	go ranker.Run(context.Background(), time.Duration(intEnv("RANKINGS_REFRESH_MINUTES", 10))*time.Minute)
+
 	// creating and running the server
 	mux := http.NewServeMux()
 
@@ -66,39 +123,161 @@ func main() {
 	mux.HandleFunc("PUT /api/users/updateProfile", middlewares.ValidateJWT(apiCfg.HandleUpdateProfile, apiCfg.JwtSecret, apiCfg.DB))
 	mux.HandleFunc("POST /api/users/follow/{followingID}", middlewares.ValidateJWT(apiCfg.HandleFollowUnFollowUser, apiCfg.JwtSecret, apiCfg.DB))
 	mux.HandleFunc("DELETE /api/users/deleteAccount", middlewares.ValidateJWT(apiCfg.HandleDeleteUserAccount, apiCfg.JwtSecret, apiCfg.DB))
-	mux.HandleFunc("GET /api/users/search", apiCfg.HandleSearch)
+	mux.HandleFunc("GET /api/users/search", middlewares.OptionalJWT(apiCfg.HandleSearch, apiCfg.JwtSecret, apiCfg.DB))
 	mux.HandleFunc("GET /api/users/feeds", middlewares.ValidateJWT(apiCfg.HandleGetUserFeeds, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/users/{username}", middlewares.OptionalJWT(apiCfg.HandleGetPublicProfile, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/users/{userID}/followers", middlewares.OptionalJWT(apiCfg.HandleGetFollowers, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/users/{userID}/following", middlewares.OptionalJWT(apiCfg.HandleGetFollowing, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/users/{userID}/collections", middlewares.OptionalJWT(apiCfg.HandleGetUserPublicCollections, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("POST /api/users/block/{userID}", middlewares.ValidateJWT(apiCfg.HandleBlockUser, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("DELETE /api/users/unblock/{userID}", middlewares.ValidateJWT(apiCfg.HandleUnblockUser, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/users/blocked", middlewares.ValidateJWT(apiCfg.HandleGetBlockedUsers, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("POST /api/users/mute/{userID}", middlewares.ValidateJWT(apiCfg.HandleMuteUser, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("DELETE /api/users/unmute/{userID}", middlewares.ValidateJWT(apiCfg.HandleUnmuteUser, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/users/muted", middlewares.ValidateJWT(apiCfg.HandleGetMutedUsers, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/users/followRequests", middlewares.ValidateJWT(apiCfg.HandleGetFollowRequests, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/users/followRequests/approve/{userID}", middlewares.ValidateJWT(apiCfg.HandleApproveFollowRequest, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/users/followRequests/deny/{userID}", middlewares.ValidateJWT(apiCfg.HandleDenyFollowRequest, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/users/{userID}/relationship", middlewares.ValidateJWT(apiCfg.HandleGetRelationship, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/users/mentions", middlewares.ValidateJWT(apiCfg.HandleGetMyMentions, apiCfg.JwtSecret, apiCfg.DB))
 
 	// api endpoints for category
 	mux.HandleFunc("POST /api/category/create", middlewares.ValidateJWT(apiCfg.HandleAddCategory, apiCfg.JwtSecret, apiCfg.DB))
 	mux.HandleFunc("PUT /api/category/edit/{categoryID}", middlewares.ValidateJWT(apiCfg.HandleEditCategory, apiCfg.JwtSecret, apiCfg.DB))
 	mux.HandleFunc("DELETE /api/category/delete/{categoryID}", middlewares.ValidateJWT(apiCfg.HandleRemoveCategory, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/category/review/{categoryID}", middlewares.ValidateJWT(apiCfg.HandleSetCategoryReview, apiCfg.JwtSecret, apiCfg.DB))
 
 	// api endpoints for blogs
 	mux.HandleFunc("POST /api/blogs/create", middlewares.ValidateJWT(apiCfg.HandleCreateBlog, apiCfg.JwtSecret, apiCfg.DB))
 	mux.HandleFunc("PUT /api/blogs/edit/{blogID}", middlewares.ValidateJWT(apiCfg.HandleEditBlog, apiCfg.JwtSecret, apiCfg.DB))
 	mux.HandleFunc("DELETE /api/blogs/delete/{blogID}", middlewares.ValidateJWT(apiCfg.HandleDeleteBlog, apiCfg.JwtSecret, apiCfg.DB))
-	mux.HandleFunc("GET /api/blogs/{blogID}", middlewares.ValidateJWT(apiCfg.HandleGetBlogById, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/blogs/{blogID}", middlewares.OptionalJWT(apiCfg.HandleGetBlogById, apiCfg.JwtSecret, apiCfg.DB))
 	mux.HandleFunc("GET /api/blogs/all", middlewares.ValidateJWT(apiCfg.HandleGetAllBlogs, apiCfg.JwtSecret, apiCfg.DB))
 	mux.HandleFunc("PUT /api/blogs/like/{blogID}", middlewares.ValidateJWT(apiCfg.HandleLikeOrUnlikeBlog, apiCfg.JwtSecret, apiCfg.DB))
-	mux.HandleFunc("GET /api/blogs/search", apiCfg.HandleSearchBlog)
-	mux.HandleFunc("GET /api/blogs/category", apiCfg.HandleGetBlogsByCategory)
+	mux.HandleFunc("GET /api/blogs/search", middlewares.OptionalJWT(apiCfg.HandleSearchBlog, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/blogs/category", middlewares.OptionalJWT(apiCfg.HandleGetBlogsByCategory, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/blogs/trending", middlewares.OptionalJWT(apiCfg.HandleGetTrendingBlogs, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/blogs/popular", middlewares.OptionalJWT(apiCfg.HandleGetPopularBlogs, apiCfg.JwtSecret, apiCfg.DB))
+	// /{blogID}/related would overlap /contributors/{blogID} and /review/{blogID} and make the mux panic
+	// so the sub resources of a blog are matched together and only the related posts are served
+	relatedBlogs := middlewares.OptionalJWT(apiCfg.HandleGetRelatedBlogs, apiCfg.JwtSecret, apiCfg.DB)
+	mux.HandleFunc("GET /api/blogs/{blogID}/{resource}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("resource") != "related" {
+			utility.RespondWithError(w, http.StatusNotFound, "Not found")
+			return
+		}
+		relatedBlogs(w, r)
+	})
+	mux.HandleFunc("GET /api/blogs/contributors/{blogID}", middlewares.ValidateJWT(apiCfg.HandleGetBlogContributors, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("POST /api/blogs/contributors/{blogID}", middlewares.ValidateJWT(apiCfg.HandleInviteBlogContributor, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("DELETE /api/blogs/contributors/{blogID}/{userID}", middlewares.ValidateJWT(apiCfg.HandleRemoveBlogContributor, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/blogs/invites", middlewares.ValidateJWT(apiCfg.HandleGetBlogInvites, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/blogs/invites/accept/{blogID}", middlewares.ValidateJWT(apiCfg.HandleAcceptBlogInvite, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("DELETE /api/blogs/leave/{blogID}", middlewares.ValidateJWT(apiCfg.HandleLeaveBlog, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/blogs/publish/{blogID}", middlewares.ValidateJWT(apiCfg.HandlePublishBlog, apiCfg.JwtSecret, apiCfg.DB))
+
+	// api endpoints for editorial review
+	mux.HandleFunc("GET /api/blogs/review/queue", middlewares.ValidateJWT(apiCfg.HandleGetReviewQueue, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/blogs/review/{blogID}", middlewares.ValidateJWT(apiCfg.HandleGetBlogReview, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/blogs/review/submit/{blogID}", middlewares.ValidateJWT(apiCfg.HandleSubmitBlogForReview, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/blogs/review/decide/{blogID}", middlewares.ValidateJWT(apiCfg.HandleDecideBlogReview, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("POST /api/blogs/review/reviewers/{blogID}", middlewares.ValidateJWT(apiCfg.HandleAssignBlogReviewer, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("DELETE /api/blogs/review/reviewers/{blogID}/{userID}", middlewares.ValidateJWT(apiCfg.HandleUnassignBlogReviewer, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("POST /api/blogs/review/comments/{blogID}", middlewares.ValidateJWT(apiCfg.HandleCreateReviewComment, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/blogs/review/comments/{blogID}", middlewares.ValidateJWT(apiCfg.HandleGetReviewComments, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/blogs/review/comments/resolve/{commentID}", middlewares.ValidateJWT(apiCfg.HandleResolveReviewComment, apiCfg.JwtSecret, apiCfg.DB))
 
 	// api endpoints for comments
 	mux.HandleFunc("POST /api/comments/create", middlewares.ValidateJWT(apiCfg.HandleCreateComment, apiCfg.JwtSecret, apiCfg.DB))
 	mux.HandleFunc("PUT /api/comments/edit/{commentID}", middlewares.ValidateJWT(apiCfg.HandleEditComment, apiCfg.JwtSecret, apiCfg.DB))
 	mux.HandleFunc("DELETE /api/comments/delete/{commentID}", middlewares.ValidateJWT(apiCfg.HandleDeleteComment, apiCfg.JwtSecret, apiCfg.DB))
 	mux.HandleFunc("PUT /api/comments/like/{commentID}", middlewares.ValidateJWT(apiCfg.HandleLikeComment, apiCfg.JwtSecret, apiCfg.DB))
-	mux.HandleFunc("GET /api/comments/all/{blogID}", middlewares.ValidateJWT(apiCfg.HandleGetAllCommentsByBlogId, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/comments/all/{blogID}", middlewares.OptionalJWT(apiCfg.HandleGetAllCommentsByBlogId, apiCfg.JwtSecret, apiCfg.DB))
+
+	// api endpoints for comment moderation
+	mux.HandleFunc("GET /api/comments/settings/{blogID}", middlewares.ValidateJWT(apiCfg.HandleGetCommentSettings, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/comments/settings/{blogID}", middlewares.ValidateJWT(apiCfg.HandleUpdateCommentSettings, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/comments/pending/{blogID}", middlewares.ValidateJWT(apiCfg.HandleGetPendingComments, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/comments/approve/{commentID}", middlewares.ValidateJWT(apiCfg.HandleApproveComment, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/comments/reject/{commentID}", middlewares.ValidateJWT(apiCfg.HandleRejectComment, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/comments/hide/{commentID}", middlewares.ValidateJWT(apiCfg.HandleHideComment, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/comments/pin/{commentID}", middlewares.ValidateJWT(apiCfg.HandlePinComment, apiCfg.JwtSecret, apiCfg.DB))
 
 	// api endpoints for collections
 	mux.HandleFunc("POST /api/collection/create", middlewares.ValidateJWT(apiCfg.HandleCreateCollection, apiCfg.JwtSecret, apiCfg.DB))
 	mux.HandleFunc("PUT /api/collection/edit/{collectionID}", middlewares.ValidateJWT(apiCfg.HandleEditCollection, apiCfg.JwtSecret, apiCfg.DB))
 	mux.HandleFunc("DELETE /api/collection/{collectionID}", middlewares.ValidateJWT(apiCfg.HandleDeleteCollection, apiCfg.JwtSecret, apiCfg.DB))
 	mux.HandleFunc("GET /api/collection/all", middlewares.ValidateJWT(apiCfg.HandleGetAllCollectionsByUserID, apiCfg.JwtSecret, apiCfg.DB))
-	mux.HandleFunc("GET /api/collection/blogs", middlewares.ValidateJWT(apiCfg.HandleGetAllBlogsByCollectionID, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/collection/blogs", middlewares.OptionalJWT(apiCfg.HandleGetAllBlogsByCollectionID, apiCfg.JwtSecret, apiCfg.DB))
 	mux.HandleFunc("PUT /api/collection/addBlog", middlewares.ValidateJWT(apiCfg.HandleAddBlogToCollection, apiCfg.JwtSecret, apiCfg.DB))
 	mux.HandleFunc("PUT /api/collection/removeBlog", middlewares.ValidateJWT(apiCfg.HandleRemoveBlogFromCollection, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/collection/reorder/{collectionID}", middlewares.ValidateJWT(apiCfg.HandleReorderCollection, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/collection/note", middlewares.ValidateJWT(apiCfg.HandleSetCollectionNote, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/collection/members/{collectionID}", middlewares.ValidateJWT(apiCfg.HandleGetCollectionMembers, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("POST /api/collection/members/{collectionID}", middlewares.ValidateJWT(apiCfg.HandleInviteCollectionMember, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("DELETE /api/collection/members/{collectionID}/{userID}", middlewares.ValidateJWT(apiCfg.HandleRemoveCollectionMember, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/collection/invites", middlewares.ValidateJWT(apiCfg.HandleGetCollectionInvites, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/collection/invites/accept/{collectionID}", middlewares.ValidateJWT(apiCfg.HandleAcceptCollectionInvite, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("DELETE /api/collection/leave/{collectionID}", middlewares.ValidateJWT(apiCfg.HandleLeaveCollection, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/collection/transfer/{collectionID}", middlewares.ValidateJWT(apiCfg.HandleTransferCollection, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/collection/shareToken/{collectionID}", middlewares.ValidateJWT(apiCfg.HandleRotateCollectionShareToken, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/collections/{collectionID}", middlewares.OptionalJWT(apiCfg.HandleGetCollection, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/collections/{collectionID}/export", middlewares.OptionalJWT(apiCfg.HandleExportCollection, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("POST /api/collections/{collectionID}/follow", middlewares.ValidateJWT(apiCfg.HandleFollowCollection, apiCfg.JwtSecret, apiCfg.DB))
+
+	// api endpoints for series
+	mux.HandleFunc("POST /api/series/create", middlewares.ValidateJWT(apiCfg.HandleCreateSeries, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/series/edit/{seriesID}", middlewares.ValidateJWT(apiCfg.HandleEditSeries, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("DELETE /api/series/delete/{seriesID}", middlewares.ValidateJWT(apiCfg.HandleDeleteSeries, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/series/{seriesID}", middlewares.OptionalJWT(apiCfg.HandleGetSeries, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/series/addBlog", middlewares.ValidateJWT(apiCfg.HandleAddBlogToSeries, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/series/removeBlog", middlewares.ValidateJWT(apiCfg.HandleRemoveBlogFromSeries, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/series/reorder/{seriesID}", middlewares.ValidateJWT(apiCfg.HandleReorderSeries, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/users/{userID}/series", middlewares.OptionalJWT(apiCfg.HandleGetUserSeries, apiCfg.JwtSecret, apiCfg.DB))
+
+	// api endpoints for publications
+	mux.HandleFunc("POST /api/publications/create", middlewares.ValidateJWT(apiCfg.HandleCreatePublication, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/publications/edit/{slug}", middlewares.ValidateJWT(apiCfg.HandleEditPublication, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/publications/mine", middlewares.ValidateJWT(apiCfg.HandleGetMyPublications, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/publications/invites", middlewares.ValidateJWT(apiCfg.HandleGetPublicationInvites, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/publications/invites/accept/{slug}", middlewares.ValidateJWT(apiCfg.HandleAcceptPublicationInvite, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/publications/{slug}", middlewares.OptionalJWT(apiCfg.HandleGetPublication, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/publications/{slug}/blogs", middlewares.OptionalJWT(apiCfg.HandleGetPublicationBlogs, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/publications/{slug}/categories", middlewares.OptionalJWT(apiCfg.HandleGetPublicationCategories, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/publications/{slug}/members", middlewares.OptionalJWT(apiCfg.HandleGetPublicationMembers, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("POST /api/publications/{slug}/members", middlewares.ValidateJWT(apiCfg.HandleInvitePublicationMember, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("DELETE /api/publications/{slug}/members/{userID}", middlewares.ValidateJWT(apiCfg.HandleRemovePublicationMember, apiCfg.JwtSecret, apiCfg.DB))
+
+	// api endpoints for moderation
+	mux.HandleFunc("GET /api/moderation/flags", middlewares.ValidateJWT(apiCfg.HandleGetContentFlags, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/moderation/flags/review/{flagID}", middlewares.ValidateJWT(apiCfg.HandleReviewContentFlag, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/moderation/reports", middlewares.ValidateJWT(apiCfg.HandleGetReports, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/moderation/reports/{reportID}", middlewares.ValidateJWT(apiCfg.HandleGetReportById, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/moderation/reports/assign/{reportID}", middlewares.ValidateJWT(apiCfg.HandleAssignReport, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/moderation/reports/resolve/{reportID}", middlewares.ValidateJWT(apiCfg.HandleResolveReport, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/moderation/reports/dismiss/{reportID}", middlewares.ValidateJWT(apiCfg.HandleDismissReport, apiCfg.JwtSecret, apiCfg.DB))
+
+	// api endpoints for reports
+	mux.HandleFunc("POST /api/reports", middlewares.ValidateJWT(apiCfg.HandleCreateReport, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/users/warnings", middlewares.ValidateJWT(apiCfg.HandleGetUserWarnings, apiCfg.JwtSecret, apiCfg.DB))
+
+	// api endpoints for notifications
+	mux.HandleFunc("GET /api/notifications", middlewares.ValidateJWT(apiCfg.HandleGetNotifications, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/notifications/read", middlewares.ValidateJWT(apiCfg.HandleMarkNotificationRead, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/notifications/readAll", middlewares.ValidateJWT(apiCfg.HandleMarkAllNotificationsRead, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/notifications/preferences", middlewares.ValidateJWT(apiCfg.HandleGetNotificationPreferences, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("PUT /api/notifications/preferences", middlewares.ValidateJWT(apiCfg.HandleUpdateNotificationPreferences, apiCfg.JwtSecret, apiCfg.DB))
+
+	// api endpoints for author analytics
+	mux.HandleFunc("GET /api/analytics/blogs", middlewares.ValidateJWT(apiCfg.HandleGetBlogAnalytics, apiCfg.JwtSecret, apiCfg.DB))
+	mux.HandleFunc("GET /api/analytics/daily", middlewares.ValidateJWT(apiCfg.HandleGetDailyAnalytics, apiCfg.JwtSecret, apiCfg.DB))
+
+	// api endpoints for recommendations
+	mux.HandleFunc("GET /api/recommendations/users", middlewares.ValidateJWT(apiCfg.HandleGetUserRecommendations, apiCfg.JwtSecret, apiCfg.DB))
+
+	// api endpoint for realtime events, browsers can send the access token as the access_token query param
+	mux.HandleFunc("GET /api/events", middlewares.AccessTokenFromQuery(middlewares.ValidateJWT(apiCfg.HandleEvents, apiCfg.JwtSecret, apiCfg.DB)))
+	mux.HandleFunc("GET /api/live/{blogID}", middlewares.AccessTokenFromQuery(middlewares.ValidateJWT(apiCfg.HandleLiveBlog, apiCfg.JwtSecret, apiCfg.DB)))
 
 	server := &http.Server{
 		Handler: mux,
@@ -109,3 +288,27 @@ func main() {
 		log.Fatal("Unable to start server: ", err)
 	}
 }
+
+// function to read a list from an env variable, empty entries are dropped
+func splitEnv(name string, separator string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(name), separator) {
+		if value = strings.TrimSpace(value); len(value) > 0 {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// function to read a positive number from an env variable, falling back to the default when not set
+// zero and negative values are rejected as every setting read this way is a limit or an interval
+func intEnv(name string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(name))
+	if err != nil {
+		return fallback
+	}
+	if value <= 0 {
+		log.Fatalf("%s must be a positive number", name)
+	}
+	return value
+}
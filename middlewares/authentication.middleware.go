@@ -14,6 +14,11 @@ import (
 
 type authedHandler func(http.ResponseWriter, *http.Request, database.User, string)
 
+// function to check if the request only reads data, suspended users are still allowed to make such requests
+func isReadOnlyRequest(r *http.Request) bool {
+	return r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions
+}
+
 func ValidateJWT(handler authedHandler, tokenSecret string, db *database.Queries) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// extracting auth header from request
@@ -52,6 +57,15 @@ func ValidateJWT(handler authedHandler, tokenSecret string, db *database.Queries
 			return
 		}
 
+		// calling the handler only if the user is allowed to make this request
+		serve := func(newAccessToken string) {
+			if controllers.IsSuspended(user) && !isReadOnlyRequest(r) {
+				utility.RespondWithError(w, http.StatusForbidden, "Your account is suspended until "+user.SuspendedUntil.Time.Format(time.RFC1123))
+				return
+			}
+			handler(w, r, user, newAccessToken)
+		}
+
 		if parseError != nil {
 			// extracting the expiresAt claim from token
 			expiresAt, err := token.Claims.GetExpirationTime()
@@ -80,7 +94,7 @@ func ValidateJWT(handler authedHandler, tokenSecret string, db *database.Queries
 						utility.RespondWithError(w, http.StatusInternalServerError, err.Error())
 						return
 					}
-					handler(w, r, user, newAccessToken)
+					serve(newAccessToken)
 					return
 				}
 			}
@@ -88,6 +102,31 @@ func ValidateJWT(handler authedHandler, tokenSecret string, db *database.Queries
 			return
 		}
 
-		handler(w, r, user, "")
+		serve("")
+	}
+}
+
+// AccessTokenFromQuery lets event streams and websockets opened by browsers, which cannot set headers, send the access token as the access_token query param
+func AccessTokenFromQuery(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if accessToken := query.Get("access_token"); len(accessToken) > 0 && len(r.Header.Get("Authorization")) == 0 {
+			r.Header.Set("Authorization", "Bearer "+accessToken)
+			query.Del("access_token")
+			r.URL.RawQuery = query.Encode()
+		}
+		handler(w, r)
+	}
+}
+
+// OptionalJWT lets anonymous requests through with an empty user and validates the token like ValidateJWT when one is sent
+func OptionalJWT(handler authedHandler, tokenSecret string, db *database.Queries) http.HandlerFunc {
+	validateJWT := ValidateJWT(handler, tokenSecret, db)
+	return func(w http.ResponseWriter, r *http.Request) {
+		if len(r.Header.Get("Authorization")) == 0 {
+			handler(w, r, database.User{}, "")
+			return
+		}
+		validateJWT(w, r)
 	}
 }
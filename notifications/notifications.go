@@ -0,0 +1,231 @@
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/harshvardha/blogs/internal/database"
+	"github.com/harshvardha/blogs/realtime"
+)
+
+// notification types
+const (
+	TypeFollow            = "follow"
+	TypeBlogLike          = "blog_like"
+	TypeBlogComment       = "blog_comment"
+	TypeCommentLike       = "comment_like"
+	TypeCommentReply      = "comment_reply"
+	TypeMention           = "mention"
+	TypeFollowRequest     = "follow_request"
+	TypeFollowAccepted    = "follow_accepted"
+	TypeCollectionInvite  = "collection_invite"
+	TypeBlogInvite        = "blog_invite"
+	TypeReviewRequest     = "review_request"
+	TypeReviewDecision    = "review_decision"
+	TypePublicationInvite = "publication_invite"
+)
+
+// Types lists every notification type a user can turn on or off
+var Types = []string{
+	TypeFollow,
+	TypeBlogLike,
+	TypeBlogComment,
+	TypeCommentLike,
+	TypeCommentReply,
+	TypeMention,
+	TypeFollowRequest,
+	TypeFollowAccepted,
+	TypeCollectionInvite,
+	TypeBlogInvite,
+	TypeReviewRequest,
+	TypeReviewDecision,
+	TypePublicationInvite,
+}
+
+// entities a notification can point to
+const (
+	EntityUser        = "user"
+	EntityBlog        = "blog"
+	EntityComment     = "comment"
+	EntityCollection  = "collection"
+	EntityPublication = "publication"
+)
+
+// Notification is something which happened to a user because of another user
+type Notification struct {
+	UserID     uuid.UUID
+	ActorID    uuid.UUID
+	Type       string
+	EntityType string
+	EntityID   uuid.UUID
+}
+
+// PushedNotification is the notification as pushed to a connected user in real time
+type PushedNotification struct {
+	Type       string    `json:"type"`
+	EntityType string    `json:"entity_type"`
+	EntityID   uuid.UUID `json:"entity_id"`
+	ActorID    uuid.UUID `json:"actor_id"`
+	ActorName  string    `json:"actor_name"`
+	Message    string    `json:"message"`
+}
+
+// Service saves the notifications emitted by the request handlers and pushes them to the connected users
+type Service struct {
+	db     *database.Queries
+	broker realtime.Broker
+}
+
+// NewService creates the notification service, notifications are only saved when broker is nil
+func NewService(db *database.Queries, broker realtime.Broker) *Service {
+	return &Service{
+		db:     db,
+		broker: broker,
+	}
+}
+
+// Notify saves the notification unless the user caused it themselves, turned this type of notification off or blocked or muted the actor
+func (s *Service) Notify(ctx context.Context, notification Notification) error {
+	if notification.UserID == notification.ActorID {
+		return nil
+	}
+
+	enabled, err := s.IsEnabled(ctx, notification.UserID, notification.Type)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+
+	// users never hear from the users they blocked, were blocked by or muted
+	suppressed, err := s.db.IsNotificationSuppressed(ctx, database.IsNotificationSuppressedParams{
+		UserID:  notification.UserID,
+		ActorID: notification.ActorID,
+	})
+	if err != nil {
+		return err
+	}
+	if suppressed {
+		return nil
+	}
+
+	err = s.db.CreateNotification(ctx, database.CreateNotificationParams{
+		UserID:     notification.UserID,
+		ActorID:    notification.ActorID,
+		Type:       notification.Type,
+		EntityType: notification.EntityType,
+		EntityID:   notification.EntityID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.push(ctx, notification)
+}
+
+// function to push a saved notification to the user if they are connected
+func (s *Service) push(ctx context.Context, notification Notification) error {
+	if s.broker == nil {
+		return nil
+	}
+
+	actor, err := s.db.GetUserById(ctx, notification.ActorID)
+	if err != nil {
+		return err
+	}
+	event, err := realtime.NewEvent(realtime.UserTopic(notification.UserID), realtime.EventNotification, PushedNotification{
+		Type:       notification.Type,
+		EntityType: notification.EntityType,
+		EntityID:   notification.EntityID,
+		ActorID:    notification.ActorID,
+		ActorName:  actor.Username,
+		Message:    Message(notification.Type, actor.Username, 1),
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.broker.Publish(ctx, event)
+}
+
+// Retract removes a notification whose cause was undone, like an unlike or an unfollow
+func (s *Service) Retract(ctx context.Context, notification Notification) error {
+	return s.db.DeleteNotification(ctx, database.DeleteNotificationParams{
+		UserID:   notification.UserID,
+		ActorID:  notification.ActorID,
+		Type:     notification.Type,
+		EntityID: notification.EntityID,
+	})
+}
+
+// IsEnabled checks the user preference for a notification type, every type is enabled by default
+func (s *Service) IsEnabled(ctx context.Context, userID uuid.UUID, notificationType string) (bool, error) {
+	enabled, err := s.db.GetNotificationPreference(ctx, database.GetNotificationPreferenceParams{
+		UserID: userID,
+		Type:   notificationType,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return enabled, nil
+}
+
+// IsValidType checks if the notification type is one of the known types
+func IsValidType(notificationType string) bool {
+	for _, knownType := range Types {
+		if knownType == notificationType {
+			return true
+		}
+	}
+	return false
+}
+
+// Message builds the text shown for a group of notifications, e.g. "alice and 12 others liked your post"
+func Message(notificationType string, latestActorName string, actorsCount int64) string {
+	actors := latestActorName
+	switch {
+	case actorsCount == 2:
+		actors = latestActorName + " and 1 other"
+	case actorsCount > 2:
+		actors = fmt.Sprintf("%s and %d others", latestActorName, actorsCount-1)
+	}
+
+	switch notificationType {
+	case TypeFollow:
+		return actors + " started following you"
+	case TypeBlogLike:
+		return actors + " liked your post"
+	case TypeBlogComment:
+		return actors + " commented on your post"
+	case TypeCommentLike:
+		return actors + " liked your comment"
+	case TypeCommentReply:
+		return actors + " replied to your comment"
+	case TypeMention:
+		return actors + " mentioned you"
+	case TypeFollowRequest:
+		return actors + " requested to follow you"
+	case TypeFollowAccepted:
+		return actors + " accepted your follow request"
+	case TypeCollectionInvite:
+		return actors + " invited you to a collection"
+	case TypeBlogInvite:
+		return actors + " invited you to contribute to a post"
+	case TypeReviewRequest:
+		return actors + " asked you to review a post"
+	case TypeReviewDecision:
+		return actors + " reviewed your post"
+	case TypePublicationInvite:
+		return actors + " invited you to a publication"
+	default:
+		return actors + " interacted with you"
+	}
+}
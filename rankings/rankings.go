@@ -0,0 +1,112 @@
+package rankings
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/harshvardha/blogs/internal/database"
+)
+
+// Trending ranks the blogs by their likes, comments and views of the last week, the older an interaction the less it counts
+const Trending = "trending"
+
+// PopularWindows are the windows the popular blogs can be ranked over, mapped to their length in hours
+var PopularWindows = map[string]int32{
+	"day":   24,
+	"week":  24 * 7,
+	"month": 24 * 30,
+}
+
+// weights of the interactions with a blog and how fast trending interactions lose their weight
+const (
+	likeWeight      = 1.0
+	commentWeight   = 2.0
+	viewWeight      = 0.1
+	trendingHours   = 24 * 7
+	trendingGravity = 1.5
+)
+
+// Popular is the name of the ranking of the most popular blogs over the window
+func Popular(window string) string {
+	return "popular_" + window
+}
+
+// Service computes the trending and popular rankings of the blogs into the blog_rankings table
+// the handlers read the saved rankings instead of scanning the likes, comments and views on every request
+type Service struct {
+	conn *sql.DB
+	db   *database.Queries
+}
+
+// NewService creates the ranking service
+func NewService(conn *sql.DB, db *database.Queries) *Service {
+	return &Service{
+		conn: conn,
+		db:   db,
+	}
+}
+
+// Run refreshes the rankings right away and then every interval until the context is cancelled
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		log.Println("Invalid rankings refresh interval: ", interval)
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.Refresh(ctx)
+		if err != nil {
+			log.Println("Error refreshing blog rankings: ", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh recomputes every ranking, readers keep seeing the old rankings until the new ones are saved
+func (s *Service) Refresh(ctx context.Context) error {
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	queries := s.db.WithTx(tx)
+
+	err = queries.DeleteBlogRankings(ctx)
+	if err != nil {
+		return err
+	}
+	err = queries.ComputeTrendingRanking(ctx, database.ComputeTrendingRankingParams{
+		Ranking:       Trending,
+		Gravity:       trendingGravity,
+		LikeWeight:    likeWeight,
+		CommentWeight: commentWeight,
+		ViewWeight:    viewWeight,
+		WindowHours:   trendingHours,
+	})
+	if err != nil {
+		return err
+	}
+	for window, hours := range PopularWindows {
+		err = queries.ComputePopularRanking(ctx, database.ComputePopularRankingParams{
+			Ranking:       Popular(window),
+			LikeWeight:    likeWeight,
+			CommentWeight: commentWeight,
+			ViewWeight:    viewWeight,
+			WindowHours:   hours,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,68 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// event types pushed to the clients
+const (
+	EventCommentCreated = "comment.created"
+	EventCommentEdited  = "comment.edited"
+	EventCommentDeleted = "comment.deleted"
+	EventBlogLikes      = "blog.likes"
+	EventCommentLikes   = "comment.likes"
+	EventNotification   = "notification"
+	EventTyping         = "typing"
+	EventPresenceJoined = "presence.joined"
+	EventPresenceLeft   = "presence.left"
+)
+
+// Event is a message pushed to every client subscribed to its topic
+// ActorID is the user whose action caused the event, it is empty for events like like counts which have no single actor
+type Event struct {
+	Topic   string          `json:"topic"`
+	Type    string          `json:"type"`
+	ActorID *uuid.UUID      `json:"actor_id,omitempty"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Broker delivers the published events to the subscribers of their topic
+type Broker interface {
+	Publish(ctx context.Context, event Event) error
+	Subscribe(topics ...string) *Subscription
+}
+
+// NewEvent encodes the data of an event
+func NewEvent(topic string, eventType string, data any) (Event, error) {
+	encodedData, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, err
+	}
+
+	return Event{
+		Topic: topic,
+		Type:  eventType,
+		Data:  encodedData,
+	}, nil
+}
+
+// BlogTopic is the topic carrying the new comments and like counts of a blog
+func BlogTopic(blogID uuid.UUID) string {
+	return "blog:" + blogID.String()
+}
+
+// UserTopic is the topic carrying the notifications of a user
+func UserTopic(userID uuid.UUID) string {
+	return "user:" + userID.String()
+}
+
+// WriteEvent writes the event in the server-sent events format
+func WriteEvent(w io.Writer, eventType string, data []byte) error {
+	_, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", eventType, data)
+	return err
+}
@@ -0,0 +1,93 @@
+package realtime
+
+import (
+	"context"
+	"sync"
+)
+
+// number of events buffered for a subscriber, events for a subscriber with a full buffer are dropped and the subscription is marked as lagging
+const subscriptionBufferSize = 32
+
+// Hub is an in-process broker, events only reach the subscribers connected to this server
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[*Subscription]struct{}
+}
+
+// Subscription receives the events published to its topics until it is closed
+type Subscription struct {
+	hub       *Hub
+	topics    []string
+	events    chan Event
+	lagged    chan struct{}
+	closeOnce sync.Once
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: make(map[string]map[*Subscription]struct{}),
+	}
+}
+
+// Publish delivers the event to the subscribers of its topic without waiting on slow subscribers
+func (h *Hub) Publish(ctx context.Context, event Event) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for subscription := range h.subscribers[event.Topic] {
+		select {
+		case subscription.events <- event:
+		default:
+			select {
+			case subscription.lagged <- struct{}{}:
+			default:
+			}
+		}
+	}
+	return nil
+}
+
+// Subscribe starts receiving the events published to the given topics
+func (h *Hub) Subscribe(topics ...string) *Subscription {
+	subscription := &Subscription{
+		hub:    h,
+		topics: topics,
+		events: make(chan Event, subscriptionBufferSize),
+		lagged: make(chan struct{}, 1),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, topic := range topics {
+		if h.subscribers[topic] == nil {
+			h.subscribers[topic] = make(map[*Subscription]struct{})
+		}
+		h.subscribers[topic][subscription] = struct{}{}
+	}
+	return subscription
+}
+
+// Events returns the channel on which the events of the subscription arrive
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Lagged signals that events were dropped because the subscriber did not keep up
+func (s *Subscription) Lagged() <-chan struct{} {
+	return s.lagged
+}
+
+// Close stops the subscription, it is safe to call more than once
+func (s *Subscription) Close() {
+	s.closeOnce.Do(func() {
+		s.hub.mu.Lock()
+		defer s.hub.mu.Unlock()
+		for _, topic := range s.topics {
+			delete(s.hub.subscribers[topic], s)
+			if len(s.hub.subscribers[topic]) == 0 {
+				delete(s.hub.subscribers, topic)
+			}
+		}
+		close(s.events)
+	})
+}
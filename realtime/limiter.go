@@ -0,0 +1,36 @@
+package realtime
+
+import (
+	"time"
+)
+
+// Limiter is a token bucket limiting the messages a single connection can send, it is not safe for concurrent use
+type Limiter struct {
+	tokens     float64
+	burst      float64
+	perSecond  float64
+	lastRefill time.Time
+}
+
+// NewLimiter allows burst messages at once refilled at perSecond messages every second
+func NewLimiter(perSecond float64, burst int) *Limiter {
+	return &Limiter{
+		tokens:     float64(burst),
+		burst:      float64(burst),
+		perSecond:  perSecond,
+		lastRefill: time.Now(),
+	}
+}
+
+// Allow takes a token and reports whether there was one left
+func (l *Limiter) Allow() bool {
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.lastRefill).Seconds()*l.perSecond)
+	l.lastRefill = now
+
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
@@ -0,0 +1,91 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/harshvardha/blogs/internal/database"
+	"github.com/lib/pq"
+)
+
+// postgres channel the events are sent on
+const postgresChannel = "realtime_events"
+
+// postgres rejects notification payloads of 8000 bytes or more
+const maxPostgresPayload = 7999
+
+// PostgresBroker sends the events through postgres LISTEN/NOTIFY so that they reach the subscribers on every server
+type PostgresBroker struct {
+	hub      *Hub
+	db       *database.Queries
+	listener *pq.Listener
+}
+
+// NewPostgresBroker starts listening for events on postgres, the listener reconnects on its own if the connection drops
+func NewPostgresBroker(dbURL string, db *database.Queries) (*PostgresBroker, error) {
+	listener := pq.NewListener(dbURL, 10*time.Second, time.Minute, func(eventType pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Println("Realtime listener error: ", err)
+		}
+	})
+	err := listener.Listen(postgresChannel)
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	broker := &PostgresBroker{
+		hub:      NewHub(),
+		db:       db,
+		listener: listener,
+	}
+	go broker.listen()
+	return broker, nil
+}
+
+// Publish sends the event to every server, events too large for postgres are only delivered on this server
+func (b *PostgresBroker) Publish(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if len(payload) > maxPostgresPayload {
+		log.Println("Realtime event too large for postgres, delivering locally: ", event.Type)
+		return b.hub.Publish(ctx, event)
+	}
+
+	return b.db.PublishRealtimeEvent(ctx, database.PublishRealtimeEventParams{
+		Channel: postgresChannel,
+		Payload: string(payload),
+	})
+}
+
+// Subscribe starts receiving the events published to the given topics on any server
+func (b *PostgresBroker) Subscribe(topics ...string) *Subscription {
+	return b.hub.Subscribe(topics...)
+}
+
+// Close stops listening for events
+func (b *PostgresBroker) Close() error {
+	return b.listener.Close()
+}
+
+// function to hand the events received from postgres to the local subscribers
+func (b *PostgresBroker) listen() {
+	for notification := range b.listener.Notify {
+		// a nil notification means the connection was re-established, events sent in between are lost
+		if notification == nil {
+			continue
+		}
+
+		event := Event{}
+		err := json.Unmarshal([]byte(notification.Extra), &event)
+		if err != nil {
+			log.Println("Invalid realtime event: ", err)
+			continue
+		}
+		b.hub.Publish(context.Background(), event)
+	}
+}
@@ -0,0 +1,86 @@
+package realtime
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Member is a user connected to a topic
+type Member struct {
+	UserID   uuid.UUID
+	Username string
+}
+
+// Presence keeps track of the users connected to each topic on this server, a user can be connected more than once
+// it is not shared between servers, so with the postgres broker every server only knows its own connections
+type Presence struct {
+	mu          sync.Mutex
+	connections map[string]map[uuid.UUID]*presenceEntry
+}
+
+type presenceEntry struct {
+	username    string
+	connections int
+}
+
+func NewPresence() *Presence {
+	return &Presence{
+		connections: make(map[string]map[uuid.UUID]*presenceEntry),
+	}
+}
+
+// Join records a new connection of the user and reports whether it is the first one
+func (p *Presence) Join(topic string, member Member) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.connections[topic] == nil {
+		p.connections[topic] = make(map[uuid.UUID]*presenceEntry)
+	}
+	entry, ok := p.connections[topic][member.UserID]
+	if !ok {
+		entry = &presenceEntry{
+			username: member.Username,
+		}
+		p.connections[topic][member.UserID] = entry
+	}
+	entry.connections++
+	return !ok
+}
+
+// Leave removes a connection of the user and reports whether it was the last one
+func (p *Presence) Leave(topic string, userID uuid.UUID) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	entry, ok := p.connections[topic][userID]
+	if !ok {
+		return false
+	}
+	entry.connections--
+	if entry.connections > 0 {
+		return false
+	}
+
+	delete(p.connections[topic], userID)
+	if len(p.connections[topic]) == 0 {
+		delete(p.connections, topic)
+	}
+	return true
+}
+
+// Members lists the users connected to the topic
+func (p *Presence) Members(topic string) []Member {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	members := []Member{}
+	for userID, entry := range p.connections[topic] {
+		members = append(members, Member{
+			UserID:   userID,
+			Username: entry.username,
+		})
+	}
+	return members
+}
@@ -0,0 +1,105 @@
+package recommendations
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/harshvardha/blogs/internal/database"
+)
+
+// number of recommendations kept for every user and every blog
+const (
+	MaxUsersPerUser = 50
+	MaxBlogsPerBlog = 20
+)
+
+// number of the most recent blogs of a category considered as related posts for the other blogs of that category
+// it keeps the same category pairs linear in the number of blogs instead of quadratic in the size of the category
+const CategoryCandidates = 100
+
+// number of the most recent likes of a user counted when pairing the blogs they liked
+// it keeps the pairs contributed by heavy likers linear in the number of users instead of quadratic in their likes
+const LikesPerUser = 100
+
+// reasons a user is recommended for
+const (
+	ReasonFollowedByPeopleYouFollow = "followed_by_people_you_follow"
+	ReasonWritesInCategoriesYouLike = "writes_in_categories_you_like"
+	ReasonWritesInCategoriesYouRead = "writes_in_categories_you_read"
+)
+
+// Service computes the "who to follow" and "related posts" recommendations into their tables
+// computing them is too expensive for a request so they are refreshed periodically and read by the handlers
+type Service struct {
+	conn *sql.DB
+	db   *database.Queries
+}
+
+// NewService creates the recommendation service
+func NewService(conn *sql.DB, db *database.Queries) *Service {
+	return &Service{
+		conn: conn,
+		db:   db,
+	}
+}
+
+// Run refreshes the recommendations right away and then every interval until the context is cancelled
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		log.Println("Invalid recommendations refresh interval: ", interval)
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.Refresh(ctx)
+		if err != nil {
+			log.Println("Error refreshing recommendations: ", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh recomputes every recommendation, readers keep seeing the old recommendations until the new ones are saved
+func (s *Service) Refresh(ctx context.Context) error {
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	queries := s.db.WithTx(tx)
+
+	// who to follow, from friends of friends and the authors writing in the categories the user likes or reads
+	err = queries.DeleteUserRecommendations(ctx)
+	if err != nil {
+		return err
+	}
+	err = queries.ComputeUserRecommendations(ctx, MaxUsersPerUser)
+	if err != nil {
+		return err
+	}
+
+	// related posts, from the recent blogs of the category and the users who liked both blogs
+	err = queries.DeleteRelatedBlogs(ctx)
+	if err != nil {
+		return err
+	}
+	err = queries.ComputeRelatedBlogs(ctx, database.ComputeRelatedBlogsParams{
+		LikesPerUser:          LikesPerUser,
+		CandidatesPerCategory: CategoryCandidates,
+		MaxPerBlog:            MaxBlogsPerBlog,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,55 @@
+-- name: BlockUser :exec
+insert into user_blocks (blocker_id, blocked_id, created_at)
+values ($1, $2, NOW())
+on conflict do nothing;
+
+-- name: UnblockUser :exec
+delete from user_blocks where blocker_id = $1 and blocked_id = $2;
+
+-- name: GetBlockedUsers :many
+select users.id, users.username, users.display_name, users.avatar_url, user_blocks.created_at
+    from user_blocks join users on users.id = user_blocks.blocked_id
+    where user_blocks.blocker_id = $1
+    order by user_blocks.created_at desc
+    limit $2 offset $3;
+
+-- name: IsBlockedEitherWay :one
+select exists(
+    select 1 from user_blocks
+    where (blocker_id = sqlc.arg(user_id) and blocked_id = sqlc.arg(other_user_id))
+        or (blocker_id = sqlc.arg(other_user_id) and blocked_id = sqlc.arg(user_id))
+);
+
+-- name: GetBlockedEitherWayUserIds :many
+select user_blocks.blocked_id as user_id from user_blocks where user_blocks.blocker_id = sqlc.arg(user_id)
+union
+select user_blocks.blocker_id from user_blocks where user_blocks.blocked_id = sqlc.arg(user_id);
+
+-- name: DeleteFollowsBetween :exec
+delete from users_follow
+    where (follower_id = sqlc.arg(user_id) and following_id = sqlc.arg(other_user_id))
+        or (follower_id = sqlc.arg(other_user_id) and following_id = sqlc.arg(user_id));
+
+-- name: MuteUser :exec
+insert into user_mutes (muter_id, muted_id, created_at)
+values ($1, $2, NOW())
+on conflict do nothing;
+
+-- name: UnmuteUser :exec
+delete from user_mutes where muter_id = $1 and muted_id = $2;
+
+-- name: GetMutedUsers :many
+select users.id, users.username, users.display_name, users.avatar_url, user_mutes.created_at
+    from user_mutes join users on users.id = user_mutes.muted_id
+    where user_mutes.muter_id = $1
+    order by user_mutes.created_at desc
+    limit $2 offset $3;
+
+-- name: IsNotificationSuppressed :one
+select (exists(
+    select 1 from user_blocks
+    where (blocker_id = sqlc.arg(user_id) and blocked_id = sqlc.arg(actor_id))
+        or (blocker_id = sqlc.arg(actor_id) and blocked_id = sqlc.arg(user_id))
+) or exists(
+    select 1 from user_mutes where muter_id = sqlc.arg(user_id) and muted_id = sqlc.arg(actor_id)
+))::boolean as suppressed;
@@ -0,0 +1,55 @@
+-- name: GetBlogRole :one
+select (case when blogs.author_id = sqlc.arg(user_id) then 'owner'
+    else coalesce((select blog_contributors.role from blog_contributors
+        where blog_contributors.blog_id = blogs.id and blog_contributors.user_id = sqlc.arg(user_id) and blog_contributors.status = 'accepted'),
+        (select 'reviewer' from blog_reviewers where blog_reviewers.blog_id = blogs.id and blog_reviewers.reviewer_id = sqlc.arg(user_id)),
+        (select 'editor' from publication_members where publication_members.publication_id = blogs.publication_id and publication_members.user_id = sqlc.arg(user_id) and publication_members.role in ('owner', 'editor') and publication_members.status = 'accepted'), '') end)::text as role
+    from blogs where blogs.id = sqlc.arg(blog_id);
+
+-- name: InviteBlogContributor :one
+insert into blog_contributors (blog_id, user_id, role, status, invited_by, created_at, updated_at)
+values ($1, $2, $3, 'invited', $4, NOW(), NOW())
+on conflict (blog_id, user_id) do update set role = excluded.role, updated_at = NOW()
+returning *;
+
+-- name: AcceptBlogInvite :one
+update blog_contributors set status = 'accepted', updated_at = NOW() where blog_id = $1 and user_id = $2 and status = 'invited'
+returning *;
+
+-- name: RemoveBlogContributor :execrows
+delete from blog_contributors where blog_id = $1 and user_id = $2;
+
+-- name: GetBlogContributors :many
+select users.id,
+    users.username,
+    users.display_name,
+    users.avatar_url,
+    blog_contributors.role,
+    blog_contributors.status,
+    blog_contributors.created_at
+    from blog_contributors join users on users.id = blog_contributors.user_id
+    where blog_contributors.blog_id = $1
+    order by blog_contributors.created_at;
+
+-- name: GetBlogAuthors :many
+select authors.id, authors.username, authors.display_name, authors.avatar_url, authors.role from (
+    select users.id, users.username, users.display_name, users.avatar_url, 'owner'::text as role, blogs.created_at as since
+        from blogs join users on users.id = blogs.author_id
+        where blogs.id = sqlc.arg(blog_id)
+    union all
+    select users.id, users.username, users.display_name, users.avatar_url, blog_contributors.role, blog_contributors.updated_at as since
+        from blog_contributors join users on users.id = blog_contributors.user_id
+        where blog_contributors.blog_id = sqlc.arg(blog_id) and blog_contributors.role = 'co-author' and blog_contributors.status = 'accepted'
+) as authors
+order by authors.since;
+
+-- name: GetBlogInvites :many
+select blogs.id,
+    blogs.title,
+    blogs.author_id,
+    users.username as author_name,
+    blog_contributors.role,
+    blog_contributors.created_at
+    from blog_contributors join blogs on blogs.id = blog_contributors.blog_id join users on users.id = blogs.author_id
+    where blog_contributors.user_id = $1 and blog_contributors.status = 'invited'
+    order by blog_contributors.created_at desc;
@@ -0,0 +1,29 @@
+-- name: RecordBlogViews :exec
+insert into blog_views (blog_id, viewer_key, view_window, viewed_at)
+select unnest(sqlc.arg(blog_ids)::uuid[]), unnest(sqlc.arg(viewer_keys)::text[]), unnest(sqlc.arg(view_windows)::bigint[]), NOW()
+on conflict do nothing;
+
+-- name: GetAuthorBlogStats :many
+select blogs.id,
+    blogs.title,
+    blogs.created_at,
+    (select count(*) from blog_views where blog_views.blog_id = blogs.id and blog_views.viewed_at > NOW() - make_interval(days => sqlc.arg(days)::int)) as views,
+    (select count(distinct blog_views.viewer_key) from blog_views where blog_views.blog_id = blogs.id and blog_views.viewed_at > NOW() - make_interval(days => sqlc.arg(days)::int)) as unique_readers,
+    (select count(*) from likes where likes.blog_id = blogs.id and likes.created_at > NOW() - make_interval(days => sqlc.arg(days)::int)) as likes,
+    (select count(*) from comments where comments.blog_id = blogs.id and comments.status = 'approved' and comments.created_at > NOW() - make_interval(days => sqlc.arg(days)::int)) as comments
+    from blogs where blogs.author_id = sqlc.arg(author_id)
+    order by blogs.created_at desc;
+
+-- name: GetAuthorDailyStats :many
+select days.day::date as day,
+    (select count(*) from blog_views join blogs on blogs.id = blog_views.blog_id
+        where blogs.author_id = sqlc.arg(author_id) and (sqlc.narg(blog_id)::uuid is null or blogs.id = sqlc.narg(blog_id)::uuid) and blog_views.viewed_at::date = days.day::date) as views,
+    (select count(distinct blog_views.viewer_key) from blog_views join blogs on blogs.id = blog_views.blog_id
+        where blogs.author_id = sqlc.arg(author_id) and (sqlc.narg(blog_id)::uuid is null or blogs.id = sqlc.narg(blog_id)::uuid) and blog_views.viewed_at::date = days.day::date) as unique_readers,
+    (select count(*) from likes join blogs on blogs.id = likes.blog_id
+        where blogs.author_id = sqlc.arg(author_id) and (sqlc.narg(blog_id)::uuid is null or blogs.id = sqlc.narg(blog_id)::uuid) and likes.created_at::date = days.day::date) as likes,
+    (select count(*) from comments join blogs on blogs.id = comments.blog_id
+        where blogs.author_id = sqlc.arg(author_id) and (sqlc.narg(blog_id)::uuid is null or blogs.id = sqlc.narg(blog_id)::uuid) and comments.status = 'approved' and comments.created_at::date = days.day::date) as comments,
+    (select count(*) from users_follow where users_follow.following_id = sqlc.arg(author_id) and users_follow.created_at::date = days.day::date) as new_followers
+    from generate_series((NOW() - make_interval(days => sqlc.arg(days)::int - 1))::date, NOW()::date, interval '1 day') as days(day)
+    order by days.day;
@@ -6,6 +6,9 @@ insert into blogs(
     thumbnail_url, 
     content, 
     category, 
+    visibility,
+    is_published,
+    publication_id,
     created_at, 
     updated_at
 )
@@ -16,13 +19,16 @@ values (
     $3,
     $4,
     $5,
+    $6,
+    $7,
+    $8,
     NOW(),
     NOW()
 )
 returning *;
 
 -- name: EditBlog :one
-update blogs set title = $1, thumbnail_url = $2, content = $3, category = $4, updated_at = NOW() where id = $5
+update blogs set title = $1, thumbnail_url = $2, content = $3, category = $4, visibility = $5, updated_at = NOW() where id = $6
 returning *;
 
 -- name: DeleteBlog :one
@@ -38,12 +44,17 @@ select blogs.id,
     blogs.category,
     blogs.created_at,
     blogs.updated_at,
+    blogs.moderation_status,
+    blogs.visibility,
+    blogs.is_published,
+    blogs.review_status,
+    blogs.publication_id,
     count(likes.blog_id) as likes_count 
     from blogs left join likes on blogs.id = likes.blog_id 
     where blogs.id = $1 group by blogs.id, blogs.title;
 
 -- name: GetBlogsByAuthorId :many
-select blogs.id, blogs.title, blogs.author_id, blogs.content, blogs.thumbnail_url, blogs.category, blogs.created_at, blogs.updated_at, count(likes.blog_id) as likes_count from blogs left join likes on blogs.id = likes.blog_id where blogs.author_id = $1 group by blogs.id, blogs.title, blogs.author_id, blogs.thumbnail_url;
+select blogs.id, blogs.title, blogs.author_id, blogs.content, blogs.thumbnail_url, blogs.category, blogs.visibility, blogs.is_published, blogs.review_status, blogs.created_at, blogs.updated_at, count(likes.blog_id) as likes_count from blogs left join likes on blogs.id = likes.blog_id where blogs.author_id = $1 group by blogs.id, blogs.title, blogs.author_id, blogs.thumbnail_url;
 
 -- name: LikeBlog :exec
 insert into likes (user_id, blog_id, created_at, updated_at)
@@ -59,13 +70,36 @@ select count(*) from likes where blog_id = $1;
 select * from likes where user_id = $1 and blog_id = $2;
 
 -- name: GetBlogsByTitle :many
-select id, title, author_id, thumbnail_url from blogs where title = $1;
+select blogs.id, blogs.title, blogs.author_id, blogs.thumbnail_url from blogs where blogs.title = $1 and blogs.is_published and blogs.moderation_status in ('visible', 'flagged')
+    and blogs.author_id not in (select user_blocks.blocked_id from user_blocks where user_blocks.blocker_id = sqlc.arg(viewer_id))
+    and blogs.author_id not in (select user_blocks.blocker_id from user_blocks where user_blocks.blocked_id = sqlc.arg(viewer_id))
+    and (blogs.author_id = sqlc.arg(viewer_id)
+        or blogs.author_id not in (select users.id from users where users.is_private)
+        or blogs.author_id in (select users_follow.following_id from users_follow where users_follow.follower_id = sqlc.arg(viewer_id)))
+    and (blogs.visibility = 'public'
+        or (blogs.author_id = sqlc.arg(viewer_id) and blogs.visibility <> 'unlisted')
+        or (blogs.visibility = 'followers' and blogs.author_id in (select users_follow.following_id from users_follow where users_follow.follower_id = sqlc.arg(viewer_id))));
 
 -- name: GetBlogsByCategory :many
-select blogs.id, blogs.title, blogs.author_id, blogs.thumbnail_url, count(likes.blog_id) as likes_count from blogs left join likes on blogs.id = likes.blog_id where blogs.category = $1 group by blogs.id, blogs.title, blogs.author_id, blogs.thumbnail_url;
+select blogs.id, blogs.title, blogs.author_id, blogs.thumbnail_url, count(likes.blog_id) as likes_count from blogs left join likes on blogs.id = likes.blog_id where blogs.category = $1 and blogs.is_published and blogs.moderation_status in ('visible', 'flagged')
+    and blogs.author_id not in (select user_blocks.blocked_id from user_blocks where user_blocks.blocker_id = sqlc.arg(viewer_id))
+    and blogs.author_id not in (select user_blocks.blocker_id from user_blocks where user_blocks.blocked_id = sqlc.arg(viewer_id))
+    and (blogs.author_id = sqlc.arg(viewer_id)
+        or blogs.author_id not in (select users.id from users where users.is_private)
+        or blogs.author_id in (select users_follow.following_id from users_follow where users_follow.follower_id = sqlc.arg(viewer_id)))
+    and (blogs.visibility = 'public'
+        or (blogs.author_id = sqlc.arg(viewer_id) and blogs.visibility <> 'unlisted')
+        or (blogs.visibility = 'followers' and blogs.author_id in (select users_follow.following_id from users_follow where users_follow.follower_id = sqlc.arg(viewer_id))))
+    group by blogs.id, blogs.title, blogs.author_id, blogs.thumbnail_url;
 
 -- name: GetAuthorNameByBlogId :one
 select username from users join blogs on users.id = blogs.author_id where blogs.id = $1;
 
 -- name: GetBlogNameById :one
-select title from blogs where id = $1;
\ No newline at end of file
+select title from blogs where id = $1;
+
+-- name: GetBlogAuthorId :one
+select author_id from blogs where id = $1;
+
+-- name: SetBlogModerationStatus :exec
+update blogs set moderation_status = $1 where id = $2;
\ No newline at end of file
@@ -1,8 +1,9 @@
 -- name: CreateCategory :one
-insert into categories (id, category_name, created_at, updated_at)
+insert into categories (id, category_name, publication_id, created_at, updated_at)
 values (
     gen_random_uuid(),
     $1,
+    $2,
     NOW(),
     NOW()
 )
@@ -17,7 +18,14 @@ delete from categories where id = $1
 returning *;
 
 -- name: GetCategoryIdByName :one
-select id from categories where category_name = $1;
+select id from categories where category_name = sqlc.arg(category_name) and publication_id is not distinct from sqlc.narg(publication_id)::uuid;
+
+-- name: GetCategoryById :one
+select * from categories where id = $1;
+
+-- name: SetCategoryRequiresReview :one
+update categories set requires_review = $1, updated_at = NOW() where id = $2
+returning *;
 
 -- name: GetCategoryNameById :one
 select category_name from categories where id = $1;
\ No newline at end of file
@@ -1,8 +1,11 @@
 -- name: AddBlogToCollection :one
-insert into collection_blog (collection_id, blog_id, created_at, updated_at)
+insert into collection_blog (collection_id, blog_id, added_by, note, position, created_at, updated_at)
 values (
-    $1,
-    $2,
+    sqlc.arg(collection_id),
+    sqlc.arg(blog_id),
+    sqlc.arg(added_by),
+    sqlc.arg(note),
+    coalesce((select max(collection_blog.position) from collection_blog where collection_blog.collection_id = sqlc.arg(collection_id)), 0) + sqlc.arg(position_gap)::bigint,
     NOW(),
     NOW()
 )
@@ -10,4 +13,19 @@ returning *;
 
 -- name: RemoveBlogFromCollection :one
 delete from collection_blog where collection_id = $1 and blog_id = $2
-returning *;
\ No newline at end of file
+returning *;
+
+-- name: GetCollectionItemPositions :many
+select blog_id, position from collection_blog where collection_id = $1 order by position;
+
+-- name: SetCollectionItemPosition :exec
+update collection_blog set position = $1, updated_at = NOW() where collection_id = $2 and blog_id = $3;
+
+-- name: RenumberCollectionItems :exec
+update collection_blog set position = ranked.rank * sqlc.arg(position_gap)::bigint
+    from (select collection_blog.blog_id, row_number() over (order by collection_blog.position) as rank from collection_blog where collection_blog.collection_id = sqlc.arg(collection_id)) as ranked
+    where collection_blog.collection_id = sqlc.arg(collection_id) and collection_blog.blog_id = ranked.blog_id;
+
+-- name: SetCollectionItemNote :one
+update collection_blog set note = $1, updated_at = NOW() where collection_id = $2 and blog_id = $3
+returning *;
@@ -0,0 +1,61 @@
+-- name: GetCollectionRole :one
+select (case when collections.user_id = sqlc.arg(user_id) then 'owner'
+    else coalesce((select collection_members.role from collection_members
+        where collection_members.collection_id = collections.id and collection_members.user_id = sqlc.arg(user_id) and collection_members.status = 'accepted'), '') end)::text as role
+    from collections where collections.id = sqlc.arg(collection_id);
+
+-- name: InviteCollectionMember :one
+insert into collection_members (collection_id, user_id, role, status, invited_by, created_at, updated_at)
+values ($1, $2, $3, 'invited', $4, NOW(), NOW())
+on conflict (collection_id, user_id) do update set role = excluded.role, updated_at = NOW()
+returning *;
+
+-- name: AcceptCollectionInvite :one
+update collection_members set status = 'accepted', updated_at = NOW() where collection_id = $1 and user_id = $2 and status = 'invited'
+returning *;
+
+-- name: RemoveCollectionMember :execrows
+delete from collection_members where collection_id = $1 and user_id = $2;
+
+-- name: GetCollectionMembers :many
+select users.id,
+    users.username,
+    users.display_name,
+    users.avatar_url,
+    collection_members.role,
+    collection_members.status,
+    collection_members.created_at
+    from collection_members join users on users.id = collection_members.user_id
+    where collection_members.collection_id = $1
+    order by collection_members.created_at;
+
+-- name: GetCollectionInvites :many
+select collections.id,
+    collections.name,
+    collections.user_id,
+    users.username as owner_name,
+    collection_members.role,
+    collection_members.created_at
+    from collection_members join collections on collections.id = collection_members.collection_id join users on users.id = collections.user_id
+    where collection_members.user_id = $1 and collection_members.status = 'invited'
+    order by collection_members.created_at desc;
+
+-- name: GetSharedCollectionsByUserId :many
+select collections.*
+    from collections join collection_members on collection_members.collection_id = collections.id
+    where collection_members.user_id = $1 and collection_members.status = 'accepted'
+    order by collections.updated_at desc;
+
+-- name: TransferCollectionOwnership :one
+with new_owner as (
+    delete from collection_members
+    where collection_members.collection_id = sqlc.arg(collection_id) and collection_members.user_id = sqlc.arg(new_owner_id) and collection_members.status = 'accepted'
+    returning collection_members.collection_id
+), previous_owner as (
+    insert into collection_members (collection_id, user_id, role, status, invited_by, created_at, updated_at)
+    select new_owner.collection_id, sqlc.arg(owner_id), 'editor', 'accepted', sqlc.arg(new_owner_id), NOW(), NOW() from new_owner
+    returning collection_members.collection_id
+)
+update collections set user_id = sqlc.arg(new_owner_id), updated_at = NOW()
+where collections.id in (select previous_owner.collection_id from previous_owner)
+returning *;
@@ -1,16 +1,20 @@
 -- name: CreateCollection :one
-insert into collections (id, name, user_id, created_at, updated_at)
+insert into collections (id, name, user_id, visibility, share_token, description, cover_url, created_at, updated_at)
 values (
     gen_random_uuid(),
     $1,
     $2,
+    $3,
+    $4,
+    $5,
+    $6,
     NOW(),
     NOW()
 )
 returning *;
 
 -- name: EditCollection :one
-update collections set name = $1, updated_at = NOW() where id = $2
+update collections set name = $1, description = $2, cover_url = $3, updated_at = NOW() where id = $4
 returning *;
 
 -- name: DeleteCollection :one
@@ -21,10 +25,54 @@ returning *;
 select * from collections where user_id = $1;
 
 -- name: GetAllBlogsByCollectionId :many
-select blogs.id, blogs.title, blogs.author_id, users.username as author_name, blogs.thumbnail_url, blogs.content, blogs.category, categories.category_name, blogs.created_at, blogs.updated_at from collections join collection_blog on collections.id = collection_blog.collection_id join blogs on collection_blog.blog_id = blogs.id join users on blogs.author_id = users.id join categories on blogs.category = categories.id where collections.id = $1;
+select blogs.id, blogs.title, blogs.author_id, users.username as author_name, blogs.thumbnail_url, blogs.content, blogs.category, categories.category_name, blogs.created_at, blogs.updated_at, collection_blog.added_by, collection_blog.note, collection_blog.position from collections join collection_blog on collections.id = collection_blog.collection_id join blogs on collection_blog.blog_id = blogs.id join users on blogs.author_id = users.id join categories on blogs.category = categories.id where collections.id = $1 and blogs.is_published and blogs.moderation_status in ('visible', 'flagged')
+    and blogs.author_id not in (select user_blocks.blocked_id from user_blocks where user_blocks.blocker_id = sqlc.arg(viewer_id))
+    and blogs.author_id not in (select user_blocks.blocker_id from user_blocks where user_blocks.blocked_id = sqlc.arg(viewer_id))
+    and (blogs.author_id = sqlc.arg(viewer_id)
+        or blogs.author_id not in (select users.id from users where users.is_private)
+        or blogs.author_id in (select users_follow.following_id from users_follow where users_follow.follower_id = sqlc.arg(viewer_id)))
+    and (blogs.visibility in ('public', 'unlisted')
+        or blogs.author_id = sqlc.arg(viewer_id)
+        or (blogs.visibility = 'followers' and blogs.author_id in (select users_follow.following_id from users_follow where users_follow.follower_id = sqlc.arg(viewer_id))))
+    order by collection_blog.position;
 
 -- name: GetOwnerId :one
 select user_id from collections where id = $1;
 
 -- name: GetCollectionNameById :one
-select name from collections where id = $1;
\ No newline at end of file
+select name from collections where id = $1;
+
+-- name: GetCollectionById :one
+select * from collections where id = $1;
+
+-- name: SetCollectionVisibility :one
+update collections set visibility = $1, share_token = $2, updated_at = NOW() where id = $3
+returning *;
+
+-- name: GetPublicCollectionsByUserId :many
+select collections.id,
+    collections.name,
+    collections.user_id,
+    collections.visibility,
+    collections.description,
+    collections.cover_url,
+    collections.created_at,
+    collections.updated_at,
+    (select count(*) from collection_blog where collection_blog.collection_id = collections.id) as blogs_count,
+    (select count(*) from collection_follows where collection_follows.collection_id = collections.id) as followers_count
+    from collections where collections.user_id = $1 and collections.visibility = 'public'
+    order by collections.updated_at desc
+    limit sqlc.arg('limit') offset sqlc.arg('offset');
+
+-- name: FollowCollection :exec
+insert into collection_follows (user_id, collection_id, created_at)
+values ($1, $2, NOW());
+
+-- name: UnfollowCollection :execrows
+delete from collection_follows where user_id = $1 and collection_id = $2;
+
+-- name: IsCollectionFollowed :one
+select exists(select 1 from collection_follows where user_id = $1 and collection_id = $2);
+
+-- name: CountCollectionFollowers :one
+select count(*) from collection_follows where collection_id = $1;
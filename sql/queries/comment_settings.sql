@@ -0,0 +1,14 @@
+-- name: GetCommentSettings :one
+select * from blog_comment_settings where blog_id = $1;
+
+-- name: UpsertCommentSettings :one
+insert into blog_comment_settings (blog_id, comment_policy, require_approval, created_at, updated_at)
+values (
+    $1,
+    $2,
+    $3,
+    NOW(),
+    NOW()
+)
+on conflict (blog_id) do update set comment_policy = excluded.comment_policy, require_approval = excluded.require_approval, updated_at = NOW()
+returning *;
@@ -1,10 +1,12 @@
 -- name: CreateComment :one
-insert into comments (id, description, blog_id, user_id, created_at, updated_at)
+insert into comments (id, description, blog_id, user_id, status, parent_id, created_at, updated_at)
 values (
     gen_random_uuid(),
     $1,
     $2,
     $3,
+    $4,
+    $5,
     NOW(),
     NOW()
 )
@@ -30,14 +32,41 @@ select comments.id,
     comments.description, 
     comments.blog_id, 
     comments.user_id, 
+    comments.status,
+    comments.is_pinned,
+    comments.parent_id,
     comments.created_at, 
     comments.updated_at, 
-    count(comment_likes.comment_id) as likes_count 
-    from comments left join comment_likes on comments.id = comment_likes.comment_id where comments.blog_id = $1
-    group by comments.id;
+    count(comment_likes.comment_id) as likes_count,
+    exists(select 1 from comment_likes as my_likes where my_likes.comment_id = comments.id and my_likes.user_id = $2)::boolean as liked_by_me
+    from comments left join comment_likes on comments.id = comment_likes.comment_id where comments.blog_id = $1 and (comments.status = 'approved' or (comments.status = 'shadow_hidden' and comments.user_id = $2))
+        and comments.user_id not in (select user_blocks.blocked_id from user_blocks where user_blocks.blocker_id = $2)
+        and comments.user_id not in (select user_blocks.blocker_id from user_blocks where user_blocks.blocked_id = $2)
+    group by comments.id
+    order by comments.is_pinned desc, comments.created_at;
 
 -- name: GetCommentById :one
 select * from comments where id = $1;
 
 -- name: IsCommentLiked :one
-select user_id, comment_id from comment_likes where user_id = $1 and comment_id = $2;
\ No newline at end of file
+select user_id, comment_id from comment_likes where user_id = $1 and comment_id = $2;
+
+-- name: GetPendingCommentsByBlogId :many
+select * from comments where blog_id = $1 and status = 'pending' order by created_at;
+
+-- name: UpdateCommentStatus :one
+update comments set status = $1, moderated_by = $2, moderated_at = NOW() where id = $3
+returning *;
+
+-- name: SetCommentStatus :one
+update comments set status = $1 where id = $2
+returning *;
+
+-- name: UnpinCommentsByBlogId :exec
+update comments set is_pinned = false where blog_id = $1 and is_pinned = true;
+
+-- name: SetCommentPinned :one
+update comments set is_pinned = $1 where id = $2
+returning *;
+-- name: GetNoOfCommentLikes :one
+select count(*) from comment_likes where comment_id = $1;
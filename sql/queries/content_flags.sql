@@ -0,0 +1,20 @@
+-- name: CreateContentFlag :one
+insert into content_flags (id, entity_type, entity_id, user_id, action, reason, created_at, updated_at)
+values (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    NOW(),
+    NOW()
+)
+returning *;
+
+-- name: GetUnreviewedContentFlags :many
+select * from content_flags where reviewed_at is null order by created_at limit $1 offset $2;
+
+-- name: MarkContentFlagReviewed :one
+update content_flags set reviewed_by = $1, reviewed_at = NOW(), updated_at = NOW() where id = $2
+returning *;
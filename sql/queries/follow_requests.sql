@@ -0,0 +1,39 @@
+-- name: CreateFollowRequest :exec
+insert into follow_requests (requester_id, target_id, created_at)
+values ($1, $2, NOW())
+on conflict do nothing;
+
+-- name: DeleteFollowRequest :execrows
+delete from follow_requests where requester_id = $1 and target_id = $2;
+
+-- name: IsFollowRequested :one
+select exists(select 1 from follow_requests where requester_id = $1 and target_id = $2);
+
+-- name: GetIncomingFollowRequests :many
+select users.id, users.username, users.display_name, users.avatar_url, follow_requests.created_at
+    from follow_requests join users on users.id = follow_requests.requester_id
+    where follow_requests.target_id = $1
+    order by follow_requests.created_at desc
+    limit $2 offset $3;
+
+-- name: ApproveAllFollowRequests :many
+with approved as (
+    delete from follow_requests where target_id = $1
+    returning requester_id, target_id
+), followed as (
+    insert into users_follow (follower_id, following_id, created_at, updated_at)
+    select requester_id, target_id, NOW(), NOW() from approved
+    on conflict do nothing
+)
+select requester_id from approved;
+
+-- name: DeleteFollowRequestsBetween :exec
+delete from follow_requests
+    where (requester_id = sqlc.arg(user_id) and target_id = sqlc.arg(other_user_id))
+        or (requester_id = sqlc.arg(other_user_id) and target_id = sqlc.arg(user_id));
+
+-- name: CanViewAuthorContent :one
+select (users.id = sqlc.arg(viewer_id)
+    or not users.is_private
+    or exists(select 1 from users_follow where users_follow.follower_id = sqlc.arg(viewer_id) and users_follow.following_id = users.id))::boolean as allowed
+    from users where users.id = sqlc.arg(author_id);
@@ -0,0 +1,26 @@
+-- name: CreateMention :exec
+insert into mentions (id, source_type, source_id, mentioned_user_id, mentioner_id, created_at)
+values (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    NOW()
+)
+on conflict (source_type, source_id, mentioned_user_id) do nothing;
+
+-- name: DeleteMention :exec
+delete from mentions where source_type = $1 and source_id = $2 and mentioned_user_id = $3;
+
+-- name: DeleteMentionsBySource :exec
+delete from mentions where source_type = $1 and source_id = $2;
+
+-- name: GetMentionsBySource :many
+select mentions.mentioned_user_id, users.username from mentions join users on mentions.mentioned_user_id = users.id where mentions.source_type = $1 and mentions.source_id = $2;
+
+-- name: GetMentionsOfUser :many
+select mentions.source_type, mentions.source_id, mentions.mentioner_id, users.username as mentioner_name, mentions.created_at from mentions join users on mentions.mentioner_id = users.id where mentions.mentioned_user_id = $1 order by mentions.created_at desc limit $2 offset $3;
+
+-- name: DeleteCommentMentionsByBlogId :exec
+delete from mentions where source_type = 'comment' and source_id in (select id from comments where blog_id = $1);
@@ -0,0 +1,51 @@
+-- name: CreateNotification :exec
+insert into notifications (id, user_id, actor_id, type, entity_type, entity_id, created_at)
+values (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    NOW()
+);
+
+-- name: DeleteNotification :exec
+delete from notifications where user_id = $1 and actor_id = $2 and type = $3 and entity_id = $4;
+
+-- name: GetNotificationGroups :many
+select notifications.type,
+    notifications.entity_type,
+    notifications.entity_id,
+    count(distinct notifications.actor_id) as actors_count,
+    max(notifications.created_at)::timestamp as latest_at,
+    bool_or(notifications.read_at is null) as unread,
+    (array_agg(users.username order by notifications.created_at desc))[1]::text as latest_actor_name
+    from notifications join users on notifications.actor_id = users.id
+    where notifications.user_id = $1
+    group by notifications.type, notifications.entity_type, notifications.entity_id
+    order by latest_at desc
+    limit $2 offset $3;
+
+-- name: CountUnreadNotificationGroups :one
+select count(*) from (
+    select notifications.type from notifications where notifications.user_id = $1 and notifications.read_at is null
+    group by notifications.type, notifications.entity_type, notifications.entity_id
+) as unread_groups;
+
+-- name: MarkNotificationGroupRead :exec
+update notifications set read_at = NOW() where user_id = $1 and type = $2 and entity_type = $3 and entity_id = $4 and read_at is null;
+
+-- name: MarkAllNotificationsRead :exec
+update notifications set read_at = NOW() where user_id = $1 and read_at is null;
+
+-- name: GetNotificationPreference :one
+select enabled from notification_preferences where user_id = $1 and type = $2;
+
+-- name: GetNotificationPreferences :many
+select type, enabled from notification_preferences where user_id = $1;
+
+-- name: UpsertNotificationPreferences :exec
+insert into notification_preferences (user_id, type, enabled, created_at, updated_at)
+select sqlc.arg(user_id), unnest(sqlc.arg(types)::text[]), unnest(sqlc.arg(enabled)::boolean[]), NOW(), NOW()
+on conflict (user_id, type) do update set enabled = excluded.enabled, updated_at = NOW();
@@ -0,0 +1,102 @@
+-- name: CreatePublication :one
+with new_publication as (
+    insert into publications (id, slug, name, description, created_at, updated_at)
+    values (
+        gen_random_uuid(),
+        sqlc.arg(slug),
+        sqlc.arg(name),
+        sqlc.arg(description),
+        NOW(),
+        NOW()
+    )
+    returning *
+), owner as (
+    insert into publication_members (publication_id, user_id, role, created_at, updated_at)
+    select new_publication.id, sqlc.arg(owner_id), 'owner', NOW(), NOW() from new_publication
+)
+select new_publication.id,
+    new_publication.slug,
+    new_publication.name,
+    new_publication.description,
+    new_publication.created_at,
+    new_publication.updated_at
+    from new_publication;
+
+-- name: EditPublication :one
+update publications set name = $1, description = $2, updated_at = NOW() where id = $3
+returning *;
+
+-- name: GetPublicationBySlug :one
+select * from publications where slug = $1;
+
+-- name: GetPublicationRole :one
+select role from publication_members where publication_id = $1 and user_id = $2 and status = 'accepted';
+
+-- name: InvitePublicationMember :one
+insert into publication_members (publication_id, user_id, role, status, invited_by, created_at, updated_at)
+values ($1, $2, $3, 'invited', $4, NOW(), NOW())
+on conflict (publication_id, user_id) do update set role = excluded.role, updated_at = NOW()
+returning *;
+
+-- name: AcceptPublicationInvite :one
+update publication_members set status = 'accepted', updated_at = NOW() where publication_id = $1 and user_id = $2 and status = 'invited'
+returning *;
+
+-- name: GetPublicationInvites :many
+select publications.id,
+    publications.slug,
+    publications.name,
+    publication_members.role,
+    publication_members.invited_by,
+    publication_members.created_at
+    from publication_members join publications on publications.id = publication_members.publication_id
+    where publication_members.user_id = $1 and publication_members.status = 'invited'
+    order by publication_members.created_at desc;
+
+-- name: RemovePublicationMember :execrows
+delete from publication_members where publication_id = $1 and user_id = $2 and role <> 'owner';
+
+-- name: GetPublicationMembers :many
+select users.id,
+    users.username,
+    users.display_name,
+    users.avatar_url,
+    publication_members.role,
+    publication_members.status,
+    publication_members.created_at
+    from publication_members join users on users.id = publication_members.user_id
+    where publication_members.publication_id = $1
+    order by publication_members.created_at;
+
+-- name: GetPublicationsByUserId :many
+select publications.*, publication_members.role
+    from publication_members join publications on publications.id = publication_members.publication_id
+    where publication_members.user_id = $1 and publication_members.status = 'accepted'
+    order by publications.name;
+
+-- name: GetPublicationCategories :many
+select * from categories where publication_id = $1 order by category_name;
+
+-- name: GetPublicationBlogs :many
+select blogs.id,
+    blogs.title,
+    blogs.author_id,
+    users.username as author_name,
+    blogs.thumbnail_url,
+    categories.category_name,
+    blogs.created_at,
+    (select count(*) from likes where likes.blog_id = blogs.id) as likes_count
+    from blogs join users on users.id = blogs.author_id join categories on categories.id = blogs.category
+    where blogs.publication_id = sqlc.arg(publication_id)
+    and (sqlc.narg(category)::uuid is null or blogs.category = sqlc.narg(category)::uuid)
+    and blogs.is_published and blogs.moderation_status in ('visible', 'flagged')
+    and blogs.author_id not in (select user_blocks.blocked_id from user_blocks where user_blocks.blocker_id = sqlc.arg(viewer_id))
+    and blogs.author_id not in (select user_blocks.blocker_id from user_blocks where user_blocks.blocked_id = sqlc.arg(viewer_id))
+    and (blogs.author_id = sqlc.arg(viewer_id)
+        or blogs.author_id not in (select users.id from users where users.is_private)
+        or blogs.author_id in (select users_follow.following_id from users_follow where users_follow.follower_id = sqlc.arg(viewer_id)))
+    and (blogs.visibility = 'public'
+        or (blogs.author_id = sqlc.arg(viewer_id) and blogs.visibility <> 'unlisted')
+        or (blogs.visibility = 'followers' and blogs.author_id in (select users_follow.following_id from users_follow where users_follow.follower_id = sqlc.arg(viewer_id))))
+    order by blogs.created_at desc
+    limit sqlc.arg('limit') offset sqlc.arg('offset');
@@ -0,0 +1,49 @@
+-- name: DeleteBlogRankings :exec
+delete from blog_rankings;
+
+-- name: ComputeTrendingRanking :exec
+insert into blog_rankings (ranking, blog_id, score, computed_at)
+select sqlc.arg(ranking)::text,
+    interactions.blog_id,
+    sum(interactions.weight / power(extract(epoch from NOW() - interactions.created_at) / 3600 + 2, sqlc.arg(gravity)::float8))::float8,
+    NOW()
+    from (
+        select likes.blog_id, likes.created_at, sqlc.arg(like_weight)::float8 as weight from likes where likes.created_at > NOW() - make_interval(hours => sqlc.arg(window_hours)::int)
+        union all
+        select comments.blog_id, comments.created_at, sqlc.arg(comment_weight)::float8 from comments where comments.status = 'approved' and comments.created_at > NOW() - make_interval(hours => sqlc.arg(window_hours)::int)
+        union all
+        select blog_views.blog_id, blog_views.viewed_at, sqlc.arg(view_weight)::float8 from blog_views where blog_views.viewed_at > NOW() - make_interval(hours => sqlc.arg(window_hours)::int)
+    ) as interactions
+    group by interactions.blog_id;
+
+-- name: ComputePopularRanking :exec
+insert into blog_rankings (ranking, blog_id, score, computed_at)
+select sqlc.arg(ranking)::text, interactions.blog_id, sum(interactions.weight)::float8, NOW()
+    from (
+        select likes.blog_id, sqlc.arg(like_weight)::float8 as weight from likes where likes.created_at > NOW() - make_interval(hours => sqlc.arg(window_hours)::int)
+        union all
+        select comments.blog_id, sqlc.arg(comment_weight)::float8 from comments where comments.status = 'approved' and comments.created_at > NOW() - make_interval(hours => sqlc.arg(window_hours)::int)
+        union all
+        select blog_views.blog_id, sqlc.arg(view_weight)::float8 from blog_views where blog_views.viewed_at > NOW() - make_interval(hours => sqlc.arg(window_hours)::int)
+    ) as interactions
+    group by interactions.blog_id;
+
+-- name: GetRankedBlogs :many
+select blogs.id,
+    blogs.title,
+    blogs.author_id,
+    users.username as author_name,
+    blogs.thumbnail_url,
+    categories.category_name,
+    blog_rankings.score
+    from blog_rankings join blogs on blogs.id = blog_rankings.blog_id join users on users.id = blogs.author_id join categories on categories.id = blogs.category
+    where blog_rankings.ranking = sqlc.arg(ranking)
+        and (sqlc.narg(category)::uuid is null or blogs.category = sqlc.narg(category)::uuid)
+        and blogs.visibility = 'public' and blogs.is_published and blogs.moderation_status in ('visible', 'flagged')
+        and blogs.author_id not in (select user_blocks.blocked_id from user_blocks where user_blocks.blocker_id = sqlc.arg(viewer_id))
+        and blogs.author_id not in (select user_blocks.blocker_id from user_blocks where user_blocks.blocked_id = sqlc.arg(viewer_id))
+        and (blogs.author_id = sqlc.arg(viewer_id)
+            or not users.is_private
+            or blogs.author_id in (select users_follow.following_id from users_follow where users_follow.follower_id = sqlc.arg(viewer_id)))
+    order by blog_rankings.score desc
+    limit sqlc.arg('limit') offset sqlc.arg('offset');
@@ -0,0 +1,2 @@
+-- name: PublishRealtimeEvent :exec
+select pg_notify(sqlc.arg(channel)::text, sqlc.arg(payload)::text);
@@ -0,0 +1,106 @@
+-- name: DeleteUserRecommendations :exec
+delete from user_recommendations;
+
+-- name: ComputeUserRecommendations :exec
+insert into user_recommendations (user_id, recommended_id, score, reason, computed_at)
+select ranked.user_id, ranked.recommended_id, ranked.score, ranked.reason, NOW() from (
+    select candidates.user_id,
+        candidates.recommended_id,
+        sum(candidates.score)::float8 as score,
+        (array_agg(candidates.reason order by candidates.score desc))[1]::text as reason,
+        row_number() over (partition by candidates.user_id order by sum(candidates.score) desc) as rank
+    from (
+        select follows.follower_id as user_id, friends.following_id as recommended_id, 1.0::float8 as score, 'followed_by_people_you_follow'::text as reason
+            from users_follow as follows join users_follow as friends on friends.follower_id = follows.following_id
+        union all
+        select liked_categories.user_id, category_authors.author_id, 0.5 * liked_categories.likes_count, 'writes_in_categories_you_like'
+            from (select likes.user_id, blogs.category, count(*)::float8 as likes_count from likes join blogs on blogs.id = likes.blog_id group by likes.user_id, blogs.category) as liked_categories
+            join (select distinct blogs.category, blogs.author_id from blogs where blogs.visibility = 'public' and blogs.is_published and blogs.moderation_status in ('visible', 'flagged')) as category_authors
+            on category_authors.category = liked_categories.category
+        union all
+        select read_categories.user_id, category_authors.author_id, 0.2 * read_categories.views_count, 'writes_in_categories_you_read'
+            from (select substring(blog_views.viewer_key from 6)::uuid as user_id, blogs.category, count(*)::float8 as views_count from blog_views join blogs on blogs.id = blog_views.blog_id
+                where blog_views.viewer_key like 'user:%' group by blog_views.viewer_key, blogs.category) as read_categories
+            join (select distinct blogs.category, blogs.author_id from blogs where blogs.visibility = 'public' and blogs.is_published and blogs.moderation_status in ('visible', 'flagged')) as category_authors
+            on category_authors.category = read_categories.category
+    ) as candidates
+    where candidates.user_id <> candidates.recommended_id
+        and not exists (select 1 from users_follow where users_follow.follower_id = candidates.user_id and users_follow.following_id = candidates.recommended_id)
+        and not exists (select 1 from user_blocks where (user_blocks.blocker_id = candidates.user_id and user_blocks.blocked_id = candidates.recommended_id)
+            or (user_blocks.blocker_id = candidates.recommended_id and user_blocks.blocked_id = candidates.user_id))
+        and not exists (select 1 from user_mutes where user_mutes.muter_id = candidates.user_id and user_mutes.muted_id = candidates.recommended_id)
+    group by candidates.user_id, candidates.recommended_id
+) as ranked
+where ranked.rank <= sqlc.arg(max_per_user)::bigint;
+
+-- name: DeleteRelatedBlogs :exec
+delete from related_blogs;
+
+-- name: ComputeRelatedBlogs :exec
+with recent_likes as (
+    select ranked_likes.user_id, ranked_likes.blog_id from (
+        select likes.user_id, likes.blog_id, row_number() over (partition by likes.user_id order by likes.created_at desc) as position
+            from likes
+    ) as ranked_likes
+    where ranked_likes.position <= sqlc.arg(likes_per_user)::bigint
+)
+insert into related_blogs (blog_id, related_id, score, computed_at)
+select ranked.blog_id, ranked.related_id, ranked.score, NOW() from (
+    select candidates.blog_id,
+        candidates.related_id,
+        sum(candidates.score)::float8 as score,
+        row_number() over (partition by candidates.blog_id order by sum(candidates.score) desc) as rank
+    from (
+        select blogs.id as blog_id, same_category.id as related_id, 0.2::float8 as score
+            from blogs join (
+                select recent.id, recent.category from (
+                    select blogs.id, blogs.category, row_number() over (partition by blogs.category order by blogs.created_at desc) as position
+                        from blogs where blogs.visibility = 'public' and blogs.is_published and blogs.moderation_status in ('visible', 'flagged')
+                ) as recent
+                where recent.position <= sqlc.arg(candidates_per_category)::bigint
+            ) as same_category on same_category.category = blogs.category and same_category.id <> blogs.id
+        union all
+        select user_likes.blog_id, co_likes.blog_id, count(*)::float8 / sqrt(blog_likes.likes_count * related_likes.likes_count)
+            from recent_likes as user_likes join recent_likes as co_likes on co_likes.user_id = user_likes.user_id and co_likes.blog_id <> user_likes.blog_id
+            join (select likes.blog_id, count(*)::float8 as likes_count from likes group by likes.blog_id) as blog_likes on blog_likes.blog_id = user_likes.blog_id
+            join (select likes.blog_id, count(*)::float8 as likes_count from likes group by likes.blog_id) as related_likes on related_likes.blog_id = co_likes.blog_id
+            group by user_likes.blog_id, co_likes.blog_id, blog_likes.likes_count, related_likes.likes_count
+    ) as candidates
+    join blogs as related on related.id = candidates.related_id
+    where related.visibility = 'public' and related.is_published and related.moderation_status in ('visible', 'flagged')
+    group by candidates.blog_id, candidates.related_id
+) as ranked
+where ranked.rank <= sqlc.arg(max_per_blog)::bigint;
+
+-- name: GetUserRecommendations :many
+select users.id,
+    users.username,
+    users.display_name,
+    users.avatar_url,
+    user_recommendations.reason,
+    user_recommendations.score
+    from user_recommendations join users on users.id = user_recommendations.recommended_id
+    where user_recommendations.user_id = sqlc.arg(user_id)
+        and not exists (select 1 from users_follow where users_follow.follower_id = sqlc.arg(user_id) and users_follow.following_id = users.id)
+        and not exists (select 1 from user_blocks where (user_blocks.blocker_id = sqlc.arg(user_id) and user_blocks.blocked_id = users.id)
+            or (user_blocks.blocker_id = users.id and user_blocks.blocked_id = sqlc.arg(user_id)))
+    order by user_recommendations.score desc
+    limit sqlc.arg('limit') offset sqlc.arg('offset');
+
+-- name: GetRelatedBlogs :many
+select blogs.id,
+    blogs.title,
+    blogs.author_id,
+    users.username as author_name,
+    blogs.thumbnail_url,
+    related_blogs.score
+    from related_blogs join blogs on blogs.id = related_blogs.related_id join users on users.id = blogs.author_id
+    where related_blogs.blog_id = sqlc.arg(blog_id)
+        and blogs.visibility = 'public' and blogs.is_published and blogs.moderation_status in ('visible', 'flagged')
+        and blogs.author_id not in (select user_blocks.blocked_id from user_blocks where user_blocks.blocker_id = sqlc.arg(viewer_id))
+        and blogs.author_id not in (select user_blocks.blocker_id from user_blocks where user_blocks.blocked_id = sqlc.arg(viewer_id))
+        and (blogs.author_id = sqlc.arg(viewer_id)
+            or not users.is_private
+            or blogs.author_id in (select users_follow.following_id from users_follow where users_follow.follower_id = sqlc.arg(viewer_id)))
+    order by related_blogs.score desc
+    limit sqlc.arg('limit');
@@ -0,0 +1,45 @@
+-- name: CreateReport :one
+insert into reports (id, reporter_id, entity_type, entity_id, reason_code, details, created_at, updated_at)
+values (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    NOW(),
+    NOW()
+)
+returning *;
+
+-- name: GetReportById :one
+select * from reports where id = $1;
+
+-- name: GetReportsByStatus :many
+select * from reports where status = $1 order by created_at limit $2 offset $3;
+
+-- name: GetReportsByAssignee :many
+select * from reports where assignee_id = $1 and status = 'assigned' order by created_at limit $2 offset $3;
+
+-- name: AssignReport :one
+update reports set assignee_id = $1, status = 'assigned', updated_at = NOW() where id = $2 and status in ('open', 'assigned')
+returning *;
+
+-- name: CloseReport :one
+update reports set status = $1, action_taken = $2, resolution_note = $3, resolved_by = $4, resolved_at = NOW(), updated_at = NOW() where id = $5 and status in ('open', 'assigned')
+returning *;
+
+-- name: CreateUserWarning :one
+insert into user_warnings (id, user_id, moderator_id, report_id, message, created_at)
+values (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    NOW()
+)
+returning *;
+
+-- name: GetUserWarnings :many
+select * from user_warnings where user_id = $1 order by created_at desc;
@@ -0,0 +1,95 @@
+-- name: SubmitBlogForReview :one
+update blogs set review_status = 'pending', updated_at = NOW() where id = $1
+returning *;
+
+-- name: ClearReviewDecisions :exec
+update blog_reviewers set decision = null, decided_at = null, updated_at = NOW() where blog_id = $1;
+
+-- name: AssignBlogReviewer :one
+insert into blog_reviewers (blog_id, reviewer_id, assigned_by, created_at, updated_at)
+values ($1, $2, $3, NOW(), NOW())
+on conflict (blog_id, reviewer_id) do update set updated_at = NOW()
+returning *;
+
+-- name: UnassignBlogReviewer :execrows
+delete from blog_reviewers where blog_id = $1 and reviewer_id = $2;
+
+-- name: GetBlogReviewers :many
+select users.id,
+    users.username,
+    users.display_name,
+    users.avatar_url,
+    blog_reviewers.decision,
+    blog_reviewers.decided_at,
+    blog_reviewers.created_at
+    from blog_reviewers join users on users.id = blog_reviewers.reviewer_id
+    where blog_reviewers.blog_id = $1
+    order by blog_reviewers.created_at;
+
+-- name: GetReviewQueue :many
+select blogs.id,
+    blogs.title,
+    blogs.author_id,
+    users.username as author_name,
+    blogs.review_status,
+    blogs.updated_at,
+    blog_reviewers.decision
+    from blog_reviewers join blogs on blogs.id = blog_reviewers.blog_id join users on users.id = blogs.author_id
+    where blog_reviewers.reviewer_id = $1 and blogs.review_status = 'pending'
+    order by blogs.updated_at
+    limit sqlc.arg('limit') offset sqlc.arg('offset');
+
+-- name: DecideBlogReview :one
+update blog_reviewers set decision = $1, decided_at = NOW(), updated_at = NOW() where blog_id = $2 and reviewer_id = $3
+returning *;
+
+-- name: UpdateBlogReviewStatus :one
+update blogs set review_status = (case
+        when exists (select 1 from blog_reviewers where blog_reviewers.blog_id = blogs.id and blog_reviewers.decision = 'changes_requested') then 'changes_requested'
+        when exists (select 1 from blog_reviewers where blog_reviewers.blog_id = blogs.id and blog_reviewers.decision = 'approved') then 'approved'
+        else 'pending' end),
+    updated_at = NOW()
+    where blogs.id = $1
+returning review_status;
+
+-- name: ResetBlogReview :execrows
+update blogs set review_status = 'pending' where id = $1 and review_status = 'approved' and not is_published;
+
+-- name: UnpublishBlogForReview :one
+update blogs set is_published = false, review_status = 'pending', updated_at = NOW() where id = $1
+returning *;
+
+-- name: GetPublishedCategoryBlogs :many
+select id, is_published, review_status from blogs where category = $1 and is_published;
+
+-- name: PublishBlog :one
+update blogs set is_published = true, updated_at = NOW() where id = $1
+returning *;
+
+-- name: CreateReviewComment :one
+insert into review_comments (id, blog_id, user_id, body, anchor_start, anchor_end, quoted_text, created_at, updated_at)
+values (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    NOW(),
+    NOW()
+)
+returning *;
+
+-- name: GetReviewCommentById :one
+select * from review_comments where id = $1;
+
+-- name: GetReviewComments :many
+select review_comments.*, users.username
+    from review_comments join users on users.id = review_comments.user_id
+    where review_comments.blog_id = $1
+    order by review_comments.anchor_start, review_comments.created_at;
+
+-- name: SetReviewCommentResolved :one
+update review_comments set resolved = $1, updated_at = NOW() where id = $2
+returning *;
@@ -0,0 +1,58 @@
+-- name: CreateSeries :one
+insert into series (id, author_id, title, description, created_at, updated_at)
+values (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    NOW(),
+    NOW()
+)
+returning *;
+
+-- name: EditSeries :one
+update series set title = $1, description = $2, updated_at = NOW() where id = $3
+returning *;
+
+-- name: DeleteSeries :exec
+delete from series where id = $1;
+
+-- name: GetSeriesById :one
+select * from series where id = $1;
+
+-- name: GetSeriesByAuthorId :many
+select series.*,
+    (select count(*) from series_blogs where series_blogs.series_id = series.id) as parts_count
+    from series where series.author_id = $1
+    order by series.updated_at desc
+    limit sqlc.arg('limit') offset sqlc.arg('offset');
+
+-- name: GetSeriesByBlogId :one
+select series.* from series join series_blogs on series_blogs.series_id = series.id where series_blogs.blog_id = $1;
+
+-- name: AddBlogToSeries :one
+insert into series_blogs (series_id, blog_id, position, created_at)
+values (
+    sqlc.arg(series_id),
+    sqlc.arg(blog_id),
+    coalesce((select max(series_blogs.position) from series_blogs where series_blogs.series_id = sqlc.arg(series_id)), 0) + 1,
+    NOW()
+)
+returning *;
+
+-- name: RemoveBlogFromSeries :execrows
+delete from series_blogs where series_id = $1 and blog_id = $2;
+
+-- name: GetSeriesParts :many
+select blogs.id, blogs.title, blogs.thumbnail_url, blogs.moderation_status, blogs.visibility, blogs.is_published, blogs.created_at, series_blogs.position
+    from series_blogs join blogs on blogs.id = series_blogs.blog_id
+    where series_blogs.series_id = $1
+    order by series_blogs.position;
+
+-- name: ReorderSeries :exec
+update series_blogs set position = ordered.position
+    from unnest(sqlc.arg(blog_ids)::uuid[]) with ordinality as ordered(blog_id, position)
+    where series_blogs.series_id = sqlc.arg(series_id) and series_blogs.blog_id = ordered.blog_id;
+
+-- name: TouchSeries :exec
+update series set updated_at = NOW() where id = $1;
@@ -0,0 +1,10 @@
+-- name: GetSocialLinks :many
+select platform, url from user_social_links where user_id = $1 order by platform;
+
+-- name: UpsertSocialLink :exec
+insert into user_social_links (user_id, platform, url, created_at, updated_at)
+values ($1, $2, $3, NOW(), NOW())
+on conflict (user_id, platform) do update set url = excluded.url, updated_at = NOW();
+
+-- name: DeleteSocialLink :exec
+delete from user_social_links where user_id = $1 and platform = $2;
@@ -0,0 +1,14 @@
+-- name: RecordUsernameChange :exec
+insert into username_history (old_username, user_id, changed_at)
+values ($1, $2, NOW())
+on conflict (lower(old_username)) do update set old_username = excluded.old_username, user_id = excluded.user_id, changed_at = NOW();
+
+-- name: ReleaseUsername :exec
+delete from username_history where lower(old_username) = lower(sqlc.arg(username));
+
+-- name: IsUsernameHeld :one
+select exists(select 1 from username_history where lower(old_username) = lower(sqlc.arg(username)) and user_id <> sqlc.arg(user_id) and changed_at > sqlc.arg(held_since));
+
+-- name: GetCurrentUsernameByOldUsername :one
+select users.username from username_history join users on users.id = username_history.user_id
+    where lower(username_history.old_username) = lower(sqlc.arg(username));
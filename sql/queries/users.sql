@@ -25,10 +25,62 @@ delete from users where id = $1
 returning *;
 
 -- name: GetUsersByUsername :many
-select id, username from users where username = $1;
+select id, username from users where lower(username) = lower(sqlc.arg(username));
+
+-- name: SearchUsersByUsername :many
+select id, username from users where lower(username) = lower(sqlc.arg(username))
+    and not exists(
+        select 1 from user_blocks
+        where (blocker_id = sqlc.arg(viewer_id) and blocked_id = users.id)
+            or (blocker_id = users.id and blocked_id = sqlc.arg(viewer_id))
+    );
+
+-- name: GetUserIdByUsername :one
+select id from users where lower(username) = lower(sqlc.arg(username));
 
 -- name: GetUserFollowingList :many
 select following_id from users_follow where follower_id = $1;
 
 -- name: GetUserFeed :many
-select * from blogs where author_id = (select following_id from users_follow where follower_id = $1) order by created_at;
\ No newline at end of file
+select * from blogs where (blogs.author_id in (select users_follow.following_id from users_follow where users_follow.follower_id = $1)
+        or blogs.id in (select collection_blog.blog_id from collection_blog
+            join collection_follows on collection_follows.collection_id = collection_blog.collection_id
+            join collections on collections.id = collection_blog.collection_id
+            where collection_follows.user_id = $1 and collections.visibility <> 'private' and collection_blog.created_at >= collection_follows.created_at))
+    and blogs.is_published and blogs.moderation_status in ('visible', 'flagged')
+    and (blogs.visibility = 'public'
+        or (blogs.visibility = 'followers' and blogs.author_id in (select users_follow.following_id from users_follow where users_follow.follower_id = $1)))
+    and (blogs.author_id not in (select users.id from users where users.is_private)
+        or blogs.author_id in (select users_follow.following_id from users_follow where users_follow.follower_id = $1))
+    and blogs.author_id not in (select user_mutes.muted_id from user_mutes where user_mutes.muter_id = $1)
+    and blogs.author_id not in (select user_blocks.blocked_id from user_blocks where user_blocks.blocker_id = $1)
+    and blogs.author_id not in (select user_blocks.blocker_id from user_blocks where user_blocks.blocked_id = $1)
+    order by blogs.created_at;
+
+-- name: SuspendUser :exec
+update users set suspended_until = $1, updated_at = NOW() where id = $2;
+
+-- name: GetPublicProfileByUsername :one
+select users.id,
+    users.username,
+    users.display_name,
+    users.bio,
+    users.avatar_url,
+    users.website,
+    users.location,
+    users.is_private,
+    users.created_at,
+    (select count(*) from users_follow where users_follow.following_id = users.id) as followers_count,
+    (select count(*) from users_follow where users_follow.follower_id = users.id) as following_count,
+    (select count(*) from blogs where blogs.author_id = users.id and blogs.is_published and blogs.moderation_status in ('visible', 'flagged') and blogs.visibility in ('public', 'followers')) as posts_count
+    from users where lower(users.username) = lower(sqlc.arg(username));
+
+-- name: UpdateUserProfile :one
+update users set display_name = $1, bio = $2, avatar_url = $3, website = $4, location = $5, updated_at = NOW() where id = $6
+returning id, username, email, display_name, bio, avatar_url, website, location, created_at, updated_at;
+
+-- name: ChangeUsername :exec
+update users set username = $1, username_changed_at = NOW(), updated_at = NOW() where id = $2;
+
+-- name: SetUserPrivacy :exec
+update users set is_private = $1, updated_at = NOW() where id = $2;
@@ -6,4 +6,38 @@ values ($1, $2, NOW(), NOW());
 delete from users_follow where follower_id = $1 and following_id = $2;
 
 -- name: GetPair :one
-select * from users_follow where follower_id = $1 and following_id = $2;
\ No newline at end of file
+select * from users_follow where follower_id = $1 and following_id = $2;
+
+-- name: GetFollowers :many
+select users.id,
+    users.username,
+    users.display_name,
+    users.avatar_url,
+    users_follow.created_at as followed_at,
+    exists(select 1 from users_follow as viewer_follow where viewer_follow.follower_id = sqlc.arg(viewer_id) and viewer_follow.following_id = users.id) as followed_by_viewer,
+    exists(select 1 from users_follow as viewer_follow where viewer_follow.follower_id = users.id and viewer_follow.following_id = sqlc.arg(viewer_id)) as follows_viewer
+    from users_follow join users on users.id = users_follow.follower_id
+    where users_follow.following_id = sqlc.arg(user_id)
+    and users.id not in (select user_blocks.blocked_id from user_blocks where user_blocks.blocker_id = sqlc.arg(viewer_id))
+    and users.id not in (select user_blocks.blocker_id from user_blocks where user_blocks.blocked_id = sqlc.arg(viewer_id))
+    order by users_follow.created_at desc
+    limit sqlc.arg('limit') offset sqlc.arg('offset');
+
+-- name: GetFollowing :many
+select users.id,
+    users.username,
+    users.display_name,
+    users.avatar_url,
+    users_follow.created_at as followed_at,
+    exists(select 1 from users_follow as viewer_follow where viewer_follow.follower_id = sqlc.arg(viewer_id) and viewer_follow.following_id = users.id) as followed_by_viewer,
+    exists(select 1 from users_follow as viewer_follow where viewer_follow.follower_id = users.id and viewer_follow.following_id = sqlc.arg(viewer_id)) as follows_viewer
+    from users_follow join users on users.id = users_follow.following_id
+    where users_follow.follower_id = sqlc.arg(user_id)
+    and users.id not in (select user_blocks.blocked_id from user_blocks where user_blocks.blocker_id = sqlc.arg(viewer_id))
+    and users.id not in (select user_blocks.blocker_id from user_blocks where user_blocks.blocked_id = sqlc.arg(viewer_id))
+    order by users_follow.created_at desc
+    limit sqlc.arg('limit') offset sqlc.arg('offset');
+
+-- name: GetRelationship :one
+select exists(select 1 from users_follow where users_follow.follower_id = sqlc.arg(viewer_id) and users_follow.following_id = sqlc.arg(user_id)) as following,
+    exists(select 1 from users_follow where users_follow.follower_id = sqlc.arg(user_id) and users_follow.following_id = sqlc.arg(viewer_id)) as followed_by;
@@ -0,0 +1,20 @@
+-- +goose Up
+alter table users add column role text not null default 'user' check (role in ('user', 'moderator', 'admin'));
+
+create table blog_comment_settings (
+    blog_id uuid primary key references blogs(id) on delete cascade,
+    comment_policy text not null default 'open' check (comment_policy in ('open', 'closed', 'followers_only')),
+    require_approval boolean not null default false,
+    created_at timestamp not null,
+    updated_at timestamp not null
+);
+
+alter table comments add column status text not null default 'approved' check (status in ('pending', 'approved', 'rejected', 'hidden')),
+add column is_pinned boolean not null default false,
+add column moderated_by uuid references users(id) on delete set null,
+add column moderated_at timestamp;
+
+-- +goose Down
+alter table comments drop column moderated_at, drop column moderated_by, drop column is_pinned, drop column status;
+drop table blog_comment_settings;
+alter table users drop column role;
@@ -0,0 +1,24 @@
+-- +goose Up
+alter table comments drop constraint comments_status_check,
+add constraint comments_status_check check (status in ('pending', 'approved', 'rejected', 'hidden', 'shadow_hidden'));
+
+alter table blogs add column moderation_status text not null default 'visible' check (moderation_status in ('visible', 'flagged', 'shadow_hidden', 'hidden'));
+
+create table content_flags (
+    id uuid primary key,
+    entity_type text not null check (entity_type in ('blog', 'comment')),
+    entity_id uuid not null,
+    user_id uuid not null references users(id) on delete cascade,
+    action text not null,
+    reason text not null,
+    reviewed_by uuid references users(id) on delete set null,
+    reviewed_at timestamp,
+    created_at timestamp not null,
+    updated_at timestamp not null
+);
+
+-- +goose Down
+drop table content_flags;
+alter table blogs drop column moderation_status;
+alter table comments drop constraint comments_status_check,
+add constraint comments_status_check check (status in ('pending', 'approved', 'rejected', 'hidden'));
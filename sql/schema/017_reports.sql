@@ -0,0 +1,33 @@
+-- +goose Up
+create table reports (
+    id uuid primary key,
+    reporter_id uuid not null references users(id) on delete cascade,
+    entity_type text not null check (entity_type in ('blog', 'comment', 'user')),
+    entity_id uuid not null,
+    reason_code text not null check (reason_code in ('spam', 'harassment', 'hate_speech', 'sexual_content', 'violence', 'misinformation', 'impersonation', 'other')),
+    details text not null default '',
+    status text not null default 'open' check (status in ('open', 'assigned', 'resolved', 'dismissed')),
+    assignee_id uuid references users(id) on delete set null,
+    action_taken text not null default '',
+    resolution_note text not null default '',
+    resolved_by uuid references users(id) on delete set null,
+    resolved_at timestamp,
+    created_at timestamp not null,
+    updated_at timestamp not null
+);
+
+alter table users add column suspended_until timestamp;
+
+create table user_warnings (
+    id uuid primary key,
+    user_id uuid not null references users(id) on delete cascade,
+    moderator_id uuid references users(id) on delete set null,
+    report_id uuid references reports(id) on delete set null,
+    message text not null,
+    created_at timestamp not null
+);
+
+-- +goose Down
+drop table user_warnings;
+alter table users drop column suspended_until;
+drop table reports;
@@ -0,0 +1,27 @@
+-- +goose Up
+create table mentions (
+    id uuid primary key,
+    source_type text not null check (source_type in ('blog', 'comment')),
+    source_id uuid not null,
+    mentioned_user_id uuid not null references users(id) on delete cascade,
+    mentioner_id uuid not null references users(id) on delete cascade,
+    created_at timestamp not null,
+    unique(source_type, source_id, mentioned_user_id)
+);
+
+create table notifications (
+    id uuid primary key,
+    user_id uuid not null references users(id) on delete cascade,
+    actor_id uuid not null references users(id) on delete cascade,
+    type text not null,
+    entity_type text not null,
+    entity_id uuid not null,
+    read_at timestamp,
+    created_at timestamp not null
+);
+
+create index notifications_user_id_idx on notifications(user_id, created_at);
+
+-- +goose Down
+drop table notifications;
+drop table mentions;
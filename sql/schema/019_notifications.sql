@@ -0,0 +1,18 @@
+-- +goose Up
+create table notification_preferences (
+    user_id uuid not null references users(id) on delete cascade,
+    type text not null,
+    enabled boolean not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    primary key(user_id, type)
+);
+
+create index notifications_group_idx on notifications(user_id, type, entity_type, entity_id);
+
+alter table comments add column parent_id uuid references comments(id) on delete cascade;
+
+-- +goose Down
+alter table comments drop column parent_id;
+drop index notifications_group_idx;
+drop table notification_preferences;
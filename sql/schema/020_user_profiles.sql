@@ -0,0 +1,23 @@
+-- +goose Up
+alter table users add column display_name text not null default '',
+    add column bio text not null default '',
+    add column avatar_url text not null default '',
+    add column website text not null default '',
+    add column location text not null default '';
+
+create table user_social_links(
+    user_id uuid not null references users(id) on delete cascade,
+    platform text not null,
+    url text not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    primary key(user_id, platform)
+);
+
+-- +goose Down
+drop table user_social_links;
+alter table users drop column display_name,
+    drop column bio,
+    drop column avatar_url,
+    drop column website,
+    drop column location;
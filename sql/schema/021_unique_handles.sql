@@ -0,0 +1,20 @@
+-- +goose Up
+-- keeping the oldest account on every case-insensitive collision and renaming the others
+update users set username = users.username || '_' || substr(replace(users.id::text, '-', ''), 1, 6)
+    from (select id, row_number() over (partition by lower(username) order by created_at) as position from users) as ranked
+    where users.id = ranked.id and ranked.position > 1;
+
+create unique index users_username_lower_idx on users (lower(username));
+alter table users add column username_changed_at timestamp;
+
+create table username_history(
+    old_username text not null,
+    user_id uuid not null references users(id) on delete cascade,
+    changed_at timestamp not null
+);
+create unique index username_history_old_username_idx on username_history (lower(old_username));
+
+-- +goose Down
+drop table username_history;
+alter table users drop column username_changed_at;
+drop index users_username_lower_idx;
@@ -0,0 +1,6 @@
+-- +goose Up
+delete from users_follow where follower_id = following_id;
+alter table users_follow add constraint no_self_follow check (follower_id <> following_id);
+
+-- +goose Down
+alter table users_follow drop constraint no_self_follow;
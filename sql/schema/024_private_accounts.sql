@@ -0,0 +1,15 @@
+-- +goose Up
+alter table users add column is_private boolean not null default false;
+
+create table follow_requests(
+    requester_id uuid not null references users(id) on delete cascade,
+    target_id uuid not null references users(id) on delete cascade,
+    created_at timestamp not null,
+    primary key(requester_id, target_id),
+    check (requester_id <> target_id)
+);
+create index follow_requests_target_idx on follow_requests(target_id, created_at);
+
+-- +goose Down
+drop table follow_requests;
+alter table users drop column is_private;
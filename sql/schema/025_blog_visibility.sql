@@ -0,0 +1,5 @@
+-- +goose Up
+alter table blogs add column visibility text not null default 'public' check (visibility in ('public', 'unlisted', 'followers', 'private'));
+
+-- +goose Down
+alter table blogs drop column visibility;
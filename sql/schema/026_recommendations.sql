@@ -0,0 +1,23 @@
+-- +goose Up
+create table user_recommendations (
+    user_id uuid not null references users(id) on delete cascade,
+    recommended_id uuid not null references users(id) on delete cascade,
+    score double precision not null,
+    reason text not null,
+    computed_at timestamp not null,
+    primary key(user_id, recommended_id)
+);
+create index user_recommendations_score_idx on user_recommendations(user_id, score desc);
+
+create table related_blogs (
+    blog_id uuid not null references blogs(id) on delete cascade,
+    related_id uuid not null references blogs(id) on delete cascade,
+    score double precision not null,
+    computed_at timestamp not null,
+    primary key(blog_id, related_id)
+);
+create index related_blogs_score_idx on related_blogs(blog_id, score desc);
+
+-- +goose Down
+drop table related_blogs;
+drop table user_recommendations;
@@ -0,0 +1,16 @@
+-- +goose Up
+create table blog_rankings (
+    ranking text not null,
+    blog_id uuid not null references blogs(id) on delete cascade,
+    score double precision not null,
+    computed_at timestamp not null,
+    primary key(ranking, blog_id)
+);
+create index blog_rankings_score_idx on blog_rankings(ranking, score desc);
+create index likes_created_at_idx on likes(created_at);
+create index comments_created_at_idx on comments(created_at);
+
+-- +goose Down
+drop index comments_created_at_idx;
+drop index likes_created_at_idx;
+drop table blog_rankings;
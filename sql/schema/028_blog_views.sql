@@ -0,0 +1,12 @@
+-- +goose Up
+create table blog_views (
+    blog_id uuid not null references blogs(id) on delete cascade,
+    viewer_key text not null,
+    view_window bigint not null,
+    viewed_at timestamp not null,
+    primary key(blog_id, viewer_key, view_window)
+);
+create index blog_views_viewed_at_idx on blog_views(blog_id, viewed_at);
+
+-- +goose Down
+drop table blog_views;
@@ -0,0 +1,16 @@
+-- +goose Up
+alter table collections add column visibility text not null default 'private' check (visibility in ('public', 'unlisted', 'private')),
+add column share_token text unique;
+
+create table collection_follows (
+    user_id uuid not null references users(id) on delete cascade,
+    collection_id uuid not null references collections(id) on delete cascade,
+    created_at timestamp not null,
+    primary key(user_id, collection_id)
+);
+create index collection_follows_collection_idx on collection_follows(collection_id);
+
+-- +goose Down
+drop table collection_follows;
+alter table collections drop column share_token,
+drop column visibility;
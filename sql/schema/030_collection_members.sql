@@ -0,0 +1,18 @@
+-- +goose Up
+create table collection_members (
+    collection_id uuid not null references collections(id) on delete cascade,
+    user_id uuid not null references users(id) on delete cascade,
+    role text not null check (role in ('editor', 'viewer')),
+    status text not null check (status in ('invited', 'accepted')),
+    invited_by uuid references users(id) on delete set null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    primary key(collection_id, user_id)
+);
+create index collection_members_user_idx on collection_members(user_id);
+
+alter table collection_blog add column added_by uuid references users(id) on delete set null;
+
+-- +goose Down
+alter table collection_blog drop column added_by;
+drop table collection_members;
@@ -0,0 +1,18 @@
+-- +goose Up
+alter table collections add column description text not null default '',
+add column cover_url text not null default '';
+
+alter table collection_blog add column position bigint,
+add column note text not null default '';
+update collection_blog set position = ranked.rank * 1024
+    from (select collection_id, blog_id, row_number() over (partition by collection_id order by created_at) as rank from collection_blog) as ranked
+    where collection_blog.collection_id = ranked.collection_id and collection_blog.blog_id = ranked.blog_id;
+alter table collection_blog alter column position set not null;
+create index collection_blog_position_idx on collection_blog(collection_id, position);
+
+-- +goose Down
+drop index collection_blog_position_idx;
+alter table collection_blog drop column note,
+drop column position;
+alter table collections drop column cover_url,
+drop column description;
@@ -0,0 +1,23 @@
+-- +goose Up
+create table series (
+    id uuid not null primary key,
+    author_id uuid not null references users(id) on delete cascade,
+    title text not null,
+    description text not null default '',
+    created_at timestamp not null,
+    updated_at timestamp not null
+);
+create index series_author_idx on series(author_id);
+
+create table series_blogs (
+    series_id uuid not null references series(id) on delete cascade,
+    blog_id uuid not null unique references blogs(id) on delete cascade,
+    position int not null,
+    created_at timestamp not null,
+    primary key(series_id, blog_id)
+);
+create index series_blogs_position_idx on series_blogs(series_id, position);
+
+-- +goose Down
+drop table series_blogs;
+drop table series;
@@ -0,0 +1,15 @@
+-- +goose Up
+create table blog_contributors (
+    blog_id uuid not null references blogs(id) on delete cascade,
+    user_id uuid not null references users(id) on delete cascade,
+    role text not null check (role in ('co-author', 'editor', 'reviewer')),
+    status text not null check (status in ('invited', 'accepted')),
+    invited_by uuid references users(id) on delete set null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    primary key(blog_id, user_id)
+);
+create index blog_contributors_user_idx on blog_contributors(user_id);
+
+-- +goose Down
+drop table blog_contributors;
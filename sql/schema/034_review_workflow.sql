@@ -0,0 +1,39 @@
+-- +goose Up
+alter table categories add column requires_review boolean not null default false;
+
+alter table blogs add column is_published boolean not null default true,
+add column review_status text not null default 'none' check (review_status in ('none', 'pending', 'changes_requested', 'approved'));
+
+create table blog_reviewers (
+    blog_id uuid not null references blogs(id) on delete cascade,
+    reviewer_id uuid not null references users(id) on delete cascade,
+    assigned_by uuid references users(id) on delete set null,
+    decision text check (decision in ('approved', 'changes_requested')),
+    decided_at timestamp,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    primary key(blog_id, reviewer_id)
+);
+create index blog_reviewers_reviewer_idx on blog_reviewers(reviewer_id);
+
+create table review_comments (
+    id uuid not null primary key,
+    blog_id uuid not null references blogs(id) on delete cascade,
+    user_id uuid not null references users(id) on delete cascade,
+    body text not null,
+    anchor_start int not null,
+    anchor_end int not null,
+    quoted_text text not null,
+    resolved boolean not null default false,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    check (anchor_start >= 0 and anchor_end >= anchor_start)
+);
+create index review_comments_blog_idx on review_comments(blog_id, created_at);
+
+-- +goose Down
+drop table review_comments;
+drop table blog_reviewers;
+alter table blogs drop column review_status,
+drop column is_published;
+alter table categories drop column requires_review;
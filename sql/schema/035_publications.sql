@@ -0,0 +1,38 @@
+-- +goose Up
+create table publications (
+    id uuid not null primary key,
+    slug text not null unique,
+    name text not null,
+    description text not null default '',
+    created_at timestamp not null,
+    updated_at timestamp not null
+);
+
+create table publication_members (
+    publication_id uuid not null references publications(id) on delete cascade,
+    user_id uuid not null references users(id) on delete cascade,
+    role text not null check (role in ('owner', 'editor', 'writer')),
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    primary key(publication_id, user_id)
+);
+create index publication_members_user_idx on publication_members(user_id);
+create unique index publication_members_owner_idx on publication_members(publication_id) where role = 'owner';
+
+alter table categories add column publication_id uuid references publications(id) on delete cascade;
+alter table categories drop constraint categories_category_name_key;
+create unique index categories_global_name_idx on categories(category_name) where publication_id is null;
+create unique index categories_publication_name_idx on categories(publication_id, category_name) where publication_id is not null;
+
+alter table blogs add column publication_id uuid references publications(id) on delete cascade;
+create index blogs_publication_idx on blogs(publication_id, created_at desc);
+
+-- +goose Down
+drop index blogs_publication_idx;
+alter table blogs drop column publication_id;
+drop index categories_publication_name_idx;
+drop index categories_global_name_idx;
+alter table categories drop column publication_id;
+alter table categories add constraint categories_category_name_key unique (category_name);
+drop table publication_members;
+drop table publications;
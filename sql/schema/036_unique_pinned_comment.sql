@@ -0,0 +1,7 @@
+-- +goose Up
+update comments set is_pinned = false
+where is_pinned and id not in (select distinct on (blog_id) id from comments where is_pinned order by blog_id, updated_at desc);
+create unique index comments_pinned_idx on comments(blog_id) where is_pinned;
+
+-- +goose Down
+drop index comments_pinned_idx;
@@ -0,0 +1,7 @@
+-- +goose Up
+alter table publication_members add column status text not null default 'accepted' check (status in ('invited', 'accepted')),
+add column invited_by uuid references users(id) on delete set null;
+
+-- +goose Down
+delete from publication_members where status = 'invited';
+alter table publication_members drop column invited_by, drop column status;
@@ -0,0 +1,5 @@
+-- +goose Up
+update notifications set entity_id = user_id where type in ('follow', 'follow_request', 'follow_accepted');
+
+-- +goose Down
+update notifications set entity_id = actor_id where type in ('follow', 'follow_request', 'follow_accepted');
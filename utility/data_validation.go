@@ -1 +1,235 @@
 package utility
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// limits of the profile fields
+const (
+	MaxDisplayNameLength = 50
+	MaxBioLength         = 300
+	MaxLocationLength    = 100
+	MaxURLLength         = 2048
+)
+
+// limits of the collection fields
+const (
+	MaxCollectionDescriptionLength = 2000
+	MaxCollectionNoteLength        = 1000
+)
+
+// limits of the series fields
+const (
+	MaxSeriesTitleLength       = 150
+	MaxSeriesDescriptionLength = 2000
+)
+
+// limits of the publication fields
+const (
+	MinPublicationSlugLength        = 3
+	MaxPublicationSlugLength        = 50
+	MaxPublicationNameLength        = 100
+	MaxPublicationDescriptionLength = 2000
+)
+
+// maximum length of a review comment
+const MaxReviewCommentLength = 2000
+
+// limits of a username
+const (
+	MinUsernameLength = 3
+	MaxUsernameLength = 30
+)
+
+// ReservedUsernames cannot be taken by anyone as they clash with routes or could be used to impersonate the staff
+var ReservedUsernames = map[string]bool{
+	"about":          true,
+	"admin":          true,
+	"administrator":  true,
+	"all":            true,
+	"api":            true,
+	"block":          true,
+	"blocked":        true,
+	"blogs":          true,
+	"deleteaccount":  true,
+	"feeds":          true,
+	"followrequests": true,
+	"help":           true,
+	"login":          true,
+	"logout":         true,
+	"me":             true,
+	"mentions":       true,
+	"moderator":      true,
+	"mute":           true,
+	"muted":          true,
+	"notifications":  true,
+	"null":           true,
+	"register":       true,
+	"root":           true,
+	"search":         true,
+	"settings":       true,
+	"signup":         true,
+	"staff":          true,
+	"support":        true,
+	"system":         true,
+	"unblock":        true,
+	"unmute":         true,
+	"updateprofile":  true,
+	"warnings":       true,
+}
+
+// ValidateUsername checks that the username only has letters, digits and underscores, starts with a letter and is not reserved
+func ValidateUsername(username string) error {
+	if len(username) < MinUsernameLength || len(username) > MaxUsernameLength {
+		return fmt.Errorf("username must be between %d and %d characters", MinUsernameLength, MaxUsernameLength)
+	}
+	for position, character := range username {
+		isLetter := (character >= 'a' && character <= 'z') || (character >= 'A' && character <= 'Z')
+		isDigit := character >= '0' && character <= '9'
+		if position == 0 && !isLetter {
+			return errors.New("username must start with a letter")
+		}
+		if !isLetter && !isDigit && character != '_' {
+			return errors.New("username can only contain letters, digits and underscores")
+		}
+	}
+	if ReservedUsernames[strings.ToLower(username)] {
+		return errors.New("username is reserved")
+	}
+	return nil
+}
+
+// SocialPlatforms lists the platforms a user can link to from their profile
+var SocialPlatforms = []string{
+	"github",
+	"instagram",
+	"linkedin",
+	"mastodon",
+	"twitter",
+	"youtube",
+}
+
+// function to check that a text field is not too long and has no control characters
+func validateText(field string, value string, maxLength int) error {
+	if utf8.RuneCountInString(value) > maxLength {
+		return fmt.Errorf("%s must be at most %d characters", field, maxLength)
+	}
+	for _, character := range value {
+		if unicode.IsControl(character) && character != '\n' {
+			return fmt.Errorf("%s contains invalid characters", field)
+		}
+	}
+	return nil
+}
+
+func ValidateDisplayName(displayName string) error {
+	if strings.Contains(displayName, "\n") {
+		return errors.New("display name must be a single line")
+	}
+	return validateText("display name", displayName, MaxDisplayNameLength)
+}
+
+func ValidateBio(bio string) error {
+	return validateText("bio", bio, MaxBioLength)
+}
+
+func ValidateLocation(location string) error {
+	if strings.Contains(location, "\n") {
+		return errors.New("location must be a single line")
+	}
+	return validateText("location", location, MaxLocationLength)
+}
+
+func ValidateCollectionDescription(description string) error {
+	return validateText("description", description, MaxCollectionDescriptionLength)
+}
+
+func ValidateCollectionNote(note string) error {
+	return validateText("note", note, MaxCollectionNoteLength)
+}
+
+func ValidateSeriesTitle(title string) error {
+	if len(title) == 0 {
+		return errors.New("title is required")
+	}
+	if strings.Contains(title, "\n") {
+		return errors.New("title must be a single line")
+	}
+	return validateText("title", title, MaxSeriesTitleLength)
+}
+
+func ValidateSeriesDescription(description string) error {
+	return validateText("description", description, MaxSeriesDescriptionLength)
+}
+
+// ValidatePublicationSlug checks that the slug only has lowercase letters, digits and hyphens and does not clash with the publication routes
+func ValidatePublicationSlug(slug string) error {
+	if len(slug) < MinPublicationSlugLength || len(slug) > MaxPublicationSlugLength {
+		return fmt.Errorf("slug must be between %d and %d characters", MinPublicationSlugLength, MaxPublicationSlugLength)
+	}
+	for position, character := range slug {
+		isLetter := character >= 'a' && character <= 'z'
+		isDigit := character >= '0' && character <= '9'
+		if (position == 0 || position == len(slug)-1) && character == '-' {
+			return errors.New("slug cannot start or end with a hyphen")
+		}
+		if !isLetter && !isDigit && character != '-' {
+			return errors.New("slug can only contain lowercase letters, digits and hyphens")
+		}
+	}
+	if slug == "create" || slug == "edit" || slug == "mine" || slug == "invites" {
+		return errors.New("slug is reserved")
+	}
+	return nil
+}
+
+func ValidatePublicationName(name string) error {
+	if len(name) == 0 {
+		return errors.New("name is required")
+	}
+	if strings.Contains(name, "\n") {
+		return errors.New("name must be a single line")
+	}
+	return validateText("name", name, MaxPublicationNameLength)
+}
+
+func ValidatePublicationDescription(description string) error {
+	return validateText("description", description, MaxPublicationDescriptionLength)
+}
+
+func ValidateReviewComment(body string) error {
+	if len(body) == 0 {
+		return errors.New("comment is required")
+	}
+	return validateText("comment", body, MaxReviewCommentLength)
+}
+
+// ValidateURL checks that the link is an absolute http or https url, an empty link clears the field
+func ValidateURL(field string, link string) error {
+	if len(link) == 0 {
+		return nil
+	}
+	if len(link) > MaxURLLength {
+		return fmt.Errorf("%s must be at most %d characters", field, MaxURLLength)
+	}
+	parsedURL, err := url.Parse(link)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || len(parsedURL.Host) == 0 {
+		return fmt.Errorf("%s must be a valid http or https url", field)
+	}
+	return nil
+}
+
+// ValidateSocialLink checks that the platform is supported and the link is a valid url
+func ValidateSocialLink(platform string, link string) error {
+	for _, socialPlatform := range SocialPlatforms {
+		if socialPlatform == platform {
+			return ValidateURL(platform+" link", link)
+		}
+	}
+	return fmt.Errorf("unsupported social platform: %s", platform)
+}
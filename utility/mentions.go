@@ -0,0 +1,49 @@
+package utility
+
+import (
+	"regexp"
+	"strings"
+)
+
+// a mention is an @ followed by a username which is not part of an email address or another word
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@.])@([A-Za-z0-9_]{1,30})\b`)
+
+// function to extract the unique usernames mentioned in the text in the order they appear
+// usernames are case-insensitive so only the first spelling of a username is kept
+func ExtractMentions(text string) []string {
+	var usernames []string
+	seen := make(map[string]bool)
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		username := match[1]
+		if !seen[strings.ToLower(username)] {
+			seen[strings.ToLower(username)] = true
+			usernames = append(usernames, username)
+		}
+	}
+
+	return usernames
+}
+
+// function to render the mentions of the given usernames as markdown links to their profiles
+// the set holds lowercase usernames, mentions of usernames not in the set are left as plain text
+func RenderMentions(text string, usernames map[string]bool) string {
+	if len(usernames) == 0 {
+		return text
+	}
+
+	var rendered strings.Builder
+	last := 0
+	for _, match := range mentionPattern.FindAllStringSubmatchIndex(text, -1) {
+		// match[2] and match[3] are the bounds of the username, the @ is right before it
+		username := text[match[2]:match[3]]
+		if !usernames[strings.ToLower(username)] {
+			continue
+		}
+		rendered.WriteString(text[last : match[2]-1])
+		rendered.WriteString("[@" + username + "](/users/" + username + ")")
+		last = match[3]
+	}
+	rendered.WriteString(text[last:])
+
+	return rendered.String()
+}
@@ -1,6 +1,7 @@
 package utility
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"log"
 	"net/http"
@@ -32,3 +33,15 @@ func RespondWithJson(w http.ResponseWriter, code int, payload interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.Write(data)
 }
+
+func RespondWithCSV(w http.ResponseWriter, filename string, records [][]string) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	err := writer.WriteAll(records)
+	if err != nil {
+		log.Println("Error encoding into csv: ", err)
+	}
+}